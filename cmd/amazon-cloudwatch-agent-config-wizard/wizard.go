@@ -53,22 +53,50 @@ func main() {
 	configOutputPath = flag.String("configOutputPath", "", "Specifies where to write the configuration file generated by the wizard")
 	parameterStoreName := flag.String("parameterStoreName", "", "The parameter store name. Default is AmazonCloudWatch-windows")
 	parameterStoreRegion := flag.String("parameterStoreRegion", "", "The parameter store region. Default is us-east-1")
+	answersFile := flag.String("answersFile", "",
+		"The path of a YAML or JSON file mapping the wizard questions to their answers. If set, the wizard runs non-interactively and fails on unanswered questions.")
 
 	flag.Parse()
 
+	if *answersFile != "" {
+		if *isNonInteractiveWindowsMigration {
+			fmt.Println("E! answersFile cannot be used with isNonInteractiveWindowsMigration")
+			os.Exit(1)
+		}
+		answers, err := util.ReadAnswers(*answersFile)
+		if err != nil {
+			fmt.Printf("E! Failed to read the answers file: %v\n", err)
+			os.Exit(1)
+		}
+		err = runWithAnswers(answers, func() {
+			run(*isNonInteractiveLinuxMigration, *tracesOnly, *configFilePath)
+		})
+		if err != nil {
+			fmt.Printf("E! %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if *isNonInteractiveWindowsMigration {
 		addWindowsMigrationInputs(*configFilePath, *parameterStoreName, *parameterStoreRegion, *useParameterStore)
-	} else if *isNonInteractiveLinuxMigration {
+	}
+	run(*isNonInteractiveLinuxMigration, *tracesOnly, *configFilePath)
+}
+
+// run runs the processors of the linux migration, the traces configuration or the wizard.
+func run(isNonInteractiveLinuxMigration, tracesOnly bool, configFilePath string) {
+	if isNonInteractiveLinuxMigration {
 		ctx := new(runtime.Context)
 		config := new(data.Config)
 		ctx.HasExistingLinuxConfig = true
-		ctx.ConfigFilePath = *configFilePath
+		ctx.ConfigFilePath = configFilePath
 		if ctx.ConfigFilePath == "" {
 			ctx.ConfigFilePath = linux.DefaultFilePathLinuxConfiguration
 		}
 		process(ctx, config, linux.Processor, serialization.Processor)
 		return
-	} else if *tracesOnly {
+	} else if tracesOnly {
 		ctx := new(runtime.Context)
 		config := new(data.Config)
 		ctx.TracesOnly = true
@@ -97,6 +125,30 @@ func init() {
 	processors.StartProcessor = basicInfo.Processor
 }
 
+// runWithAnswers runs the wizard with the answers instead of stdin. It returns an error for the
+// first question without a valid answer.
+func runWithAnswers(answers *util.Answers, run func()) (err error) {
+	util.SetAnswers(answers)
+	defer util.SetAnswers(nil)
+	defer func() {
+		if r := recover(); r != nil {
+			switch e := r.(type) {
+			case *util.UnansweredError:
+				err = e
+			case *util.InvalidAnswerError:
+				err = e
+			default:
+				panic(r)
+			}
+		}
+	}()
+	run()
+	for _, question := range answers.Unused() {
+		fmt.Printf("W! The answer to question %q was not used\n", question)
+	}
+	return nil
+}
+
 func addWindowsMigrationInputs(configFilePath string, parameterStoreName string, parameterStoreRegion string, useParameterStore bool) {
 	inputChan := testutil.SetUpTestInputStream()
 	if useParameterStore {
@@ -9,7 +9,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/aws/amazon-cloudwatch-agent/tool/data"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/agentconfig"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/basicInfo"
@@ -20,6 +22,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/statsd"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/template"
 	"github.com/aws/amazon-cloudwatch-agent/tool/processors/tracesconfig"
+	"github.com/aws/amazon-cloudwatch-agent/tool/runtime"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
@@ -76,3 +79,36 @@ func TestWindowsMigration(t *testing.T) {
 		t.Errorf("The generated new config is incorrect, got:\n '%v'\n, want:\n '%v'.\n", actualConfig, expectedConfig)
 	}
 }
+
+func TestRunWithAnswers(t *testing.T) {
+	answers, err := util.ParseAnswers([]byte(`
+"Do you want to turn on StatsD daemon?": yes
+"Which port do you want StatsD daemon to listen to?": 8126
+"What is the collect interval for StatsD daemon?": 30s
+"What is the aggregation interval for metrics collected by StatsD daemon?": ""
+`))
+	require.NoError(t, err)
+	config := new(data.Config)
+	err = runWithAnswers(answers, func() {
+		process(new(runtime.Context), config, statsd.Processor)
+	})
+	require.NoError(t, err)
+	got := config.MetricsConf().Collection().StatsD
+	assert.Equal(t, ":8126", got.ServiceAddress)
+	assert.Equal(t, 30, got.MetricsCollectionInterval)
+	assert.Equal(t, 60, got.MetricsAggregationInterval)
+
+	answers, err = util.ParseAnswers([]byte(`"Do you want to turn on StatsD daemon?": yes`))
+	require.NoError(t, err)
+	err = runWithAnswers(answers, func() {
+		process(new(runtime.Context), new(data.Config), statsd.Processor)
+	})
+	assert.EqualError(t, err, `no answer for question "Which port do you want StatsD daemon to listen to?"`)
+
+	answers, err = util.ParseAnswers([]byte(`"Do you want to turn on StatsD daemon?": maybe`))
+	require.NoError(t, err)
+	err = runWithAnswers(answers, func() {
+		process(new(runtime.Context), new(data.Config), statsd.Processor)
+	})
+	assert.ErrorContains(t, err, `answer "maybe" is not valid for question "Do you want to turn on StatsD daemon?"`)
+}
@@ -0,0 +1,209 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/merge/confmap"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/service/admin"
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+)
+
+const (
+	adminCommand = "admin"
+	// logAgentPipeline is the name of the pipeline of the log files sent by the logs agent.
+	logAgentPipeline = "logagent"
+
+	adminShutdownTimeout = 5 * time.Second
+	adminRequestTimeout  = time.Minute
+)
+
+// adminEndpoints are the admin API endpoints called by the admin subcommand.
+var adminEndpoints = map[string]struct{ method, path string }{
+	"status":    {method: http.MethodGet, path: admin.PathStatus},
+	"config":    {method: http.MethodGet, path: admin.PathConfig},
+	"pipelines": {method: http.MethodGet, path: admin.PathPipelines},
+	"flush":     {method: http.MethodPost, path: admin.PathFlush},
+	"reload":    {method: http.MethodPost, path: admin.PathReload},
+}
+
+// adminAgent is the running agent served by the admin API.
+type adminAgent struct {
+	telegraf *config.Config
+	// otelConfigs are the OTel configs of the collector, which is not running in logs-only mode.
+	otelConfigs []string
+	restart     func()
+}
+
+var _ admin.Agent = (*adminAgent)(nil)
+
+// Config returns the translated TOML config, the merged OTel config and the json config
+// fragments, which are read again since they may have been hot reloaded.
+func (a *adminAgent) Config() (*admin.Config, error) {
+	toml, err := os.ReadFile(*fTomlConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the toml config: %w", err)
+	}
+	c := &admin.Config{Toml: string(toml)}
+	// the fragments are only written when the config was translated from json
+	if fragments, err := os.ReadFile(cmdutil.FragmentsPath(*fTomlConfig)); err == nil {
+		c.Fragments = fragments
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("unable to read the json config fragments: %w", err)
+	}
+	if len(a.otelConfigs) > 0 {
+		if c.Otel, err = loadOtelConfig(a.otelConfigs); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Pipelines returns the OTel pipelines and the pipeline of the logs agent.
+func (a *adminAgent) Pipelines() (map[string]admin.Pipeline, error) {
+	c, err := a.Config()
+	if err != nil {
+		return nil, err
+	}
+	pipelines := otelPipelines(c.Otel)
+	if pipeline, ok := logAgentPipelineOf(a.telegraf); ok {
+		pipelines[logAgentPipeline] = pipeline
+	}
+	return pipelines, nil
+}
+
+func (a *adminAgent) Reload() {
+	a.restart()
+}
+
+// loadOtelConfig returns the OTel configs merged the same way as when the agent starts.
+func loadOtelConfig(configPaths []string) (map[string]interface{}, error) {
+	merged, err := mergeConfigs(configPaths)
+	if err != nil {
+		return nil, err
+	}
+	if merged == nil {
+		if merged, err = confmap.NewFileLoader(configPaths[0]).Load(); err != nil {
+			return nil, fmt.Errorf("unable to read the OTEL config: %w", err)
+		}
+	}
+	return merged.ToStringMap(), nil
+}
+
+// otelPipelines returns the pipelines of the service of the OTel config.
+func otelPipelines(otelConfig map[string]interface{}) map[string]admin.Pipeline {
+	pipelines := map[string]admin.Pipeline{}
+	service, _ := otelConfig["service"].(map[string]interface{})
+	configs, _ := service["pipelines"].(map[string]interface{})
+	for name, value := range configs {
+		pipeline, _ := value.(map[string]interface{})
+		pipelines[name] = admin.Pipeline{
+			Receivers:  toStrings(pipeline["receivers"]),
+			Processors: toStrings(pipeline["processors"]),
+			Exporters:  toStrings(pipeline["exporters"]),
+		}
+	}
+	return pipelines
+}
+
+// logAgentPipelineOf returns the log collections and log backends connected by the logs agent.
+func logAgentPipelineOf(c *config.Config) (admin.Pipeline, bool) {
+	pipeline := admin.Pipeline{Receivers: []string{}, Exporters: []string{}}
+	for _, input := range c.Inputs {
+		if _, ok := input.Input.(logs.LogCollection); ok {
+			pipeline.Receivers = append(pipeline.Receivers, input.Config.Name)
+		}
+	}
+	for _, output := range c.Outputs {
+		if _, ok := output.Output.(logs.LogBackend); ok {
+			name := output.Config.Alias
+			if name == "" {
+				name = output.Config.Name
+			}
+			pipeline.Exporters = append(pipeline.Exporters, name)
+		}
+	}
+	sort.Strings(pipeline.Receivers)
+	sort.Strings(pipeline.Exporters)
+	return pipeline, len(pipeline.Receivers) > 0 && len(pipeline.Exporters) > 0
+}
+
+func toStrings(value interface{}) []string {
+	values, _ := value.([]interface{})
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		strs = append(strs, fmt.Sprint(v))
+	}
+	return strs
+}
+
+// startAdminServer serves the admin API of the agent on the admin socket, and returns the
+// function to stop serving it.
+func startAdminServer(agent *adminAgent) func() {
+	if *fAdminSocket == "" {
+		return func() {}
+	}
+	server := admin.NewServer(*fAdminSocket, agent)
+	if err := server.Start(); err != nil {
+		log.Printf("W! Unable to serve the admin API on %s: %v", *fAdminSocket, err)
+		return func() {}
+	}
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), adminShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("W! Unable to stop serving the admin API: %v", err)
+		}
+	}
+}
+
+/**
+ *	amazon-cloudwatch-agent [-admin-socket ${SOCKET}] admin status|config|pipelines|flush|reload
+ *
+ *		Calls the admin API of the running agent and prints the response.
+ */
+func runAdminCommand(socket string, args []string, stdout io.Writer) int {
+	if len(args) != 1 {
+		log.Printf("E! Usage: %s admin %s", os.Args[0], strings.Join(adminCommandNames(), "|"))
+		return 2
+	}
+	endpoint, ok := adminEndpoints[args[0]]
+	if !ok {
+		log.Printf("E! Unknown admin command %q, expected one of %s", args[0], strings.Join(adminCommandNames(), ", "))
+		return 2
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), adminRequestTimeout)
+	defer cancel()
+	body, err := admin.NewClient(socket).Do(ctx, endpoint.method, endpoint.path)
+	if len(body) > 0 {
+		_, _ = stdout.Write(body)
+	}
+	if err != nil {
+		log.Printf("E! Failed to call the admin API on %s: %v", socket, err)
+		return 1
+	}
+	return 0
+}
+
+func adminCommandNames() []string {
+	names := make([]string, 0, len(adminEndpoints))
+	for name := range adminEndpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
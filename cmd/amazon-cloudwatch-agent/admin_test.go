@@ -0,0 +1,127 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/service/admin"
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig"
+)
+
+const adminOtelConfig = `
+receivers:
+  telegraf_cpu: {}
+exporters:
+  awscloudwatch:
+    namespace: CWAgent
+service:
+  pipelines:
+    metrics/host:
+      receivers: [telegraf_cpu]
+      processors: [batch/host]
+      exporters: [awscloudwatch]
+`
+
+func newTestAdminAgent(t *testing.T) (*adminAgent, *int) {
+	t.Helper()
+	dir := t.TempDir()
+	tomlConfigPath := filepath.Join(dir, "amazon-cloudwatch-agent.toml")
+	require.NoError(t, os.WriteFile(tomlConfigPath, []byte(fmt.Sprintf(reloadTomlConfig, filepath.Join(dir, "state"), "app")), 0600))
+	otelConfigPath := filepath.Join(dir, "amazon-cloudwatch-agent.yaml")
+	require.NoError(t, os.WriteFile(otelConfigPath, []byte(adminOtelConfig), 0600))
+	tomlConfig := *fTomlConfig
+	*fTomlConfig = tomlConfigPath
+	t.Cleanup(func() { *fTomlConfig = tomlConfig })
+
+	c := config.NewConfig()
+	c.AllowUnusedFields = true
+	require.NoError(t, loadTomlConfigIntoAgent(c))
+	var restarts int
+	return &adminAgent{telegraf: c, otelConfigs: []string{otelConfigPath}, restart: func() { restarts++ }}, &restarts
+}
+
+func TestAdminAgent(t *testing.T) {
+	agent, restarts := newTestAdminAgent(t)
+
+	c, err := agent.Config()
+	require.NoError(t, err)
+	assert.Contains(t, c.Toml, `log_group_name = "app"`)
+	assert.Contains(t, c.Otel, "exporters")
+	assert.Nil(t, c.Fragments)
+
+	fragments := []*jsonconfig.Fragment{{Path: "/etc/conf.d/app.json", Name: "app", Owner: "app-team", Components: []string{"metrics.metrics_collected.cpu"}}}
+	require.NoError(t, cmdutil.WriteFragments(cmdutil.FragmentsPath(*fTomlConfig), fragments))
+	c, err = agent.Config()
+	require.NoError(t, err)
+	var gotFragments []*jsonconfig.Fragment
+	require.NoError(t, json.Unmarshal(c.Fragments, &gotFragments))
+	assert.Equal(t, fragments, gotFragments)
+
+	pipelines, err := agent.Pipelines()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]admin.Pipeline{
+		"metrics/host": {
+			Receivers:  []string{"telegraf_cpu"},
+			Processors: []string{"batch/host"},
+			Exporters:  []string{"awscloudwatch"},
+		},
+		logAgentPipeline: {
+			Receivers: []string{"logfile"},
+			Exporters: []string{"cloudwatchlogs"},
+		},
+	}, pipelines)
+
+	// logs-only mode
+	agent.otelConfigs = nil
+	c, err = agent.Config()
+	require.NoError(t, err)
+	assert.Nil(t, c.Otel)
+	pipelines, err = agent.Pipelines()
+	require.NoError(t, err)
+	assert.Equal(t, []string{logAgentPipeline}, keys(pipelines))
+
+	agent.Reload()
+	assert.Equal(t, 1, *restarts)
+}
+
+func TestRunAdminCommand(t *testing.T) {
+	agent, restarts := newTestAdminAgent(t)
+	socket := filepath.Join(t.TempDir(), "admin.sock")
+	server := admin.NewServer(socket, agent)
+	require.NoError(t, server.Start())
+	defer server.Shutdown(context.Background())
+
+	var stdout bytes.Buffer
+	assert.Equal(t, 0, runAdminCommand(socket, []string{"pipelines"}, &stdout))
+	var pipelines map[string]admin.Pipeline
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &pipelines))
+	assert.Contains(t, pipelines, "metrics/host")
+
+	assert.Equal(t, 0, runAdminCommand(socket, []string{"reload"}, &stdout))
+	assert.Equal(t, 1, *restarts)
+
+	assert.Equal(t, 2, runAdminCommand(socket, nil, &stdout))
+	assert.Equal(t, 2, runAdminCommand(socket, []string{"restart"}, &stdout))
+	assert.Equal(t, 1, runAdminCommand(filepath.Join(t.TempDir(), "missing.sock"), []string{"status"}, &stdout))
+}
+
+func keys(m map[string]admin.Pipeline) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	return names
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter"
 	"github.com/aws/amazon-cloudwatch-agent/service/configprovider"
 	"github.com/aws/amazon-cloudwatch-agent/service/defaultcomponents"
+	"github.com/aws/amazon-cloudwatch-agent/service/lastknowngood"
 	"github.com/aws/amazon-cloudwatch-agent/service/registry"
 	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
 	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/toyamlconfig"
@@ -69,6 +70,9 @@ var fOtelConfigs configprovider.OtelConfigFlags
 var fEnvConfig = flag.String("envconfig", "", "env configuration file to load")
 var fConfigDirectory = flag.String("config-directory", "",
 	"directory containing additional *.conf files")
+var fJsonConfig = flag.String("json-config", "", "JSON configuration file to watch and translate in process when it changes")
+var fJsonConfigDir = flag.String("json-config-dir", "", "directory of JSON configuration files to watch and translate in process when they change")
+var fCommonConfig = flag.String("common-config", "", "common-config file to use when translating the JSON configuration")
 var fVersion = flag.Bool("version", false, "display the version and exit")
 var fSampleConfig = flag.Bool("sample-config", false,
 	"print out full sample configuration")
@@ -94,6 +98,7 @@ var fServiceDisplayName = flag.String("service-display-name", "Telegraf Data Col
 var fRunAsConsole = flag.Bool("console", false, "run as console application (windows only)")
 var fSetEnv = flag.String("setenv", "", "set an env in the configuration file in the format of KEY=VALUE")
 var fStartUpErrorFile = flag.String("startup-error-file", "", "file to touch if agent can't start")
+var fAdminSocket = flag.String("admin-socket", paths.AdminSocketPath, "Unix socket of the admin API, which is not served if empty")
 
 var stop chan struct{}
 
@@ -114,6 +119,7 @@ func reloadLoop(
 		signals := make(chan os.Signal)
 		signal.Notify(signals, os.Interrupt, syscall.SIGHUP,
 			syscall.SIGTERM, syscall.SIGINT)
+		restart := make(chan struct{}, 1)
 		go func() {
 			select {
 			case sig := <-signals:
@@ -123,8 +129,15 @@ func reloadLoop(
 					reload <- true
 				}
 				cancel()
+			case <-restart:
+				log.Println("I! Restarting agent to apply the changed config")
+				<-reload
+				reload <- true
+				cancel()
 			case <-stop:
 				cancel()
+			case <-ctx.Done():
+				// the agent stopped on its own, e.g. to roll back to the last known good config
 			}
 		}()
 
@@ -177,7 +190,26 @@ func reloadLoop(
 			}(ctx, envConfigPath)
 		}
 
-		err := runAgent(ctx, inputFilters, outputFilters)
+		var store *lastknowngood.Store
+		if lastKnownGoodEnabled() {
+			store = lastknowngood.New(*fTomlConfig)
+			reportRollback(store)
+			go saveLastKnownGood(ctx, store)
+		}
+
+		err := runAgent(ctx, inputFilters, outputFilters, func() {
+			select {
+			case restart <- struct{}{}:
+			default:
+			}
+		})
+		cancel()
+		if err != nil && err != context.Canceled && store != nil && rollbackToLastKnownGood(store, err) {
+			signal.Stop(signals)
+			<-reload
+			reload <- true
+			continue
+		}
 		if err != nil && err != context.Canceled {
 			if *fStartUpErrorFile != "" {
 				f, err := os.OpenFile(*fStartUpErrorFile, os.O_CREATE|os.O_WRONLY, 0644)
@@ -231,6 +263,7 @@ func getEnvConfigPath(configPath, envConfigPath string) (string, error) {
 func runAgent(ctx context.Context,
 	inputFilters []string,
 	outputFilters []string,
+	restart func(),
 ) error {
 	envConfigPath, err := getEnvConfigPath(*fTomlConfig, *fEnvConfig)
 	if err != nil {
@@ -310,11 +343,25 @@ func runAgent(ctx context.Context,
 		}
 	}
 
+	var reloader *hotReloader
+	if hotReloadEnabled() {
+		reloader = &hotReloader{
+			inputFilters:  inputFilters,
+			outputFilters: outputFilters,
+			otelConfigs:   fOtelConfigs,
+			restart:       restart,
+			telegraf:      c,
+		}
+	}
+
 	if len(c.Inputs) != 0 && len(c.Outputs) != 0 {
 		log.Println("creating new logs agent")
 		logAgent := logs.NewLogAgent(c)
 		// Always run logAgent as goroutine regardless of whether starting OTEL or Telegraf.
 		go logAgent.Run(ctx)
+		if reloader != nil {
+			reloader.logAgent = logAgent
+		}
 
 		// If only a single YAML is provided and does not exist, then ASSUME the agent is
 		// just monitoring logs since this is the default when no OTEL config flag is provided.
@@ -324,6 +371,13 @@ func runAgent(ctx context.Context,
 			if errors.Is(err, os.ErrNotExist) {
 				log.Println("I! running in logs-only mode")
 				useragent.Get().SetComponents(&otelcol.Config{}, c)
+				if reloader != nil {
+					if r := reloader.newReloader(); r != nil {
+						go r.Run(ctx)
+					}
+				}
+				stopAdminServer := startAdminServer(&adminAgent{telegraf: c, restart: restart})
+				defer stopAdminServer()
 				return ag.Run(ctx)
 			}
 		}
@@ -372,6 +426,13 @@ func runAgent(ctx context.Context,
 	useragent.Get().SetComponents(cfg, c)
 
 	params := getCollectorParams(factories, providerSettings, loggerOptions)
+	if reloader != nil {
+		if r := reloader.newReloader(); r != nil {
+			params.ConfigProviderSettings, reloader.collector = configprovider.GetReloadableSettings(otelConfigs, logger)
+			params.Factories = reloader.factories
+			go r.Run(ctx)
+		}
+	}
 	cmd := otelcol.NewCommand(params)
 	// *************************************************************************************************
 	// ⚠️ WARNING ⚠️
@@ -385,6 +446,8 @@ func runAgent(ctx context.Context,
 		e = append(e, "--config="+uri)
 	}
 	cmd.SetArgs(e)
+	stopAdminServer := startAdminServer(&adminAgent{telegraf: c, otelConfigs: fOtelConfigs, restart: restart})
+	defer stopAdminServer()
 	return cmd.Execute()
 }
 
@@ -535,6 +598,8 @@ func main() {
 		case "version":
 			fmt.Println(version.Full())
 			return
+		case adminCommand:
+			os.Exit(runAdminCommand(*fAdminSocket, args[1:], os.Stdout))
 		case "config":
 			config.PrintSampleConfig(
 				sectionFilters,
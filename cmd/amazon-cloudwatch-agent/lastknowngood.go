@@ -0,0 +1,91 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
+	"github.com/aws/amazon-cloudwatch-agent/service/lastknowngood"
+)
+
+// lastKnownGoodDelay is how long the agent has to run before its config is considered good.
+var lastKnownGoodDelay = 30 * time.Second
+
+// lastKnownGoodEnabled reports whether the config the agent starts with is kept as the
+// last-known-good config and rolled back to when a config fails to start. It is enabled for
+// the agent started by start-amazon-cloudwatch-agent, which also falls back to it when the
+// json config fails to translate.
+func lastKnownGoodEnabled() bool {
+	return hotReloadEnabled() && !*fTest && *fTestWait == 0 && !*fSchemaTest
+}
+
+// translationPaths are the paths the config-translator writes the translation to.
+func translationPaths() (tomlConfigPath, yamlConfigPath, envConfigPath string) {
+	tomlConfigPath = *fTomlConfig
+	yamlConfigPath = filepath.Join(filepath.Dir(tomlConfigPath), yamlConfigFileName)
+	envConfigPath, _ = getEnvConfigPath(*fTomlConfig, *fEnvConfig)
+	return tomlConfigPath, yamlConfigPath, envConfigPath
+}
+
+// saveLastKnownGood saves the running translation and the json config once the agent has run
+// for lastKnownGoodDelay without failing.
+func saveLastKnownGood(ctx context.Context, store *lastknowngood.Store) {
+	timer := time.NewTimer(lastKnownGoodDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return
+	}
+	tomlConfigPath, yamlConfigPath, envConfigPath := translationPaths()
+	var sources []string
+	for _, path := range []string{*fJsonConfig, *fJsonConfigDir} {
+		if path != "" {
+			sources = append(sources, path)
+		}
+	}
+	if err := store.Save(tomlConfigPath, yamlConfigPath, envConfigPath, sources); err != nil {
+		log.Printf("E! Unable to save last-known-good config in %s: %v", store.Dir(), err)
+		return
+	}
+	log.Printf("D! Saved last-known-good config in %s", store.Dir())
+}
+
+// rollbackToLastKnownGood writes the last-known-good translation in place of the config that
+// failed to start, and reports whether the agent can be started again with it. It cannot if
+// there is none or the config that failed is the last-known-good one.
+func rollbackToLastKnownGood(store *lastknowngood.Store, cause error) bool {
+	tomlConfigPath, yamlConfigPath, envConfigPath := translationPaths()
+	if store.IsSaved(tomlConfigPath, yamlConfigPath, envConfigPath) {
+		return false
+	}
+	if err := store.Rollback(cause, tomlConfigPath, yamlConfigPath, envConfigPath); err != nil {
+		if !errors.Is(err, lastknowngood.ErrNotSaved) {
+			log.Printf("E! Unable to roll back to last-known-good config: %v", err)
+		}
+		return false
+	}
+	log.Printf("W! Agent failed to start, rolling back to last-known-good config from %s: %v", store.Dir(), cause)
+	return true
+}
+
+// reportRollback reports when the agent runs the last-known-good config because a newer
+// config failed to translate or start.
+func reportRollback(store *lastknowngood.Store) {
+	rollback, ok := store.RolledBack()
+	if !ok {
+		return
+	}
+	if !store.IsSaved(translationPaths()) {
+		return
+	}
+	log.Printf("W! Running last-known-good config from %s, the config deployed after it failed at %s: %s",
+		store.Dir(), rollback.Time.Format(time.RFC3339), rollback.Reason)
+	agent.UsageFlags().Set(agent.FlagConfigRollback)
+}
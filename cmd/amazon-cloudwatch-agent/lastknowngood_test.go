@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
+	"github.com/aws/amazon-cloudwatch-agent/service/lastknowngood"
+)
+
+func TestLastKnownGood(t *testing.T) {
+	dir := t.TempDir()
+	tomlConfigPath := filepath.Join(dir, "amazon-cloudwatch-agent.toml")
+	jsonConfigPath := filepath.Join(dir, "amazon-cloudwatch-agent.json")
+	tomlConfig, envConfig, jsonConfig, delay := *fTomlConfig, *fEnvConfig, *fJsonConfig, lastKnownGoodDelay
+	*fTomlConfig, *fEnvConfig, *fJsonConfig, lastKnownGoodDelay = tomlConfigPath, "", jsonConfigPath, time.Millisecond
+	t.Cleanup(func() {
+		*fTomlConfig, *fEnvConfig, *fJsonConfig, lastKnownGoodDelay = tomlConfig, envConfig, jsonConfig, delay
+	})
+	assert.True(t, lastKnownGoodEnabled())
+
+	require.NoError(t, os.WriteFile(tomlConfigPath, []byte("good"), 0644))
+	require.NoError(t, os.WriteFile(jsonConfigPath, []byte(`{"agent":{}}`), 0644))
+	store := lastknowngood.New(tomlConfigPath)
+	assert.False(t, rollbackToLastKnownGood(store, errors.New("invalid config")), "nothing to roll back to")
+
+	saveLastKnownGood(context.Background(), store)
+	_, err := os.Stat(filepath.Join(store.Dir(), "json", "amazon-cloudwatch-agent.json"))
+	assert.NoError(t, err)
+	assert.False(t, rollbackToLastKnownGood(store, errors.New("invalid config")), "the last-known-good config failed")
+
+	require.NoError(t, os.WriteFile(tomlConfigPath, []byte("bad"), 0644))
+	assert.True(t, rollbackToLastKnownGood(store, errors.New("invalid config")))
+	content, err := os.ReadFile(tomlConfigPath)
+	require.NoError(t, err)
+	assert.Equal(t, "good", string(content))
+
+	reportRollback(store)
+	assert.True(t, agent.UsageFlags().IsSet(agent.FlagConfigRollback))
+}
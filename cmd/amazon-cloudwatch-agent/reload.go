@@ -0,0 +1,205 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/influxdata/telegraf/config"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/useragent"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/service/configprovider"
+	"github.com/aws/amazon-cloudwatch-agent/service/reload"
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+	translatorcontext "github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/toyamlconfig"
+	translatorUtil "github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+const (
+	yamlConfigFileName = "amazon-cloudwatch-agent.yaml"
+)
+
+var initTranslatorContext sync.Once
+
+// hotReloadEnabled reports whether the json config is watched and translated in process when
+// it changes.
+func hotReloadEnabled() bool {
+	return *fTomlConfig != "" && (*fJsonConfig != "" || *fJsonConfigDir != "")
+}
+
+// translateJsonConfig translates the json config the same way start-amazon-cloudwatch-agent
+// runs the config-translator.
+func translateJsonConfig() (*cmdutil.Translation, error) {
+	ctx := translatorcontext.CurrentContext()
+	initTranslatorContext.Do(func() {
+		ctx.SetOs("")
+		ctx.SetInputJsonFilePath(*fJsonConfig)
+		ctx.SetInputJsonDirPath(*fJsonConfigDir)
+		ctx.SetMultiConfig("remove")
+		ctx.SetOutputTomlFilePath(*fTomlConfig)
+		mode := translatorUtil.DetectAgentMode("auto")
+		ctx.SetMode(mode)
+		ctx.SetKubernetesMode(translatorUtil.DetectKubernetesMode(mode))
+	})
+	if err := cmdutil.LoadCommonConfig(ctx, *fCommonConfig); err != nil {
+		return nil, err
+	}
+	return cmdutil.TranslateJsonConfig(ctx)
+}
+
+// hotReloader applies the changes of the translated json config to the running agent. The log
+// collections are reloaded by the logs agent, and the collector is reloaded with new adapted
+// receivers if the pipelines or the other inputs changed.
+type hotReloader struct {
+	inputFilters  []string
+	outputFilters []string
+	otelConfigs   []string
+	restart       func()
+
+	mu        sync.Mutex
+	telegraf  *config.Config
+	logAgent  *logs.LogAgent
+	collector *configprovider.Reloader
+}
+
+var _ reload.Agent = (*hotReloader)(nil)
+
+// newReloader returns the reloader watching the json config for the running agent, or nil if
+// the running config cannot be read.
+func (h *hotReloader) newReloader() *reload.Reloader {
+	tomlConfigPath, yamlConfigPath, envConfigPath := translationPaths()
+	running, err := cmdutil.ReadTranslation(tomlConfigPath, yamlConfigPath, envConfigPath)
+	if err != nil {
+		log.Printf("E! Unable to read running config, json config changes require a restart: %v", err)
+		return nil
+	}
+	var paths []string
+	for _, path := range []string{*fJsonConfig, *fJsonConfigDir, *fCommonConfig} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return reload.NewReloader(paths, running, translateJsonConfig, func(t *cmdutil.Translation) error {
+		if err := t.Write(tomlConfigPath, yamlConfigPath, envConfigPath); err != nil {
+			return err
+		}
+		return cmdutil.WriteFragments(cmdutil.FragmentsPath(tomlConfigPath), t.Fragments)
+	}, h)
+}
+
+// factories builds the collector factories from the latest telegraf config.
+func (h *hotReloader) factories() (otelcol.Factories, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return components(h.telegraf)
+}
+
+func (h *hotReloader) Apply(change reload.Change) error {
+	// Env config changes are picked up by the env config poller.
+	if len(change.Inputs) == 0 && len(change.Components) == 0 {
+		return nil
+	}
+	c := config.NewConfig()
+	c.OutputFilters = h.outputFilters
+	c.InputFilters = h.inputFilters
+	c.AllowUnusedFields = true
+	if err := loadTomlConfigIntoAgent(c); err != nil {
+		return err
+	}
+	if err := validateAgentFinalConfigAndPlugins(c); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	running := h.telegraf
+	h.mu.Unlock()
+	var logInputs []string
+	reloadCollector := len(change.Components) > 0
+	for _, name := range change.Inputs {
+		if isLogCollection(running, name) || isLogCollection(c, name) {
+			logInputs = append(logInputs, name)
+		} else {
+			reloadCollector = true
+		}
+	}
+
+	var cfg *otelcol.Config
+	if reloadCollector {
+		if h.collector == nil {
+			return errors.New("collector is not running")
+		}
+		var err error
+		if cfg, err = h.validateCollectorConfig(c); err != nil {
+			return fmt.Errorf("invalid OTEL configuration: %w", err)
+		}
+	}
+	if len(logInputs) > 0 {
+		if h.logAgent == nil {
+			return errors.New("logs agent is not running")
+		}
+		if err := h.logAgent.Reload(c, logInputs); err != nil {
+			return err
+		}
+	}
+	if reloadCollector {
+		h.mu.Lock()
+		h.telegraf = c
+		h.mu.Unlock()
+		useragent.Get().SetComponents(cfg, c)
+		if !h.collector.Reload() {
+			return errors.New("collector is not running")
+		}
+	}
+	return nil
+}
+
+func (h *hotReloader) Restart() {
+	h.restart()
+}
+
+// validateCollectorConfig resolves and validates the OTEL configuration the collector will be
+// reloaded with, so that a bad configuration does not stop the running collector.
+func (h *hotReloader) validateCollectorConfig(c *config.Config) (*otelcol.Config, error) {
+	otelConfigs := h.otelConfigs
+	merged, err := mergeConfigs(otelConfigs)
+	if err != nil {
+		return nil, err
+	}
+	if merged != nil {
+		_ = os.Setenv(envconfig.CWAgentMergedOtelConfig, toyamlconfig.ToYamlConfig(merged.ToStringMap()))
+		otelConfigs = []string{"env:" + envconfig.CWAgentMergedOtelConfig}
+	}
+	provider, err := otelcol.NewConfigProvider(configprovider.GetSettings(otelConfigs, zap.NewNop()))
+	if err != nil {
+		return nil, err
+	}
+	factories, err := components(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := provider.Get(context.Background(), factories)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, cfg.Validate()
+}
+
+func isLogCollection(c *config.Config, name string) bool {
+	for _, input := range c.Inputs {
+		if _, ok := input.Input.(logs.LogCollection); ok && input.Config.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/service/reload"
+)
+
+const reloadTomlConfig = `
+[agent]
+  interval = "60s"
+  flush_interval = "1s"
+
+[[inputs.cpu]]
+
+[[inputs.logfile]]
+  destination = "cloudwatchlogs"
+  file_state_folder = "%s"
+
+  [[inputs.logfile.file_config]]
+    file_path = "/tmp/app.log"
+    log_group_name = "%s"
+
+[[outputs.cloudwatchlogs]]
+  region = "us-west-2"
+`
+
+func TestHotReloaderApply(t *testing.T) {
+	dir := t.TempDir()
+	tomlConfigPath := filepath.Join(dir, "amazon-cloudwatch-agent.toml")
+	stateFolder := filepath.Join(dir, "state")
+	writeToml := func(logGroupName string) {
+		content := []byte(fmt.Sprintf(reloadTomlConfig, stateFolder, logGroupName))
+		require.NoError(t, os.WriteFile(tomlConfigPath, content, 0600))
+	}
+	tomlConfig := *fTomlConfig
+	*fTomlConfig = tomlConfigPath
+	t.Cleanup(func() { *fTomlConfig = tomlConfig })
+
+	writeToml("before")
+	c := config.NewConfig()
+	c.AllowUnusedFields = true
+	require.NoError(t, loadTomlConfigIntoAgent(c))
+	h := &hotReloader{telegraf: c, logAgent: logs.NewLogAgent(c)}
+	assert.True(t, isLogCollection(c, "logfile"))
+	assert.False(t, isLogCollection(c, "cpu"))
+
+	assert.NoError(t, h.Apply(reload.Change{Env: true}))
+
+	writeToml("after")
+	assert.NoError(t, h.Apply(reload.Change{Inputs: []string{"logfile"}}))
+
+	// the collector has to be reloaded for other inputs
+	assert.Error(t, h.Apply(reload.Change{Inputs: []string{"cpu"}}))
+	assert.Error(t, h.Apply(reload.Change{Components: []string{"receivers/telegraf_cpu"}}))
+	assert.Same(t, c, h.telegraf)
+
+	h.logAgent = nil
+	assert.Error(t, h.Apply(reload.Change{Inputs: []string{"logfile"}}))
+}
@@ -4,12 +4,19 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -28,6 +35,8 @@ const (
 	locationDefault = "default"
 	locationSSM     = "ssm"
 	locationFile    = "file"
+	locationHTTPS   = "https"
+	locationS3      = "s3"
 
 	locationSeparator = ":"
 
@@ -38,10 +47,7 @@ func defaultJsonConfig(mode string) (string, error) {
 	return config.DefaultJsonConfig(config.ToValidOs(""), mode), nil
 }
 
-func downloadFromSSM(region, parameterStoreName, mode string, credsConfig map[string]string) (string, error) {
-	fmt.Printf("Region: %v\n", region)
-	fmt.Printf("credsConfig: %v\n", credsConfig)
-	var ses *session.Session
+func newSession(region, mode string, credsConfig map[string]string) (*session.Session, error) {
 	credsMap := util.GetCredentials(mode, credsConfig)
 	profile, profileOk := credsMap[commonconfig.CredentialProfile]
 	sharedConfigFile, sharedConfigFileOk := credsMap[commonconfig.CredentialFile]
@@ -60,6 +66,16 @@ func downloadFromSSM(region, parameterStoreName, mode string, credsConfig map[st
 	ses, err := session.NewSession(rootconfig)
 	if err != nil {
 		fmt.Printf("Error in creating session: %v\n", err)
+		return nil, err
+	}
+	return ses, nil
+}
+
+func downloadFromSSM(region, parameterStoreName, mode string, credsConfig map[string]string) (string, error) {
+	fmt.Printf("Region: %v\n", region)
+	fmt.Printf("credsConfig: %v\n", credsConfig)
+	ses, err := newSession(region, mode, credsConfig)
+	if err != nil {
 		return "", err
 	}
 
@@ -94,6 +110,10 @@ func EscapeFilePath(filePath string) (escapedFilePath string) {
  *		multi-config:
  *			default, append: download config to the dir and append .tmp suffix
  *			remove: remove the config from the dir
+ *
+ *		poll-interval:
+ *			download https:// and s3:// config every interval and write it to the dir without the .tmp
+ *			suffix when it changed, which the running agent reloads
  */
 func main() {
 
@@ -107,7 +127,8 @@ func main() {
 		}
 	}()
 
-	var region, mode, downloadLocation, outputDir, inputConfig, multiConfig string
+	var region, mode, downloadLocation, outputDir, inputConfig, multiConfig, checksum, signaturePublicKey string
+	var pollInterval time.Duration
 
 	flag.StringVar(&mode, "mode", "ec2", "Please provide the mode, i.e. ec2, onPremise, onPrem, auto")
 	flag.StringVar(&downloadLocation, "download-source", "",
@@ -115,6 +136,10 @@ func main() {
 	flag.StringVar(&outputDir, "output-dir", "", "Path of output json config directory.")
 	flag.StringVar(&inputConfig, "config", "", "Please provide the common-config file")
 	flag.StringVar(&multiConfig, "multi-config", "default", "valid values: default, append, remove")
+	flag.StringVar(&checksum, "checksum", "", "Expected SHA-256 checksum of https:// and s3:// config, i.e. sha256:<hex>")
+	flag.StringVar(&signaturePublicKey, "signature-public-key", "",
+		"Path of the PEM Ed25519 public key verifying the detached signature downloaded from the https:// or s3:// location with a .sig suffix")
+	flag.DurationVar(&pollInterval, "poll-interval", 0, "Download https:// and s3:// config every interval until stopped, and write it when it changed")
 	flag.Parse()
 
 	cc := commonconfig.New()
@@ -145,7 +170,7 @@ func main() {
 
 	region, _ = util.DetectRegion(mode, cc.CredentialsMap())
 
-	if region == "" && downloadLocation != locationDefault {
+	if region == "" && requiresRegion(downloadLocation) {
 		fmt.Println("Unable to determine aws-region.")
 		if mode == config.ModeEC2 {
 			errorMessage = "E! Please check if you can access the metadata service. For example, on linux, run 'wget -q -O - http://169.254.169.254/latest/meta-data/instance-id && echo' "
@@ -158,33 +183,21 @@ func main() {
 
 	// clean up output dir for tmp files before writing out new tmp file.
 	// this step cannot be in translator because it is too late at that time.
-	filepath.Walk(
-		outputDir,
-		func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				fmt.Printf("Cannot access %v: %v \n", path, err)
-				return err
-			}
-			if info.IsDir() {
-				if strings.EqualFold(path, outputDir) {
-					return nil
-				} else {
-					fmt.Printf("Sub dir %v will be ignored.", path)
-					return filepath.SkipDir
-				}
-			}
-			if filepath.Ext(path) == constants.FileSuffixTmp {
-				return os.Remove(path)
-			}
-			return nil
-		})
+	// polling does not write tmp files and can run alongside the ctl script.
+	if pollInterval <= 0 {
+		cleanUpTmpFiles(outputDir)
+	}
 
 	locationArray := strings.SplitN(downloadLocation, locationSeparator, 2)
 	if locationArray == nil || len(locationArray) < 2 && downloadLocation != locationDefault {
 		log.Panicf("E! downloadLocation %s is malformated.", downloadLocation)
 	}
+	if pollInterval > 0 && (multiConfig == "remove" || (locationArray[0] != locationHTTPS && locationArray[0] != locationS3)) {
+		log.Panicf("E! poll-interval is only supported to fetch https:// and s3:// config")
+	}
 
 	var config, outputFilePath string
+	var remote *remoteConfig
 	var err error
 	switch locationArray[0] {
 	case locationDefault:
@@ -202,10 +215,68 @@ func main() {
 		if multiConfig != "remove" {
 			config, err = readFromFile(locationArray[1])
 		}
+	case locationHTTPS:
+		u, parseErr := parseHTTPSLocation(downloadLocation)
+		if parseErr != nil {
+			log.Panicf("E! downloadLocation %v", parseErr)
+		}
+		outputFilePath = locationHTTPS + "_" + httpsName(u)
+		if multiConfig != "remove" {
+			var client *http.Client
+			if client, err = newHTTPClient(util.GetSSL(cc.SSLMap())[commonconfig.CABundlePath]); err == nil {
+				remote = &remoteConfig{source: &httpsSource{url: downloadLocation, client: client}}
+			}
+		}
+	case locationS3:
+		location, parseErr := parseS3Location(downloadLocation)
+		if parseErr != nil {
+			log.Panicf("E! downloadLocation %v", parseErr)
+		}
+		outputFilePath = locationS3 + "_" + location.name()
+		if multiConfig != "remove" {
+			if location.region == "" {
+				location.region = region
+			}
+			var ses *session.Session
+			if ses, err = newSession(location.region, mode, cc.CredentialsMap()); err == nil {
+				remote = &remoteConfig{source: newS3Source(ses, location)}
+			}
+		}
 	default:
 		log.Panicf("E! Location type %s is not supported.", locationArray[0])
 	}
 
+	if remote != nil {
+		if checksum != "" {
+			if remote.checksum, err = parseChecksum(checksum); err != nil {
+				log.Panicf("E! %v", err)
+			}
+		}
+		if signaturePublicKey != "" {
+			if remote.publicKey, err = readPublicKey(signaturePublicKey); err != nil {
+				log.Panicf("E! Failed to read the signature public key: %v", err)
+			}
+		}
+		if pollInterval > 0 {
+			outputFilePath = filepath.Join(outputDir, outputFilePath)
+			fmt.Printf("Polling the config every %v and saving it in %s\n", pollInterval, outputFilePath)
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			remote.poll(ctx, pollInterval, func(content []byte) error {
+				if err := writeFileAtomic(outputFilePath, content); err != nil {
+					return fmt.Errorf("failed to write the json file %v: %w", outputFilePath, err)
+				}
+				fmt.Printf("Successfully fetched the changed config and saved in %s\n", outputFilePath)
+				return nil
+			})
+			return
+		}
+		var content []byte
+		if content, _, err = remote.download(); err == nil {
+			config = string(content)
+		}
+	}
+
 	if err != nil {
 		log.Panicf("E! Fail to fetch/remove json config: %v", err)
 	}
@@ -227,3 +298,60 @@ func main() {
 		}
 	}
 }
+
+// requiresRegion reports whether the download source needs the aws region of the host. The
+// https:// sources and the s3:// sources with a region parameter do not.
+func requiresRegion(downloadLocation string) bool {
+	if downloadLocation == locationDefault || strings.HasPrefix(downloadLocation, locationHTTPS+locationSeparator) {
+		return false
+	}
+	if strings.HasPrefix(downloadLocation, locationS3+locationSeparator) {
+		location, err := parseS3Location(downloadLocation)
+		return err != nil || location.region == ""
+	}
+	return true
+}
+
+// newHTTPClient returns the client for https:// sources, which also trusts the certificates of
+// the CA bundle of the common-config.
+func newHTTPClient(caBundlePath string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caBundlePath != "" {
+		pem, err := os.ReadFile(caBundlePath)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", caBundlePath)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &http.Client{Transport: transport, Timeout: time.Minute}, nil
+}
+
+func cleanUpTmpFiles(outputDir string) {
+	filepath.Walk(
+		outputDir,
+		func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				fmt.Printf("Cannot access %v: %v \n", path, err)
+				return err
+			}
+			if info.IsDir() {
+				if strings.EqualFold(path, outputDir) {
+					return nil
+				} else {
+					fmt.Printf("Sub dir %v will be ignored.", path)
+					return filepath.SkipDir
+				}
+			}
+			if filepath.Ext(path) == constants.FileSuffixTmp {
+				return os.Remove(path)
+			}
+			return nil
+		})
+}
@@ -0,0 +1,333 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+const (
+	// signatureSuffix is appended to the location of the json config for the location of its
+	// detached signature.
+	signatureSuffix = ".sig"
+	checksumPrefix  = "sha256:"
+
+	s3RegionParameter   = "region"
+	s3EndpointParameter = "endpoint"
+
+	// maxConfigSize bounds the size of the downloaded json config and signature.
+	maxConfigSize = 16 << 20
+)
+
+var errNotModified = errors.New("not modified")
+
+// remoteSource is a location json config is downloaded from.
+type remoteSource interface {
+	// get returns the content and ETag of the location with the suffix appended. It returns
+	// errNotModified if the ETag is still the given one.
+	get(suffix, etag string) ([]byte, string, error)
+}
+
+// httpsSource downloads from an https URL.
+type httpsSource struct {
+	url    string
+	client *http.Client
+}
+
+var _ remoteSource = (*httpsSource)(nil)
+
+func (s *httpsSource) get(suffix, etag string) ([]byte, string, error) {
+	// the suffix is appended to the path, so that the query of e.g. a presigned URL is kept
+	u, err := url.Parse(s.url)
+	if err != nil {
+		return nil, "", err
+	}
+	u.Path += suffix
+	if u.RawPath != "" {
+		u.RawPath += suffix
+	}
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, etag, errNotModified
+	default:
+		return nil, "", fmt.Errorf("GET %s: %s", req.URL.Redacted(), resp.Status)
+	}
+	content, err := readLimited(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, resp.Header.Get("ETag"), nil
+}
+
+// s3Source downloads an object from S3 or an S3 compatible object store.
+type s3Source struct {
+	client s3iface.S3API
+	bucket string
+	key    string
+}
+
+var _ remoteSource = (*s3Source)(nil)
+
+func (s *s3Source) get(suffix, etag string) ([]byte, string, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key + suffix),
+	}
+	if etag != "" {
+		input.IfNoneMatch = aws.String(etag)
+	}
+	output, err := s.client.GetObject(input)
+	if err != nil {
+		var reqErr awserr.RequestFailure
+		if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotModified {
+			return nil, etag, errNotModified
+		}
+		return nil, "", err
+	}
+	defer output.Body.Close()
+	content, err := readLimited(output.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, aws.StringValue(output.ETag), nil
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxConfigSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxConfigSize {
+		return nil, fmt.Errorf("content is larger than %d bytes", maxConfigSize)
+	}
+	return content, nil
+}
+
+// s3Location is a parsed s3://bucket/key?region=...&endpoint=... download source. The endpoint
+// is set for S3 compatible object stores, which are addressed with path style requests.
+type s3Location struct {
+	bucket   string
+	key      string
+	region   string
+	endpoint string
+}
+
+func parseS3Location(location string) (s3Location, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return s3Location{}, err
+	}
+	key := strings.TrimPrefix(u.Path, "/")
+	if u.Host == "" || key == "" {
+		return s3Location{}, fmt.Errorf("%s must be s3://<bucket>/<key>", location)
+	}
+	query := u.Query()
+	return s3Location{
+		bucket:   u.Host,
+		key:      key,
+		region:   query.Get(s3RegionParameter),
+		endpoint: query.Get(s3EndpointParameter),
+	}, nil
+}
+
+// name is the name of the output file without the type of the location.
+func (l s3Location) name() string {
+	return EscapeFilePath(l.bucket + "/" + l.key)
+}
+
+func newS3Source(ses *session.Session, location s3Location) *s3Source {
+	cfg := aws.NewConfig()
+	if location.endpoint != "" {
+		cfg = cfg.WithEndpoint(location.endpoint).WithS3ForcePathStyle(true)
+	}
+	return &s3Source{client: s3.New(ses, cfg), bucket: location.bucket, key: location.key}
+}
+
+func parseHTTPSLocation(location string) (*url.URL, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != locationHTTPS || u.Host == "" {
+		return nil, fmt.Errorf("%s must be an https URL", u.Redacted())
+	}
+	return u, nil
+}
+
+// httpsName is the name of the output file of the URL without the type of the location.
+func httpsName(u *url.URL) string {
+	return EscapeFilePath(u.Host + u.Path)
+}
+
+// remoteConfig downloads json config from a remote source. It keeps the last downloaded
+// content and its ETag, so that the content is only transferred again when it changed.
+type remoteConfig struct {
+	source remoteSource
+	// checksum is the expected SHA-256 of the content, if set.
+	checksum []byte
+	// publicKey verifies the detached signature of the content, if set.
+	publicKey ed25519.PublicKey
+
+	etag    string
+	content []byte
+}
+
+// download returns the verified json config and whether it changed since the last download.
+func (r *remoteConfig) download() ([]byte, bool, error) {
+	content, etag, err := r.source.get("", r.etag)
+	if errors.Is(err, errNotModified) {
+		return r.content, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if err = r.verify(content); err != nil {
+		return nil, false, err
+	}
+	changed := r.content == nil || !bytes.Equal(content, r.content)
+	r.etag, r.content = etag, content
+	return content, changed, nil
+}
+
+func (r *remoteConfig) verify(content []byte) error {
+	if r.checksum != nil {
+		sum := sha256.Sum256(content)
+		if !bytes.Equal(sum[:], r.checksum) {
+			return fmt.Errorf("checksum mismatch: expected %x, got %x", r.checksum, sum)
+		}
+	}
+	if r.publicKey != nil {
+		signature, _, err := r.source.get(signatureSuffix, "")
+		if err != nil {
+			return fmt.Errorf("unable to download signature: %w", err)
+		}
+		if !ed25519.Verify(r.publicKey, content, decodeSignature(signature)) {
+			return errors.New("signature verification failed")
+		}
+	}
+	return nil
+}
+
+// poll downloads the json config every interval and calls write with it when it changed,
+// until the context is done. Failed downloads are logged and retried at the next interval.
+func (r *remoteConfig) poll(ctx context.Context, interval time.Duration, write func([]byte) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		content, changed, err := r.download()
+		if err != nil {
+			fmt.Printf("E! Failed to fetch the config, keeping the current one: %v\n", err)
+		} else if changed {
+			if err = write(content); err != nil {
+				fmt.Printf("E! %v\n", err)
+				// download it again at the next interval
+				r.etag, r.content = "", nil
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// decodeSignature returns the signature from its raw or base64 encoding.
+func decodeSignature(signature []byte) []byte {
+	if len(signature) == ed25519.SignatureSize {
+		return signature
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature))); err == nil {
+		return decoded
+	}
+	return signature
+}
+
+// parseChecksum parses a sha256:<hex> or <hex> SHA-256 checksum.
+func parseChecksum(checksum string) ([]byte, error) {
+	sum, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(checksum), checksumPrefix))
+	if err != nil || len(sum) != sha256.Size {
+		return nil, fmt.Errorf("checksum %s must be sha256:<hex>", checksum)
+	}
+	return sum, nil
+}
+
+// readPublicKey reads an Ed25519 public key from a PEM file.
+func readPublicKey(path string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not a PEM file", path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an Ed25519 public key", path)
+	}
+	return publicKey, nil
+}
+
+// writeFileAtomic writes the file through a temporary file in the parent of its directory, so
+// that the agent, which translates every file of the config directory, never reads a partially
+// written json config. The parent is usually on the same file system, so the rename is atomic.
+func writeFileAtomic(path string, content []byte) error {
+	dir := filepath.Dir(path)
+	f, err := os.CreateTemp(filepath.Dir(dir), "."+filepath.Base(dir)+"-"+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(f.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,280 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// objectServer serves objects by path with ETags and counts the requests that transferred
+// content. It answers both https requests and path style S3 GetObject requests.
+type objectServer struct {
+	mu        sync.Mutex
+	objects   map[string][]byte
+	transfers int
+}
+
+func (s *objectServer) set(path string, content []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[path] = content
+}
+
+func (s *objectServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	content, ok := s.objects[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	sum := sha256.Sum256(content)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	s.transfers++
+	w.Write(content)
+}
+
+func newObjectServer(objects map[string][]byte) *objectServer {
+	return &objectServer{objects: objects}
+}
+
+func TestHTTPSSource(t *testing.T) {
+	objects := newObjectServer(map[string][]byte{"/config.json": []byte(`{"agent":{}}`)})
+	server := httptest.NewTLSServer(objects)
+	defer server.Close()
+
+	remote := &remoteConfig{source: &httpsSource{url: server.URL + "/config.json", client: server.Client()}}
+	content, changed, err := remote.download()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, `{"agent":{}}`, string(content))
+
+	content, changed, err = remote.download()
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, `{"agent":{}}`, string(content))
+	assert.Equal(t, 1, objects.transfers, "unchanged content is not transferred again")
+
+	objects.set("/config.json", []byte(`{"logs":{}}`))
+	content, changed, err = remote.download()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, `{"logs":{}}`, string(content))
+
+	remote = &remoteConfig{source: &httpsSource{url: server.URL + "/missing.json", client: server.Client()}}
+	_, _, err = remote.download()
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestS3Source(t *testing.T) {
+	objects := newObjectServer(map[string][]byte{"/bucket/path/config.json": []byte(`{"agent":{}}`)})
+	server := httptest.NewServer(objects)
+	defer server.Close()
+
+	location, err := parseS3Location("s3://bucket/path/config.json?region=us-west-2&endpoint=" + server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, s3Location{bucket: "bucket", key: "path/config.json", region: "us-west-2", endpoint: server.URL}, location)
+	assert.Equal(t, "bucket_path_config.json", location.name())
+	ses, err := session.NewSession(&aws.Config{
+		Region:      aws.String(location.region),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	})
+	require.NoError(t, err)
+
+	remote := &remoteConfig{source: newS3Source(ses, location)}
+	content, changed, err := remote.download()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, `{"agent":{}}`, string(content))
+
+	_, changed, err = remote.download()
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, 1, objects.transfers, "unchanged content is not transferred again")
+
+	objects.set("/bucket/path/config.json", []byte(`{"logs":{}}`))
+	content, changed, err = remote.download()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, `{"logs":{}}`, string(content))
+}
+
+func TestRemoteConfigVerify(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	content := []byte(`{"agent":{}}`)
+	sum := sha256.Sum256(content)
+
+	testCases := map[string]struct {
+		signature []byte
+		checksum  string
+		wantErr   string
+	}{
+		"WithChecksum": {
+			checksum: checksumPrefix + hex.EncodeToString(sum[:]),
+		},
+		"WithChecksumMismatch": {
+			checksum: hex.EncodeToString(make([]byte, sha256.Size)),
+			wantErr:  "checksum mismatch",
+		},
+		"WithRawSignature": {
+			signature: ed25519.Sign(privateKey, content),
+		},
+		"WithBase64Signature": {
+			signature: []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, content)) + "\n"),
+		},
+		"WithOtherKeySignature": {
+			signature: ed25519.Sign(otherKey, content),
+			wantErr:   "signature verification failed",
+		},
+		"WithMissingSignature": {
+			wantErr: "unable to download signature",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			objects := map[string][]byte{"/config.json": content}
+			if testCase.signature != nil {
+				objects["/config.json"+signatureSuffix] = testCase.signature
+			}
+			server := httptest.NewTLSServer(newObjectServer(objects))
+			defer server.Close()
+
+			// the signature is downloaded from the path with the suffix, keeping the query
+			remote := &remoteConfig{source: &httpsSource{url: server.URL + "/config.json?X-Amz-Signature=abc", client: server.Client()}}
+			if testCase.checksum != "" {
+				remote.checksum, err = parseChecksum(testCase.checksum)
+				require.NoError(t, err)
+			} else {
+				remote.publicKey = publicKey
+			}
+			got, _, err := remote.download()
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				assert.Nil(t, remote.content, "content failing verification is not kept")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, content, got)
+		})
+	}
+}
+
+func TestRemoteConfigPoll(t *testing.T) {
+	objects := newObjectServer(map[string][]byte{"/config.json": []byte(`{"agent":{}}`)})
+	server := httptest.NewTLSServer(objects)
+	defer server.Close()
+	dir := t.TempDir()
+	outputDir := filepath.Join(dir, "amazon-cloudwatch-agent.d")
+	require.NoError(t, os.Mkdir(outputDir, 0755))
+	outputFilePath := filepath.Join(outputDir, "https_config.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	written := make(chan string)
+	remote := &remoteConfig{source: &httpsSource{url: server.URL + "/config.json", client: server.Client()}}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		remote.poll(ctx, 10*time.Millisecond, func(content []byte) error {
+			if err := writeFileAtomic(outputFilePath, content); err != nil {
+				return err
+			}
+			written <- string(content)
+			return nil
+		})
+	}()
+
+	assert.Equal(t, `{"agent":{}}`, receive(t, written))
+	objects.set("/config.json", []byte(`{"logs":{}}`))
+	assert.Equal(t, `{"logs":{}}`, receive(t, written))
+	cancel()
+	<-done
+
+	content, err := os.ReadFile(outputFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, `{"logs":{}}`, string(content))
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files are left behind")
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files are left behind")
+}
+
+func receive(t *testing.T, written <-chan string) string {
+	t.Helper()
+	select {
+	case content := <-written:
+		return content
+	case <-time.After(5 * time.Second):
+		t.Fatal("config was not written")
+		return ""
+	}
+}
+
+func TestParseLocations(t *testing.T) {
+	u, err := parseHTTPSLocation("https://artifacts.internal:8443/cwagent/config.json")
+	require.NoError(t, err)
+	assert.Equal(t, "artifacts.internal_8443_cwagent_config.json", httpsName(u))
+	_, err = parseHTTPSLocation("https:config.json")
+	assert.Error(t, err)
+
+	_, err = parseS3Location("s3://bucket")
+	assert.Error(t, err)
+
+	assert.False(t, requiresRegion(locationDefault))
+	assert.False(t, requiresRegion("https://artifacts.internal/config.json"))
+	assert.False(t, requiresRegion("s3://bucket/config.json?region=us-east-1"))
+	assert.True(t, requiresRegion("s3://bucket/config.json"))
+	assert.True(t, requiresRegion("ssm:parameter"))
+
+	_, err = parseChecksum("sha256:abc")
+	assert.Error(t, err)
+}
+
+func TestReadPublicKey(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	got, err := readPublicKey(path)
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, got)
+
+	require.NoError(t, os.WriteFile(path, []byte("key"), 0600))
+	_, err = readPublicKey(path)
+	assert.ErrorContains(t, err, fmt.Sprintf("%s is not a PEM file", path))
+}
@@ -0,0 +1,203 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws/amazon-cloudwatch-agent/service/reload"
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/toyamlconfig"
+)
+
+// dryRun prints the translation of the merged json config instead of writing it. With explain,
+// it prints the merged json config, the json config files it was merged from, the translated
+// TOML and YAML and what each json config key produces in them. With diff, it prints the
+// differences to the deployed translation.
+func dryRun(w io.Writer, mergedJsonConfigMap map[string]interface{}, fragments []*jsonconfig.Fragment, explain, diff bool, tomlConfigPath, yamlConfigPath, envConfigPath string) error {
+	// The translators may modify the json config map, so it is explained before it is translated.
+	merged, err := json.MarshalIndent(mergedJsonConfigMap, "", "  ")
+	if err != nil {
+		return err
+	}
+	var explanations []cmdutil.Explanation
+	if explain {
+		if explanations, err = cmdutil.Explain(mergedJsonConfigMap); err != nil {
+			return err
+		}
+	}
+	next, err := cmdutil.TranslateJsonMap(mergedJsonConfigMap)
+	if err != nil {
+		return err
+	}
+	if explain {
+		printExplanation(w, merged, fragments, next, explanations)
+	}
+	if diff {
+		deployed, err := cmdutil.ReadTranslation(tomlConfigPath, yamlConfigPath, envConfigPath)
+		if errors.Is(err, os.ErrNotExist) {
+			deployed = &cmdutil.Translation{}
+		} else if err != nil {
+			return err
+		}
+		if err = printDiff(w, deployed, next, tomlConfigPath, yamlConfigPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printExplanation(w io.Writer, merged []byte, fragments []*jsonconfig.Fragment, t *cmdutil.Translation, explanations []cmdutil.Explanation) {
+	printSection(w, "Merged JSON", string(merged)+"\n")
+	if len(fragments) > 0 {
+		printFragments(w, fragments)
+	}
+	printSection(w, "TOML", t.Toml)
+	yamlConfig := t.Yaml
+	if yamlConfig == "" {
+		yamlConfig = "(no OTel pipelines)\n"
+	}
+	printSection(w, "YAML", yamlConfig)
+
+	var sb strings.Builder
+	for _, explanation := range explanations {
+		fmt.Fprintf(&sb, "%s\n", explanation.Key)
+		switch {
+		case explanation.Err != nil:
+			fmt.Fprintf(&sb, "  unable to translate without it: %v\n", explanation.Err)
+		case len(explanation.Produces) == 0 && len(explanation.Changes) == 0:
+			fmt.Fprintf(&sb, "  no effect on its own\n")
+		}
+		for _, id := range explanation.Produces {
+			fmt.Fprintf(&sb, "  produces %s\n", id)
+		}
+		for _, id := range explanation.Changes {
+			fmt.Fprintf(&sb, "  changes  %s\n", id)
+		}
+	}
+	printSection(w, "Explanation", sb.String())
+}
+
+func printFragments(w io.Writer, fragments []*jsonconfig.Fragment) {
+	var sb strings.Builder
+	for _, f := range fragments {
+		fmt.Fprintf(&sb, "%v\n", f)
+		for _, err := range f.Errors {
+			fmt.Fprintf(&sb, "  skipped: %s\n", err)
+		}
+		for _, component := range f.Components {
+			fmt.Fprintf(&sb, "  contributes %s\n", component)
+		}
+	}
+	printSection(w, "Fragments", sb.String())
+}
+
+func printDiff(w io.Writer, deployed, next *cmdutil.Translation, tomlConfigPath, yamlConfigPath string) error {
+	change, err := reload.Diff(deployed, next)
+	if err != nil {
+		return err
+	}
+	for _, file := range []struct {
+		path           string
+		deployed, next string
+	}{
+		{path: tomlConfigPath, deployed: deployed.Toml, next: next.Toml},
+		{path: yamlConfigPath, deployed: sortYamlPipelineComponents(deployed.Yaml), next: sortYamlPipelineComponents(next.Yaml)},
+	} {
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(file.deployed),
+			B:        splitLines(file.next),
+			FromFile: file.path,
+			ToFile:   file.path + " (translated)",
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		if text == "" {
+			text = "(no changes)\n"
+		}
+		printSection(w, "Diff "+file.path, text)
+	}
+
+	var sb strings.Builder
+	switch {
+	case change.IsEmpty():
+		sb.WriteString("no changes\n")
+	case change.RunAsUser:
+		sb.WriteString("requires an agent service restart to change run_as_user\n")
+	case change.Restart:
+		sb.WriteString("requires an agent restart\n")
+	}
+	for _, input := range change.Inputs {
+		fmt.Fprintf(&sb, "input %s\n", input)
+	}
+	for _, component := range change.Components {
+		fmt.Fprintf(&sb, "component %s\n", component)
+	}
+	if change.Env {
+		sb.WriteString("env config\n")
+	}
+	printSection(w, "Changes", sb.String())
+	return nil
+}
+
+// sortYamlPipelineComponents sorts the receivers and exporters of the OTel pipelines in the
+// YAML, like reload.Diff does, so that their order does not show as a difference. The YAML is
+// returned as it is if it cannot be decoded.
+func sortYamlPipelineComponents(content string) string {
+	var doc yaml.Node
+	if content == "" || yaml.Unmarshal([]byte(content), &doc) != nil || len(doc.Content) == 0 {
+		return content
+	}
+	pipelines := yamlMapValue(yamlMapValue(doc.Content[0], "service"), "pipelines")
+	if pipelines == nil {
+		return content
+	}
+	for i := 1; i < len(pipelines.Content); i += 2 {
+		for _, key := range []string{"receivers", "exporters"} {
+			if ids := yamlMapValue(pipelines.Content[i], key); ids != nil && ids.Kind == yaml.SequenceNode {
+				sort.Slice(ids.Content, func(a, b int) bool {
+					return ids.Content[a].Value < ids.Content[b].Value
+				})
+			}
+		}
+	}
+	return toyamlconfig.ToYamlConfig(&doc)
+}
+
+// yamlMapValue returns the value of the key in the YAML mapping, or nil if there is none.
+func yamlMapValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// splitLines splits the content into lines, without the empty line difflib adds to empty content.
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return difflib.SplitLines(content)
+}
+
+func printSection(w io.Writer, title, content string) {
+	fmt.Fprintf(w, "=== %s ===\n%s\n", title, content)
+}
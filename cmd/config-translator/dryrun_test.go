@@ -0,0 +1,120 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig"
+	translatorUtil "github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+func TestDryRun(t *testing.T) {
+	region, credentialsPath := translatorUtil.DetectRegion, translatorUtil.DetectCredentialsPath
+	translatorUtil.DetectRegion = func(string, map[string]string) (string, string) {
+		return "us-west-2", "ACJ"
+	}
+	translatorUtil.DetectCredentialsPath = func() string {
+		return "fake-path"
+	}
+	t.Cleanup(func() {
+		translatorUtil.DetectRegion, translatorUtil.DetectCredentialsPath = region, credentialsPath
+		context.ResetContext()
+	})
+
+	dir := t.TempDir()
+	jsonConfigPath := filepath.Join(dir, "config.json")
+	tomlConfigPath := filepath.Join(dir, "amazon-cloudwatch-agent.toml")
+	yamlConfigPath := filepath.Join(dir, yamlConfigFileName)
+	envConfigPath := filepath.Join(dir, envConfigFileName)
+	require.NoError(t, os.WriteFile(jsonConfigPath, []byte(`{
+  "metrics": {
+    "metrics_collected": {
+      "cpu": {"measurement": ["usage_idle"]}
+    }
+  }
+}`), 0600))
+
+	var fragments []*jsonconfig.Fragment
+	newMergedJsonConfigMap := func() map[string]interface{} {
+		context.ResetContext()
+		ctx := context.CurrentContext()
+		ctx.SetOs(config.OS_TYPE_LINUX)
+		ctx.SetMode(config.ModeEC2)
+		ctx.SetInputJsonFilePath(jsonConfigPath)
+		ctx.SetMultiConfig("remove")
+		m, f, err := cmdutil.GenerateMergedJsonConfigMap(ctx)
+		fragments = f
+		require.NoError(t, err)
+		return m
+	}
+
+	var out bytes.Buffer
+	merged := newMergedJsonConfigMap()
+	require.NoError(t, dryRun(&out, merged, fragments, true, true, tomlConfigPath, yamlConfigPath, envConfigPath))
+	got := out.String()
+	assert.Contains(t, got, "=== Merged JSON ===")
+	assert.Contains(t, got, "config ("+jsonConfigPath+")\n  contributes metrics.metrics_collected.cpu\n")
+	assert.Contains(t, got, "[[inputs.cpu]]")
+	assert.Contains(t, got, "telegraf_cpu:")
+	// the only metric produces the whole pipeline
+	assert.Contains(t, got, "metrics.metrics_collected.cpu\n")
+	assert.Contains(t, got, "  produces inputs.cpu\n")
+	assert.Contains(t, got, "  produces service/pipelines/metrics/host\n")
+	assert.Contains(t, got, "+++ "+tomlConfigPath+" (translated)\n@@ -0,0 +1,27 @@\n+[agent]\n")
+	assert.Contains(t, got, "=== Changes ===\nrequires an agent restart\n")
+	assert.NoFileExists(t, tomlConfigPath)
+	assert.NoFileExists(t, yamlConfigPath)
+
+	// nothing changes once the translation is deployed
+	next, err := cmdutil.TranslateJsonMap(newMergedJsonConfigMap())
+	require.NoError(t, err)
+	require.NoError(t, next.Write(tomlConfigPath, yamlConfigPath, envConfigPath))
+	out.Reset()
+	merged = newMergedJsonConfigMap()
+	require.NoError(t, dryRun(&out, merged, fragments, false, true, tomlConfigPath, yamlConfigPath, envConfigPath))
+	got = out.String()
+	assert.NotContains(t, got, "=== Merged JSON ===")
+	assert.Contains(t, got, "=== Diff "+tomlConfigPath+" ===\n(no changes)\n")
+	assert.Contains(t, got, "=== Diff "+yamlConfigPath+" ===\n(no changes)\n")
+	assert.Contains(t, got, "=== Changes ===\nno changes\n")
+}
+
+func TestPrintDiffIgnoresPipelineComponentOrder(t *testing.T) {
+	deployed := &cmdutil.Translation{Yaml: `service:
+    pipelines:
+        metrics/host:
+            exporters:
+                - awscloudwatch
+                - awsemf
+            processors:
+                - cumulativetodelta
+                - batch
+            receivers:
+                - telegraf_mem
+                - telegraf_cpu
+`}
+	next := &cmdutil.Translation{Yaml: strings.NewReplacer("telegraf_mem", "telegraf_cpu", "telegraf_cpu", "telegraf_mem", "awscloudwatch", "awsemf", "awsemf", "awscloudwatch").Replace(deployed.Yaml)}
+
+	var out bytes.Buffer
+	require.NoError(t, printDiff(&out, deployed, next, "agent.toml", "agent.yaml"))
+	assert.Contains(t, out.String(), "=== Diff agent.yaml ===\n(no changes)\n")
+	assert.Contains(t, out.String(), "=== Changes ===\nno changes\n")
+
+	next.Yaml = strings.NewReplacer("cumulativetodelta", "batch", "batch", "cumulativetodelta").Replace(deployed.Yaml)
+	out.Reset()
+	require.NoError(t, printDiff(&out, deployed, next, "agent.toml", "agent.yaml"))
+	assert.Contains(t, out.String(), "+                - batch\n                 - cumulativetodelta\n-                - batch\n")
+	assert.Contains(t, out.String(), "component service/pipelines/metrics/host\n")
+}
@@ -0,0 +1,98 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/constants"
+	"github.com/aws/amazon-cloudwatch-agent/translator/otelimport"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/toyamlconfig"
+)
+
+const importCommand = "import"
+
+/**
+ *	config-translator import --input ${OTEL_YAML} [--output ${JSON}] [--output-otelconfig ${OTEL_YAML}]
+ *
+ *		Converts an OTel collector config into an agent json config, which is printed if there is no output.
+ *		What has no agent json equivalent is reported, and written to the OTel config fragment for -otelconfig,
+ *		which defaults to the output with the .yaml extension.
+ */
+func runImport(args []string, stdout io.Writer) int {
+	flags := flag.NewFlagSet(importCommand, flag.ContinueOnError)
+	input := flags.String("input", "", "Please provide the path of the input OTel collector yaml config file")
+	output := flags.String("output", "", "Path of the output agent json config file")
+	outputOtelConfig := flags.String("output-otelconfig", "", "Path of the output OTel yaml config fragment")
+	if err := flags.Parse(args); err != nil {
+		return exitCodeUsage
+	}
+	if *input == "" {
+		log.Printf("E! No OTel config file to import")
+		return exitCodeUsage
+	}
+	otelConfigPath := *outputOtelConfig
+	if otelConfigPath == "" && *output != "" {
+		otelConfigPath = strings.TrimSuffix(*output, filepath.Ext(*output)) + constants.FileSuffixYAML
+	}
+
+	content, err := os.ReadFile(*input)
+	if err != nil {
+		log.Printf("E! Failed to read the OTel config: %v", err)
+		return exitCodeInvalid
+	}
+	var otelConfig map[string]interface{}
+	if err = yaml.Unmarshal(content, &otelConfig); err != nil {
+		log.Printf("E! Failed to parse the OTel config %s: %v", *input, err)
+		return exitCodeInvalid
+	}
+	result, err := otelimport.Convert(otelConfig)
+	if err != nil {
+		log.Printf("E! Failed to convert the OTel config %s: %v", *input, err)
+		return exitCodeInvalid
+	}
+	for _, issue := range result.Issues {
+		log.Printf("W! %v", issue)
+	}
+	if result.OtelConfig != nil && otelConfigPath == "" {
+		log.Printf("E! Part of the OTel config has no agent json equivalent, please provide the path of the output OTel config fragment")
+		return exitCodeUsage
+	}
+
+	if result.Config == nil {
+		log.Printf("W! Nothing in the OTel config has an agent json equivalent")
+	} else {
+		config, err := json.MarshalIndent(result.Config, "", "\t")
+		if err != nil {
+			log.Printf("E! Failed to marshal the agent json config: %v", err)
+			return exitCodeInvalid
+		}
+		config = append(config, '\n')
+		if *output == "" {
+			_, err = stdout.Write(config)
+		} else {
+			err = os.WriteFile(*output, config, 0644)
+		}
+		if err != nil {
+			log.Printf("E! Failed to write the agent json config: %v", err)
+			return exitCodeInvalid
+		}
+	}
+	if result.OtelConfig != nil {
+		if err = os.WriteFile(otelConfigPath, []byte(toyamlconfig.ToYamlConfig(result.OtelConfig)), 0644); err != nil {
+			log.Printf("E! Failed to write the OTel config fragment: %v", err)
+			return exitCodeInvalid
+		}
+		log.Printf("I! Wrote the parts without agent json equivalent to %s, pass it to the agent with -otelconfig", otelConfigPath)
+	}
+	return exitCodeValid
+}
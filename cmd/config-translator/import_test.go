@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunImport(t *testing.T) {
+	dir := t.TempDir()
+	mappedFile := filepath.Join(dir, "mapped.yaml")
+	partialFile := filepath.Join(dir, "partial.yaml")
+	require.NoError(t, os.WriteFile(mappedFile, []byte(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+exporters:
+  awsxray:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [awsxray]
+`), 0600))
+	require.NoError(t, os.WriteFile(partialFile, []byte(`
+receivers:
+  filelog:
+    include: [/var/log/app.log]
+  prometheus:
+    config:
+exporters:
+  awscloudwatchlogs:
+    log_group_name: app
+    raw_log: true
+  awscloudwatch:
+service:
+  pipelines:
+    logs:
+      receivers: [filelog]
+      exporters: [awscloudwatchlogs]
+    metrics:
+      receivers: [prometheus]
+      exporters: [awscloudwatch]
+`), 0600))
+
+	t.Run("WithStdout", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.Equal(t, exitCodeValid, runImport([]string{"-input", mappedFile}, &out))
+		assert.JSONEq(t, `{"traces": {"traces_collected": {"otlp": {}}}}`, out.String())
+	})
+	t.Run("WithOutput", func(t *testing.T) {
+		output := filepath.Join(dir, "agent.json")
+		var out bytes.Buffer
+		assert.Equal(t, exitCodeValid, runImport([]string{"-input", partialFile, "-output", output}, &out))
+		assert.Empty(t, out.String())
+		content, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/app.log", "log_group_name": "app"}]}}}}`, string(content))
+		content, err = os.ReadFile(filepath.Join(dir, "agent.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "prometheus/imported")
+	})
+	t.Run("WithoutOutputOtelConfig", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.Equal(t, exitCodeUsage, runImport([]string{"-input", partialFile}, &out))
+		assert.Empty(t, out.String())
+	})
+	t.Run("WithOutputOtelConfig", func(t *testing.T) {
+		outputOtelConfig := filepath.Join(dir, "otel.yaml")
+		var out bytes.Buffer
+		assert.Equal(t, exitCodeValid, runImport([]string{"-input", partialFile, "-output-otelconfig", outputOtelConfig}, &out))
+		assert.Contains(t, out.String(), "collect_list")
+		_, err := os.Stat(outputOtelConfig)
+		assert.NoError(t, err)
+	})
+	t.Run("WithInvalid", func(t *testing.T) {
+		invalidFile := filepath.Join(dir, "invalid.yaml")
+		require.NoError(t, os.WriteFile(invalidFile, []byte(`receivers: [`), 0600))
+		assert.Equal(t, exitCodeInvalid, runImport([]string{"-input", invalidFile}, &bytes.Buffer{}))
+		assert.Equal(t, exitCodeInvalid, runImport([]string{"-input", filepath.Join(dir, "missing.yaml")}, &bytes.Buffer{}))
+		assert.Equal(t, exitCodeUsage, runImport(nil, &bytes.Buffer{}))
+	})
+}
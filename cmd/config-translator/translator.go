@@ -11,7 +11,6 @@ import (
 	"os/user"
 	"path/filepath"
 
-	"github.com/aws/amazon-cloudwatch-agent/cfg/commonconfig"
 	userutil "github.com/aws/amazon-cloudwatch-agent/internal/util/user"
 	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
@@ -28,6 +27,11 @@ const (
 	yamlConfigFileName = "amazon-cloudwatch-agent.yaml"
 )
 
+var (
+	explain = flag.Bool("explain", false, "Print the merged json config, the translated TOML and YAML and which plugins, pipelines and components each json config key produces, without writing any file")
+	diff    = flag.Bool("diff", false, "Print the differences between the translated and the deployed TOML and YAML, without writing any file")
+)
+
 func initFlags() {
 	var inputOs = flag.String("os", "", "Please provide the os preference, valid value: windows/linux.")
 	var inputJsonFile = flag.String("input", "", "Please provide the path of input agent json config file")
@@ -45,23 +49,9 @@ func initFlags() {
 	ctx.SetMultiConfig(*multiConfig)
 	ctx.SetOutputTomlFilePath(*inputTomlFile)
 
-	if *inputConfig != "" {
-		f, err := os.Open(*inputConfig)
-		if err != nil {
-			log.Fatalf("E! Failed to open common-config file %s with error: %v", *inputConfig, err)
-		}
-		defer f.Close()
-		conf, err := commonconfig.Parse(f)
-		if err != nil {
-			log.Fatalf("E! Failed to parse common-config file %s with error: %v", *inputConfig, err)
-		}
-		ctx.SetCredentials(conf.CredentialsMap())
-		ctx.SetProxy(conf.ProxyMap())
-		ctx.SetSSL(conf.SSLMap())
-		translatorUtil.LoadImdsRetries(conf.IMDS)
+	if err := cmdutil.LoadCommonConfig(ctx, *inputConfig); err != nil {
+		log.Fatalf("E! %v", err)
 	}
-	translatorUtil.SetProxyEnv(ctx.Proxy())
-	translatorUtil.SetSSLEnv(ctx.SSL())
 
 	mode := translatorUtil.DetectAgentMode(*inputMode)
 	ctx.SetMode(mode)
@@ -70,14 +60,25 @@ func initFlags() {
 
 /**
  *	config-translator --input ${JSON} --input-dir ${JSON_DIR} --output ${TOML} --mode ${param_mode} --config ${COMMON_CONFIG}
- *  --multi-config [default|append|remove]
+ *  --multi-config [default|append|remove] [--explain] [--diff]
  *
  *		multi-config:
  *			default:	only process .tmp files
  *			append:		process both existing files and .tmp files
  *			remove:		only process existing files
+ *
+ *		explain, diff:	print the translation or its differences to the deployed one instead of writing it
+ *
+ *	config-translator validate ... validates the json config files instead, see runValidate.
+ *	config-translator import ... converts an OTel collector config into a json config instead, see runImport.
  */
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == validateCommand {
+		os.Exit(runValidate(os.Args[2:], os.Stdout))
+	}
+	if len(os.Args) > 1 && os.Args[1] == importCommand {
+		os.Exit(runImport(os.Args[2:], os.Stdout))
+	}
 	initFlags()
 	defer func() {
 		if r := recover(); r != nil {
@@ -96,11 +97,26 @@ func main() {
 	}()
 	ctx := context.CurrentContext()
 
-	mergedJsonConfigMap, err := cmdutil.GenerateMergedJsonConfigMap(ctx)
+	mergedJsonConfigMap, fragments, err := cmdutil.GenerateMergedJsonConfigMap(ctx)
 	if err != nil {
 		log.Panicf("E! Failed to generate merged json config: %v", err)
 	}
 
+	tomlConfigPath := cmdutil.GetTomlConfigPath(ctx.OutputTomlFilePath())
+	tomlConfigDir := filepath.Dir(tomlConfigPath)
+	yamlConfigPath := filepath.Join(tomlConfigDir, yamlConfigFileName)
+	// Put env config into the same folder as the toml config
+	envConfigPath := filepath.Join(tomlConfigDir, envConfigFileName)
+
+	if *explain || *diff {
+		// The translation writes the log config next to the output TOML unless its path is empty.
+		ctx.SetOutputTomlFilePath("")
+		if err = dryRun(os.Stdout, mergedJsonConfigMap, fragments, *explain, *diff, tomlConfigPath, yamlConfigPath, envConfigPath); err != nil {
+			log.Panicf("E! Failed to translate the json config: %v", err)
+		}
+		return
+	}
+
 	if !ctx.RunInContainer() {
 		// run as user only applies to non container situation.
 		current, err := user.Current()
@@ -113,9 +129,6 @@ func main() {
 		}
 	}
 
-	tomlConfigPath := cmdutil.GetTomlConfigPath(ctx.OutputTomlFilePath())
-	tomlConfigDir := filepath.Dir(tomlConfigPath)
-	yamlConfigPath := filepath.Join(tomlConfigDir, yamlConfigFileName)
 	tomlConfig, err := cmdutil.TranslateJsonMapToTomlConfig(mergedJsonConfigMap)
 	if err != nil {
 		log.Panicf("E! Failed to generate TOML configuration validation content: %v", err)
@@ -130,8 +143,9 @@ func main() {
 	if err = cmdutil.ConfigToYamlFile(yamlConfig, yamlConfigPath); err != nil {
 		log.Panicf("E! Failed to create the configuration YAML validation file: %v", err)
 	}
+	if err = cmdutil.WriteFragments(cmdutil.FragmentsPath(tomlConfigPath), fragments); err != nil {
+		log.Printf("E! Failed to write the json config fragments: %v", err)
+	}
 	log.Println(exitSuccessMessage)
-	// Put env config into the same folder as the toml config
-	envConfigPath := filepath.Join(tomlConfigDir, envConfigFileName)
 	cmdutil.TranslateJsonMapToEnvConfigFile(mergedJsonConfigMap, envConfigPath)
 }
@@ -187,6 +187,106 @@ func TestMetricsDestinationsConfig(t *testing.T) {
 	expectedErrorMap["required"] = 1
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidMetricsDestinations.json", false, expectedErrorMap)
 }
+
+func TestDerivedMetricsConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validDerivedMetrics.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["array_min_items"] = 1
+	expectedErrorMap["enum"] = 1
+	expectedErrorMap["number_one_of"] = 1
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidDerivedMetrics.json", false, expectedErrorMap)
+}
+
+func TestAggregationRulesConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validAggregationRules.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["enum"] = 1
+	expectedErrorMap["array_min_properties"] = 2
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidAggregationRules.json", false, expectedErrorMap)
+}
+
+func TestPSICgroupsConfig(t *testing.T) {
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["invalid_type"] = 1
+	expectedErrorMap["number_all_of"] = 2
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidPSICgroups.json", false, expectedErrorMap)
+}
+
+func TestCertificatesConfig(t *testing.T) {
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["number_all_of"] = 1
+	expectedErrorMap["number_any_of"] = 1
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidCertificates.json", false, expectedErrorMap)
+}
+
+func TestEndpointsConfig(t *testing.T) {
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["number_all_of"] = 1
+	expectedErrorMap["number_gte"] = 1
+	expectedErrorMap["pattern"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidEndpoints.json", false, expectedErrorMap)
+}
+
+func TestFilesystemPathsConfig(t *testing.T) {
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["invalid_type"] = 1
+	expectedErrorMap["number_all_of"] = 1
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidFilesystemPaths.json", false, expectedErrorMap)
+}
+
+func TestKernelNetConfig(t *testing.T) {
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["invalid_type"] = 1
+	expectedErrorMap["number_all_of"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidKernelNet.json", false, expectedErrorMap)
+}
+
+func TestNFSConfig(t *testing.T) {
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["invalid_type"] = 1
+	expectedErrorMap["number_all_of"] = 1
+	expectedErrorMap["pattern"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidNFS.json", false, expectedErrorMap)
+}
+
+func TestProcstatAggregateConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validProcstatAggregate.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["enum"] = 1
+	expectedErrorMap["number_all_of"] = 2
+	expectedErrorMap["number_gte"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidProcstatAggregate.json", false, expectedErrorMap)
+}
+
+func TestExecConfig(t *testing.T) {
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["enum"] = 1
+	expectedErrorMap["number_gte"] = 1
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidExec.json", false, expectedErrorMap)
+}
+
+func TestTextfileConfig(t *testing.T) {
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["additional_property_not_allowed"] = 1
+	expectedErrorMap["invalid_type"] = 1
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidTextfile.json", false, expectedErrorMap)
+}
+
+func TestStatsdConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validStatsd.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["additional_property_not_allowed"] = 1
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidStatsd.json", false, expectedErrorMap)
+}
+
 func TestContainerInsightsJmxConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validContainerInsightsJmx.json", true, map[string]int{})
 }
@@ -0,0 +1,91 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"io/fs"
+	"log"
+	"path/filepath"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/constants"
+	"github.com/aws/amazon-cloudwatch-agent/translator/validate"
+)
+
+const (
+	validateCommand = "validate"
+
+	exitCodeValid   = 0
+	exitCodeInvalid = 1
+	exitCodeUsage   = 2
+)
+
+/**
+ *	config-translator validate [--input ${JSON}] [--input-dir ${JSON_DIR}] [--format text|json|sarif] [${JSON}...]
+ *
+ *		Exits with 1 if the json config files have errors. Warnings do not fail the validation.
+ */
+func runValidate(args []string, stdout io.Writer) int {
+	flags := flag.NewFlagSet(validateCommand, flag.ContinueOnError)
+	inputJsonFile := flags.String("input", "", "Please provide the path of input agent json config file")
+	inputJsonDir := flags.String("input-dir", "", "Please provide the path of input agent json config directory.")
+	format := flags.String("format", validate.FormatText, "Output format, valid values: text, json, sarif")
+	if err := flags.Parse(args); err != nil {
+		return exitCodeUsage
+	}
+
+	files, err := validateInputFiles(*inputJsonFile, *inputJsonDir, flags.Args())
+	if err != nil {
+		log.Printf("E! Failed to list json config files: %v", err)
+		return exitCodeInvalid
+	}
+	if len(files) == 0 {
+		log.Printf("E! No json config files to validate")
+		return exitCodeUsage
+	}
+	diagnostics, err := validate.Validate(files, *inputJsonDir)
+	if err != nil {
+		log.Printf("E! Failed to validate json config files: %v", err)
+		return exitCodeInvalid
+	}
+	if err = validate.Write(stdout, *format, diagnostics); err != nil {
+		log.Printf("E! %v", err)
+		return exitCodeUsage
+	}
+	if validate.HasErrors(diagnostics) {
+		return exitCodeInvalid
+	}
+	return exitCodeValid
+}
+
+// validateInputFiles lists the json config files the config-translator would translate from
+// the input file and directory, followed by the given files.
+func validateInputFiles(inputJsonFile, inputJsonDir string, files []string) ([]string, error) {
+	var inputFiles []string
+	if inputJsonFile != "" {
+		inputFiles = append(inputFiles, inputJsonFile)
+	}
+	if inputJsonDir != "" {
+		err := filepath.WalkDir(inputJsonDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			ext := filepath.Ext(path)
+			if d.IsDir() || ext == constants.FileSuffixTmp || ext == constants.FileSuffixYAML {
+				return nil
+			}
+			inputFiles = append(inputFiles, path)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return append(inputFiles, files...), nil
+}
@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunValidate(t *testing.T) {
+	dir := t.TempDir()
+	validFile := filepath.Join(dir, "valid.json")
+	invalidFile := filepath.Join(dir, "invalid.json")
+	configDir := filepath.Join(dir, "config.d")
+	require.NoError(t, os.Mkdir(configDir, 0700))
+	require.NoError(t, os.WriteFile(validFile, []byte(`{"metrics": {"metrics_collected": {"cpu": {"measurement": ["usage_idle"]}}}}`), 0600))
+	require.NoError(t, os.WriteFile(invalidFile, []byte(`{"metrics": {"metrics_collected": {"cpu": {"measurement": "usage_idle"}}}}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "logs.json"), []byte(`{"logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/app.log"}]}}}}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "logs.json.tmp"), []byte(`{`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "otel.yaml"), []byte(`receivers:`), 0600))
+
+	testCases := map[string]struct {
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		"WithValid": {
+			args:     []string{"-input", validFile, "-input-dir", configDir},
+			wantCode: exitCodeValid,
+		},
+		"WithInvalid": {
+			args:     []string{invalidFile},
+			wantCode: exitCodeInvalid,
+			wantOut:  invalidFile + ":1:44: error: metrics.metrics_collected.cpu.measurement: Invalid type. Expected: array, given: string (schema)\n",
+		},
+		"WithJSON": {
+			args:     []string{"-format", "json", validFile},
+			wantCode: exitCodeValid,
+			wantOut:  "[]\n",
+		},
+		"WithMissingFile": {
+			args:     []string{filepath.Join(dir, "missing.json")},
+			wantCode: exitCodeInvalid,
+		},
+		"WithNoFiles": {
+			wantCode: exitCodeUsage,
+		},
+		"WithInvalidFormat": {
+			args:     []string{"-format", "xml", validFile},
+			wantCode: exitCodeUsage,
+		},
+		"WithInvalidFlag": {
+			args:     []string{"-unknown"},
+			wantCode: exitCodeUsage,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			assert.Equal(t, testCase.wantCode, runValidate(testCase.args, &out))
+			if testCase.wantOut != "" {
+				assert.Equal(t, testCase.wantOut, out.String())
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ func startAgent(writer io.WriteCloser) error {
 			"-envconfig", paths.EnvConfigPath,
 		}
 		execArgs = append(execArgs, config.GetOTELConfigArgs(paths.CONFIG_DIR_IN_CONTAINER)...)
+		execArgs = append(execArgs, hotReloadArgs()...)
 		execArgs = append(execArgs, "-pidfile", paths.AgentDir+"/var/amazon-cloudwatch-agent.pid")
 		if err := syscall.Exec(paths.AgentBinaryPath, execArgs, os.Environ()); err != nil {
 			return fmt.Errorf("error exec as agent binary: %w", err)
@@ -72,6 +73,7 @@ func startAgent(writer io.WriteCloser) error {
 		"-envconfig", paths.EnvConfigPath,
 	}
 	agentCmd = append(agentCmd, config.GetOTELConfigArgs(paths.ConfigDirPath)...)
+	agentCmd = append(agentCmd, hotReloadArgs()...)
 	agentCmd = append(agentCmd, "-pidfile", paths.AgentDir+"/var/amazon-cloudwatch-agent.pid")
 	if err = syscall.Exec(name, agentCmd, os.Environ()); err != nil {
 		// log file is closed, so use fmt here
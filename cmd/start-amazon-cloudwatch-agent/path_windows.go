@@ -29,6 +29,7 @@ func startAgent(writer io.WriteCloser) error {
 			"-envconfig", paths.EnvConfigPath,
 		}
 		execArgs = append(execArgs, config.GetOTELConfigArgs(paths.ConfigDirPath)...)
+		execArgs = append(execArgs, hotReloadArgs()...)
 		cmd := exec.Command(paths.AgentBinaryPath, execArgs...)
 		stdoutStderr, err := cmd.CombinedOutput()
 		// log file is closed, so use fmt here
@@ -40,6 +41,7 @@ func startAgent(writer io.WriteCloser) error {
 			"-envconfig", paths.EnvConfigPath,
 		}
 		execArgs = append(execArgs, config.GetOTELConfigArgs(paths.CONFIG_DIR_IN_CONTAINER)...)
+		execArgs = append(execArgs, hotReloadArgs()...)
 		execArgs = append(execArgs, "-console", "true")
 		cmd := exec.Command(paths.AgentBinaryPath, execArgs...)
 		cmd.Stdin = os.Stdin
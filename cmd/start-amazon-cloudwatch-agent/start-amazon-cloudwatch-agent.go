@@ -16,6 +16,7 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
 	"github.com/aws/amazon-cloudwatch-agent/internal/constants"
+	"github.com/aws/amazon-cloudwatch-agent/service/lastknowngood"
 	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
 )
 
@@ -50,6 +51,15 @@ func translateConfig() error {
 	return err
 }
 
+// hotReloadArgs are the agent flags to watch the json config passed to the translator in
+// translateConfig and to translate it in process when it changes.
+func hotReloadArgs() []string {
+	if envconfig.IsRunningInContainer() {
+		return []string{"-json-config-dir", paths.CONFIG_DIR_IN_CONTAINER}
+	}
+	return []string{"-json-config", paths.JsonConfigPath, "-json-config-dir", paths.ConfigDirPath, "-common-config", paths.CommonConfigPath}
+}
+
 func main() {
 	var writer io.WriteCloser
 
@@ -66,7 +76,11 @@ func main() {
 	}
 
 	if err := translateConfig(); err != nil {
-		log.Fatalf("E! Cannot translate JSON, ERROR is %v \n", err)
+		store := lastknowngood.New(paths.TomlConfigPath)
+		if rollbackErr := store.Rollback(err, paths.TomlConfigPath, paths.YamlConfigPath, paths.EnvConfigPath); rollbackErr != nil {
+			log.Fatalf("E! Cannot translate JSON, ERROR is %v \n", err)
+		}
+		log.Printf("W! Cannot translate JSON, rolling back to last-known-good config from %s, ERROR is %v \n", store.Dir(), err)
 	}
 	log.Printf("I! Config has been translated into TOML %s \n", paths.TomlConfigPath)
 	printFileContents(paths.TomlConfigPath)
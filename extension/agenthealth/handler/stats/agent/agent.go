@@ -30,6 +30,7 @@ type Stats struct {
 	RegionType                *string  `json:"rt,omitempty"`
 	Mode                      *string  `json:"m,omitempty"`
 	EntityRejected            *int     `json:"ent,omitempty"`
+	ConfigRollback            *int     `json:"cfrb,omitempty"`
 }
 
 // Merge the other Stats into the current. If the field is not nil,
@@ -80,6 +81,9 @@ func (s *Stats) Merge(other Stats) {
 	if other.EntityRejected != nil {
 		s.EntityRejected = other.EntityRejected
 	}
+	if other.ConfigRollback != nil {
+		s.ConfigRollback = other.ConfigRollback
+	}
 }
 
 func (s *Stats) Marshal() (string, error) {
@@ -24,6 +24,7 @@ const (
 	FlagRunningInContainer
 	FlagMode
 	FlagRegionType
+	FlagConfigRollback
 
 	flagIMDSFallbackSuccessStr       = "imds_fallback_success"
 	flagSharedConfigFallbackStr      = "shared_config_fallback"
@@ -32,6 +33,7 @@ const (
 	flagRunningInContainerStr        = "running_in_container"
 	flagModeStr                      = "mode"
 	flagRegionTypeStr                = "region_type"
+	flagConfigRollbackStr            = "config_rollback"
 )
 
 type Flag int
@@ -43,6 +45,8 @@ func (f Flag) String() string {
 	switch f {
 	case FlagAppSignal:
 		return flagAppSignalsStr
+	case FlagConfigRollback:
+		return flagConfigRollbackStr
 	case FlagEnhancedContainerInsights:
 		return flagEnhancedContainerInsightsStr
 	case FlagIMDSFallbackSuccess:
@@ -71,6 +75,8 @@ func (f *Flag) UnmarshalText(text []byte) error {
 	switch s := string(text); s {
 	case flagAppSignalsStr:
 		*f = FlagAppSignal
+	case flagConfigRollbackStr:
+		*f = FlagConfigRollback
 	case flagEnhancedContainerInsightsStr:
 		*f = FlagEnhancedContainerInsights
 	case flagIMDSFallbackSuccessStr:
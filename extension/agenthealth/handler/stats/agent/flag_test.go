@@ -61,6 +61,7 @@ func TestFlag(t *testing.T) {
 		str  string
 	}{
 		{flag: FlagAppSignal, str: flagAppSignalsStr},
+		{flag: FlagConfigRollback, str: flagConfigRollbackStr},
 		{flag: FlagEnhancedContainerInsights, str: flagEnhancedContainerInsightsStr},
 		{flag: FlagIMDSFallbackSuccess, str: flagIMDSFallbackSuccessStr},
 		{flag: FlagMode, str: flagModeStr},
@@ -34,6 +34,7 @@ func (p *flagStats) update() {
 		SharedConfigFallback:      boolToSparseInt(p.flagSet.IsSet(agent.FlagSharedConfigFallback)),
 		AppSignals:                boolToSparseInt(p.flagSet.IsSet(agent.FlagAppSignal)),
 		EnhancedContainerInsights: boolToSparseInt(p.flagSet.IsSet(agent.FlagEnhancedContainerInsights)),
+		ConfigRollback:            boolToSparseInt(p.flagSet.IsSet(agent.FlagConfigRollback)),
 		RunningInContainer:        boolToInt(p.flagSet.IsSet(agent.FlagRunningInContainer)),
 		Mode:                      p.flagSet.GetString(agent.FlagMode),
 		RegionType:                p.flagSet.GetString(agent.FlagRegionType),
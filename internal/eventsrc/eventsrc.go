@@ -0,0 +1,144 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package eventsrc
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+// Config is the log group the events of an input are published to.
+type Config struct {
+	Description string
+	Destination string
+	Group       string
+	Stream      string
+	Class       string
+	Retention   int
+}
+
+// Src is the log source of the structured events published by an input, e.g. the DogStatsD
+// events of statsd. Events are buffered until the log agent sets the output, and dropped once
+// the buffer is full so that a slow destination does not block the input. The source can be
+// started again by setting the output after it stops.
+type Src struct {
+	config Config
+	events chan logs.LogEvent
+	drops  atomic.Int64
+
+	mu sync.Mutex
+	// done is closed to stop the output, and nil when it is not running.
+	done chan struct{}
+}
+
+var _ logs.LogSrc = (*Src)(nil)
+
+func New(config Config, bufferSize int) *Src {
+	return &Src{
+		config: config,
+		events: make(chan logs.LogEvent, bufferSize),
+	}
+}
+
+// Publish queues the event without blocking. Returns false if the event was dropped.
+func (s *Src) Publish(msg string, t time.Time) bool {
+	select {
+	case s.events <- &event{msg: msg, t: t}:
+		return true
+	default:
+		s.drops.Add(1)
+		return false
+	}
+}
+
+// Drops returns the number of events dropped so far.
+func (s *Src) Drops() int64 {
+	return s.drops.Load()
+}
+
+func (s *Src) SetOutput(fn func(logs.LogEvent)) {
+	if fn == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done != nil {
+		return
+	}
+	s.done = make(chan struct{})
+	go s.run(fn, s.done)
+}
+
+func (s *Src) run(fn func(logs.LogEvent), done <-chan struct{}) {
+	for {
+		select {
+		case e := <-s.events:
+			fn(e)
+		case <-done:
+			// the log agent stops sending to the destination when the output is closed
+			fn(nil)
+			return
+		}
+	}
+}
+
+func (s *Src) Group() string {
+	return s.config.Group
+}
+
+func (s *Src) Stream() string {
+	return s.config.Stream
+}
+
+func (s *Src) Destination() string {
+	return s.config.Destination
+}
+
+func (s *Src) Description() string {
+	return s.config.Description
+}
+
+func (s *Src) Retention() int {
+	return s.config.Retention
+}
+
+func (s *Src) Class() string {
+	return s.config.Class
+}
+
+func (s *Src) Entity() *cloudwatchlogs.Entity {
+	return nil
+}
+
+// Stop closes the output. It is called by the input when it stops, and by the log agent when
+// the destination stops.
+func (s *Src) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done != nil {
+		close(s.done)
+		s.done = nil
+	}
+}
+
+type event struct {
+	msg string
+	t   time.Time
+}
+
+var _ logs.LogEvent = (*event)(nil)
+
+func (e *event) Message() string {
+	return e.msg
+}
+
+func (e *event) Time() time.Time {
+	return e.t
+}
+
+func (e *event) Done() {}
@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package eventsrc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+func receive(t *testing.T, received <-chan logs.LogEvent) logs.LogEvent {
+	t.Helper()
+	select {
+	case e := <-received:
+		return e
+	case <-time.After(time.Second):
+		require.Fail(t, "no event received")
+		return nil
+	}
+}
+
+func TestSrc(t *testing.T) {
+	src := New(Config{Description: "test events", Destination: "cloudwatchlogs", Group: "group", Stream: "stream", Class: "STANDARD", Retention: 7}, 2)
+	assert.Equal(t, "test events", src.Description())
+	assert.Equal(t, "cloudwatchlogs", src.Destination())
+	assert.Equal(t, "group", src.Group())
+	assert.Equal(t, "stream", src.Stream())
+	assert.Equal(t, "STANDARD", src.Class())
+	assert.Equal(t, 7, src.Retention())
+
+	// the events are buffered until the output is set
+	now := time.Now()
+	assert.True(t, src.Publish("first", now))
+	assert.True(t, src.Publish("second", now))
+	assert.False(t, src.Publish("dropped", now))
+	assert.EqualValues(t, 1, src.Drops())
+
+	received := make(chan logs.LogEvent, 3)
+	output := func(e logs.LogEvent) {
+		received <- e
+	}
+	src.SetOutput(output)
+	e := receive(t, received)
+	assert.Equal(t, "first", e.Message())
+	assert.Equal(t, now, e.Time())
+	assert.Equal(t, "second", receive(t, received).Message())
+
+	src.Stop()
+	assert.Nil(t, receive(t, received), "the output is closed when the source stops")
+	src.Stop()
+
+	// the source is started again with a new output
+	src.SetOutput(output)
+	assert.True(t, src.Publish("third", now))
+	assert.Equal(t, "third", receive(t, received).Message())
+	src.Stop()
+	assert.Nil(t, receive(t, received))
+}
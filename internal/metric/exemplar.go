@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metric
+
+import (
+	"encoding/hex"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	traceIDLabel = "trace_id"
+	spanIDLabel  = "span_id"
+)
+
+// Exemplar is a sample recorded with the labels linking it to a trace.
+type Exemplar struct {
+	Labels    map[string]string
+	Value     float64
+	Timestamp time.Time
+}
+
+// ValueWithExemplar is a Telegraf field value with the exemplar of the sample. The adapter
+// converts the value as usual, and the exemplar to an exemplar of the OTel data point, so
+// that its labels are not attributes of the data point.
+type ValueWithExemplar struct {
+	Value    interface{}
+	Exemplar Exemplar
+}
+
+// AppendTo appends the exemplar to the exemplars of a data point. The trace_id and span_id
+// labels are the trace and span IDs of the exemplar if they are hex encoded IDs, the other
+// labels are its filtered attributes.
+func (e Exemplar) AppendTo(exemplars pmetric.ExemplarSlice) {
+	exemplar := exemplars.AppendEmpty()
+	exemplar.SetDoubleValue(e.Value)
+	exemplar.SetTimestamp(pcommon.NewTimestampFromTime(e.Timestamp))
+	for name, value := range e.Labels {
+		id, err := hex.DecodeString(value)
+		switch {
+		case name == traceIDLabel && err == nil && len(id) == len(pcommon.TraceID{}):
+			exemplar.SetTraceID(pcommon.TraceID(id))
+		case name == spanIDLabel && err == nil && len(id) == len(pcommon.SpanID{}):
+			exemplar.SetSpanID(pcommon.SpanID(id))
+		default:
+			exemplar.FilteredAttributes().PutStr(name, value)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metric
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestExemplarAppendTo(t *testing.T) {
+	now := time.Now()
+	exemplars := pmetric.NewExemplarSlice()
+	Exemplar{
+		Labels: map[string]string{
+			"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+			"span_id":  "00f067aa0ba902b7",
+			"user":     "alice",
+		},
+		Value:     1.5,
+		Timestamp: now,
+	}.AppendTo(exemplars)
+	Exemplar{Labels: map[string]string{"trace_id": "not-hex"}, Value: 2, Timestamp: now}.AppendTo(exemplars)
+
+	assert.Equal(t, 2, exemplars.Len())
+	e := exemplars.At(0)
+	assert.Equal(t, 1.5, e.DoubleValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), e.Timestamp())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", e.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", e.SpanID().String())
+	assert.Equal(t, map[string]interface{}{"user": "alice"}, e.FilteredAttributes().AsRaw())
+	// labels that are not IDs are kept as attributes
+	e = exemplars.At(1)
+	assert.True(t, e.TraceID().IsEmpty())
+	assert.Equal(t, map[string]interface{}{"trace_id": "not-hex"}, e.FilteredAttributes().AsRaw())
+}
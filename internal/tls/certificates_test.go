@@ -0,0 +1,91 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestCertificate(t *testing.T, commonName string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return der, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestReadCertificates(t *testing.T) {
+	dir := t.TempDir()
+	leaf, key := createTestCertificate(t, "leaf")
+	intermediate, _ := createTestCertificate(t, "intermediate")
+	bundle := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf}), key...)
+	bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intermediate})...)
+
+	testCases := map[string]struct {
+		content   []byte
+		wantNames []string
+		wantErr   bool
+	}{
+		"PEMBundle": {
+			content:   bundle,
+			wantNames: []string{"leaf", "intermediate"},
+		},
+		"DER": {
+			content:   leaf,
+			wantNames: []string{"leaf"},
+		},
+		"PrivateKey": {
+			content: key,
+		},
+		"InvalidDER": {
+			content: []byte("not a certificate"),
+			wantErr: true,
+		},
+		"InvalidPEM": {
+			content: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("invalid")}),
+			wantErr: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(path, testCase.content, 0600))
+			certs, err := ReadCertificates(path)
+			if testCase.wantErr {
+				assert.ErrorContains(t, err, path)
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, cert := range certs {
+				names = append(names, cert.Subject.CommonName)
+			}
+			assert.Equal(t, testCase.wantNames, names)
+		})
+	}
+
+	_, err := ReadCertificates(filepath.Join(dir, "missing"))
+	assert.True(t, os.IsNotExist(err))
+}
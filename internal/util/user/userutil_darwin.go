@@ -30,6 +30,31 @@ const (
 	dsbin = "/usr/bin/dscacheutil"
 )
 
+type ExecUser struct {
+	Uid  int
+	Gid  int
+	Home string
+	Gids []int
+}
+
+// LookupExecUser returns the IDs and home directory of the user, e.g. to run a
+// command as another user. The supplementary groups are not looked up.
+func LookupExecUser(runAsUser string) (*ExecUser, error) {
+	u, err := dsLookup(runAsUser)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return nil, fmt.Errorf("UID %s cannot be converted to integer uid: %w", u.Uid, err)
+	}
+	gid, err := strconv.Atoi(u.Gid)
+	if err != nil {
+		return nil, fmt.Errorf("GID %s cannot be converted to integer gid: %w", u.Gid, err)
+	}
+	return &ExecUser{Uid: uid, Gid: gid, Home: u.HomeDir}, nil
+}
+
 // dsLookup shells out to dscacheutil to get uid, gid from username.
 func dsLookup(username string) (*user.User, error) {
 	// dscacheutil -q user -a name cwagent
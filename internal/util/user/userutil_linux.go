@@ -141,6 +141,12 @@ func getRunAsExecUser(runasuser string) (*ExecUser, error) {
 	return toExecUser(newUser)
 }
 
+// LookupExecUser returns the IDs and home directory of the user, e.g. to run a
+// command as another user.
+func LookupExecUser(runAsUser string) (*ExecUser, error) {
+	return getRunAsExecUser(runAsUser)
+}
+
 func ChangeUser(runAsUser string) (string, error) {
 	if runAsUser == "" {
 		runAsUser = "root"
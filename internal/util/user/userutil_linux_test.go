@@ -34,3 +34,12 @@ func TestGetGroupIds(t *testing.T) {
 	require.Nil(t, err, "Failed to retrieve group IDs for user: not-in-file")
 	assert.Len(t, gids, 0)
 }
+
+func TestLookupExecUser(t *testing.T) {
+	execUser, err := LookupExecUser("root")
+	require.NoError(t, err)
+	assert.Equal(t, 0, execUser.Uid)
+	assert.Equal(t, 0, execUser.Gid)
+	_, err = LookupExecUser("not-a-user-on-the-host")
+	assert.Error(t, err)
+}
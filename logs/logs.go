@@ -6,7 +6,9 @@ package logs
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/influxdata/telegraf"
@@ -24,6 +26,13 @@ type LogCollection interface {
 	Start(acc telegraf.Accumulator) error
 }
 
+// A ReloadableLogCollection is a LogCollection that can take over the configuration of a
+// newly loaded instance of the same plugin, keeping the LogSrc that did not change running.
+type ReloadableLogCollection interface {
+	LogCollection
+	Reload(LogCollection) error
+}
+
 type LogEvent interface {
 	Message() string
 	Time() time.Time
@@ -63,6 +72,7 @@ type LogAgent struct {
 	destNames                 map[LogDest]string
 	collections               []LogCollection
 	retentionAlreadyAttempted map[string]bool
+	mu                        sync.Mutex
 }
 
 func NewLogAgent(c *config.Config) *LogAgent {
@@ -109,6 +119,7 @@ func (l *LogAgent) Run(ctx context.Context) {
 		select {
 		case <-t.C:
 			log.Printf("D! [logagent] open file count, %v", tail.OpenFileCount.Load())
+			l.mu.Lock()
 			for _, c := range l.collections {
 				srcs := c.FindLogSrc()
 				for _, src := range srcs {
@@ -130,12 +141,68 @@ func (l *LogAgent) Run(ctx context.Context) {
 					go l.runSrcToDest(src, dest)
 				}
 			}
+			l.mu.Unlock()
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// Reload applies the named inputs of the new config to the running log collections. Nothing
+// is changed and an error is returned if any of them was added, removed or cannot be reloaded,
+// in which case the agent needs to be restarted.
+func (l *LogAgent) Reload(c *config.Config, inputs []string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	running := make([]ReloadableLogCollection, len(inputs))
+	next := make([]LogCollection, len(inputs))
+	for i, name := range inputs {
+		current, err := findLogCollection(l.Config, name)
+		if err != nil {
+			return err
+		}
+		next[i], err = findLogCollection(c, name)
+		if err != nil {
+			return err
+		}
+		if current == nil || next[i] == nil {
+			return fmt.Errorf("log collection %s was added or removed", name)
+		}
+		var ok bool
+		if running[i], ok = current.(ReloadableLogCollection); !ok {
+			return fmt.Errorf("log collection %s cannot be reloaded", name)
+		}
+	}
+	for i, collection := range running {
+		if err := collection.Reload(next[i]); err != nil {
+			return fmt.Errorf("could not reload log collection %s: %w", inputs[i], err)
+		}
+		log.Printf("I! [logagent] reloaded log collection %s", inputs[i])
+	}
+	return nil
+}
+
+// findLogCollection returns the log collection of the input with the given name, or nil if
+// there is none.
+func findLogCollection(c *config.Config, name string) (LogCollection, error) {
+	var found LogCollection
+	for _, input := range c.Inputs {
+		if input.Config.Name != name {
+			continue
+		}
+		collection, ok := input.Input.(LogCollection)
+		if !ok {
+			return nil, fmt.Errorf("plugin %s is not a log collection", name)
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found more than one log collection %s", name)
+		}
+		found = collection
+	}
+	return found, nil
+}
+
 func (l *LogAgent) runSrcToDest(src LogSrc, dest LogDest) {
 	eventsCh := make(chan LogEvent)
 	defer src.Stop()
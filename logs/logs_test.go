@@ -4,9 +4,12 @@
 package logs
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,3 +23,76 @@ func TestRetentionAlreadySet(t *testing.T) {
 	assert.Equal(t, -1, secondAttempt)
 	assert.True(t, l.retentionAlreadyAttempted["logGroup1"])
 }
+
+type stubNonReloadableLogCollection struct{}
+
+func (s *stubNonReloadableLogCollection) SampleConfig() string              { return "" }
+func (s *stubNonReloadableLogCollection) Description() string               { return "" }
+func (s *stubNonReloadableLogCollection) Gather(telegraf.Accumulator) error { return nil }
+func (s *stubNonReloadableLogCollection) FindLogSrc() []LogSrc              { return nil }
+func (s *stubNonReloadableLogCollection) Start(telegraf.Accumulator) error  { return nil }
+
+type stubLogCollection struct {
+	stubNonReloadableLogCollection
+	reloaded  []LogCollection
+	reloadErr error
+}
+
+func (s *stubLogCollection) Reload(collection LogCollection) error {
+	if s.reloadErr != nil {
+		return s.reloadErr
+	}
+	s.reloaded = append(s.reloaded, collection)
+	return nil
+}
+
+func newConfigWithInputs(inputs map[string]telegraf.Input) *config.Config {
+	c := config.NewConfig()
+	for name, input := range inputs {
+		c.Inputs = append(c.Inputs, models.NewRunningInput(input, &models.InputConfig{Name: name}))
+	}
+	return c
+}
+
+func TestReload(t *testing.T) {
+	running := &stubLogCollection{}
+	l := NewLogAgent(newConfigWithInputs(map[string]telegraf.Input{
+		"logfile":           running,
+		"windows_event_log": &stubNonReloadableLogCollection{},
+	}))
+
+	next := &stubLogCollection{}
+	assert.NoError(t, l.Reload(newConfigWithInputs(map[string]telegraf.Input{
+		"logfile":           next,
+		"windows_event_log": &stubNonReloadableLogCollection{},
+	}), []string{"logfile"}))
+	assert.Equal(t, []LogCollection{next}, running.reloaded)
+
+	testCases := map[string]struct {
+		inputs map[string]telegraf.Input
+		names  []string
+	}{
+		"WithAddedCollection": {
+			inputs: map[string]telegraf.Input{"logfile": next, "other_logs": &stubLogCollection{}},
+			names:  []string{"other_logs"},
+		},
+		"WithRemovedCollection": {
+			inputs: map[string]telegraf.Input{},
+			names:  []string{"logfile"},
+		},
+		"WithNonReloadable": {
+			inputs: map[string]telegraf.Input{"logfile": next, "windows_event_log": &stubNonReloadableLogCollection{}},
+			names:  []string{"logfile", "windows_event_log"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			running.reloaded = nil
+			assert.Error(t, l.Reload(newConfigWithInputs(testCase.inputs), testCase.names))
+			assert.Empty(t, running.reloaded)
+		})
+	}
+
+	running.reloadErr = errors.New("invalid")
+	assert.ErrorIs(t, l.Reload(newConfigWithInputs(map[string]telegraf.Input{"logfile": next}), []string{"logfile"}), running.reloadErr)
+}
@@ -0,0 +1,316 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package certificates
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	_ "embed"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	internaltls "github.com/aws/amazon-cloudwatch-agent/internal/tls"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/globpath"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement = "certificates"
+
+	subjectTag = "Subject"
+	issuerTag  = "Issuer"
+	serialTag  = "SerialNumber"
+	sourceTag  = "Source"
+
+	defaultTimeout = 5 * time.Second
+)
+
+// Certificates reports the expiry of the certificates in local files and served by
+// local TLS listeners. The parsed certificates of the files are cached until a
+// change to their directory is observed.
+type Certificates struct {
+	Files      []string        `toml:"files"`
+	Endpoints  []string        `toml:"endpoints"`
+	ServerName string          `toml:"server_name"`
+	Timeout    config.Duration `toml:"timeout"`
+	Log        telegraf.Logger `toml:"-"`
+
+	globs []*globpath.GlobPath
+	now   func() time.Time
+
+	mu sync.Mutex
+	// cache is nil if the files are not watched.
+	cache map[string][]*x509.Certificate
+	// generation is incremented on every change so that certificates read while a
+	// file changed are not cached.
+	generation uint64
+	watcher    *fsnotify.Watcher
+	watched    map[string]bool
+	wg         sync.WaitGroup
+}
+
+var _ telegraf.ServiceInput = (*Certificates)(nil)
+
+func (c *Certificates) Description() string {
+	return "Report the expiry of the local TLS certificates"
+}
+
+func (*Certificates) SampleConfig() string {
+	return sampleConfig
+}
+
+func (c *Certificates) Init() error {
+	if len(c.Files) == 0 && len(c.Endpoints) == 0 {
+		return fmt.Errorf("no certificate files or endpoints configured")
+	}
+	for _, file := range c.Files {
+		g, err := globpath.Compile(file)
+		if err != nil {
+			return fmt.Errorf("invalid certificate file pattern %q: %w", file, err)
+		}
+		c.globs = append(c.globs, g)
+	}
+	return nil
+}
+
+// Start watches the directories of the certificate files. The files are re-read on
+// every collection if they cannot be watched.
+func (c *Certificates) Start(telegraf.Accumulator) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watcher != nil || len(c.globs) == 0 {
+		return nil
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		c.Log.Warnf("Unable to watch the certificate files, they will be re-read on every collection: %v", err)
+		return nil
+	}
+	c.watcher = watcher
+	c.cache = map[string][]*x509.Certificate{}
+	c.watched = map[string]bool{}
+	c.wg.Add(1)
+	go c.watch(watcher)
+	return nil
+}
+
+func (c *Certificates) Stop() {
+	c.mu.Lock()
+	watcher := c.watcher
+	c.watcher = nil
+	c.cache = nil
+	c.mu.Unlock()
+	if watcher != nil {
+		watcher.Close()
+	}
+	c.wg.Wait()
+}
+
+func (c *Certificates) watch(watcher *fsnotify.Watcher) {
+	defer c.wg.Done()
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			c.handleEvent(event)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			c.Log.Errorf("Certificate watch error: %v", err)
+		}
+	}
+}
+
+// handleEvent drops the cached certificates of the directory of the changed file.
+// The whole directory is dropped since certificates are often replaced by swapping
+// a symlink, e.g. by cert-manager or certbot.
+func (c *Certificates) handleEvent(event fsnotify.Event) {
+	if event.Op == fsnotify.Chmod {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	dir := filepath.Dir(event.Name)
+	for path := range c.cache {
+		if path == event.Name || filepath.Dir(path) == dir {
+			delete(c.cache, path)
+		}
+	}
+	// the watch of a removed directory is dropped, so re-add it on the next collection
+	if c.watched[event.Name] && event.Op.Has(fsnotify.Remove) {
+		delete(c.watched, event.Name)
+	}
+}
+
+func (c *Certificates) Gather(acc telegraf.Accumulator) error {
+	now := c.now()
+	seen := map[string]bool{}
+	for _, g := range c.globs {
+		for path, info := range g.Match() {
+			if !info.IsDir() {
+				c.gatherFile(acc, path, false, seen, now)
+				continue
+			}
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				acc.AddError(fmt.Errorf("unable to read certificate directory %q: %w", path, err))
+				continue
+			}
+			c.addWatch(path)
+			for _, entry := range entries {
+				if !entry.IsDir() {
+					c.gatherFile(acc, filepath.Join(path, entry.Name()), true, seen, now)
+				}
+			}
+		}
+	}
+	c.pruneCache(seen)
+
+	var wg sync.WaitGroup
+	for _, endpoint := range c.Endpoints {
+		wg.Add(1)
+		go func(endpoint string) {
+			defer wg.Done()
+			certs, err := c.fetchCertificates(endpoint)
+			if err != nil {
+				acc.AddError(err)
+				return
+			}
+			addCertificates(acc, endpoint, certs, now)
+		}(endpoint)
+	}
+	wg.Wait()
+	return nil
+}
+
+// gatherFile reports the certificates in the file. Files found in a directory that
+// are not certificates are skipped.
+func (c *Certificates) gatherFile(acc telegraf.Accumulator, path string, inDir bool, seen map[string]bool, now time.Time) {
+	if seen[path] {
+		return
+	}
+	seen[path] = true
+	certs, err := c.readCertificates(path, inDir)
+	if err != nil {
+		if inDir {
+			c.Log.Debugf("Skipping %s: %v", path, err)
+		} else {
+			acc.AddError(err)
+		}
+		return
+	}
+	addCertificates(acc, path, certs, now)
+}
+
+func (c *Certificates) readCertificates(path string, inDir bool) ([]*x509.Certificate, error) {
+	c.mu.Lock()
+	certs, ok := c.cache[path]
+	generation := c.generation
+	c.mu.Unlock()
+	if ok {
+		return certs, nil
+	}
+	if !inDir {
+		c.addWatch(filepath.Dir(path))
+	}
+	certs, err := internaltls.ReadCertificates(path)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.cache != nil && c.generation == generation {
+		c.cache[path] = certs
+	}
+	c.mu.Unlock()
+	return certs, nil
+}
+
+func (c *Certificates) addWatch(dir string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watcher == nil || c.watched[dir] {
+		return
+	}
+	if err := c.watcher.Add(dir); err != nil {
+		c.Log.Warnf("Unable to watch certificate directory %s: %v", dir, err)
+		return
+	}
+	c.watched[dir] = true
+}
+
+// pruneCache drops the certificates of the files that no longer match.
+func (c *Certificates) pruneCache(seen map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for path := range c.cache {
+		if !seen[path] {
+			delete(c.cache, path)
+		}
+	}
+}
+
+// fetchCertificates returns the certificate chain served by the TLS listener. The
+// chain is not verified since expired and self-signed certificates are reported too.
+func (c *Certificates) fetchCertificates(endpoint string) ([]*x509.Certificate, error) {
+	serverName := c.ServerName
+	if serverName == "" {
+		host, _, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+		}
+		serverName = host
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout))
+	defer cancel()
+	dialer := &tls.Dialer{Config: &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true, // nolint:gosec
+	}}
+	conn, err := dialer.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the certificates of %s: %w", endpoint, err)
+	}
+	defer conn.Close()
+	return conn.(*tls.Conn).ConnectionState().PeerCertificates, nil
+}
+
+func addCertificates(acc telegraf.Accumulator, source string, certs []*x509.Certificate, now time.Time) {
+	for _, cert := range certs {
+		tags := map[string]string{
+			subjectTag: cert.Subject.String(),
+			issuerTag:  cert.Issuer.String(),
+			serialTag:  cert.SerialNumber.Text(16),
+			sourceTag:  source,
+		}
+		fields := map[string]interface{}{
+			"days_until_expiry": cert.NotAfter.Sub(now).Hours() / 24,
+		}
+		acc.AddGauge(measurement, fields, tags, now)
+	}
+}
+
+func init() {
+	inputs.Add("certificates", func() telegraf.Input {
+		return &Certificates{
+			Timeout: config.Duration(defaultTimeout),
+			now:     time.Now,
+		}
+	})
+}
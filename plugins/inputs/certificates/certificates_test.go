@@ -0,0 +1,164 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package certificates
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func writeCertificate(t *testing.T, path string, commonName string, serial int64, notAfter time.Time, asPEM bool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Example"}},
+		NotBefore:    testNow.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	content := der
+	if asPEM {
+		content = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+	require.NoError(t, os.WriteFile(path, content, 0600))
+}
+
+func newTestCertificates(t *testing.T, files []string, endpoints []string) *Certificates {
+	c := &Certificates{
+		Files:     files,
+		Endpoints: endpoints,
+		Timeout:   config.Duration(defaultTimeout),
+		Log:       testutil.Logger{},
+		now:       func() time.Time { return testNow },
+	}
+	require.NoError(t, c.Init())
+	return c
+}
+
+func TestGather_Files(t *testing.T) {
+	dir := t.TempDir()
+	pemPath := filepath.Join(dir, "server.pem")
+	writeCertificate(t, pemPath, "server.example.com", 0xabc, testNow.AddDate(0, 0, 30), true)
+	certDir := filepath.Join(dir, "certs")
+	require.NoError(t, os.Mkdir(certDir, 0755))
+	derPath := filepath.Join(certDir, "client.der")
+	writeCertificate(t, derPath, "client.example.com", 2, testNow.Add(-36*time.Hour), false)
+	require.NoError(t, os.WriteFile(filepath.Join(certDir, "README"), []byte("not a certificate"), 0600))
+
+	c := newTestCertificates(t, []string{filepath.Join(dir, "*.pem"), certDir, pemPath}, nil)
+	acc := &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	assert.Len(t, acc.Metrics, 2, "the certificates of a file matched twice are reported once")
+
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"days_until_expiry": 30.0,
+	}, map[string]string{
+		subjectTag: "CN=server.example.com,O=Example",
+		issuerTag:  "CN=server.example.com,O=Example",
+		serialTag:  "abc",
+		sourceTag:  pemPath,
+	})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"days_until_expiry": -1.5,
+	}, map[string]string{
+		subjectTag: "CN=client.example.com,O=Example",
+		issuerTag:  "CN=client.example.com,O=Example",
+		serialTag:  "2",
+		sourceTag:  derPath,
+	})
+}
+
+func TestGather_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.crt")
+	require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0600))
+	c := newTestCertificates(t, []string{path}, nil)
+	acc := &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	assert.Len(t, acc.Errors, 1)
+	assert.Empty(t, acc.Metrics)
+}
+
+func TestGather_ReReadOnChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "server.pem")
+	writeCertificate(t, path, "server.example.com", 1, testNow.AddDate(0, 0, 1), true)
+
+	c := newTestCertificates(t, []string{path}, nil)
+	require.NoError(t, c.Start(nil))
+	defer c.Stop()
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	acc.AssertContainsFields(t, measurement, map[string]interface{}{"days_until_expiry": 1.0})
+	c.mu.Lock()
+	assert.Len(t, c.cache, 1)
+	c.mu.Unlock()
+
+	// the renewed certificate is written to a temporary file and renamed
+	tmp := filepath.Join(dir, ".server.pem.tmp")
+	writeCertificate(t, tmp, "server.example.com", 2, testNow.AddDate(0, 0, 90), true)
+	require.NoError(t, os.Rename(tmp, path))
+	assert.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.cache) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	acc = &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"days_until_expiry": 90.0,
+	}, map[string]string{
+		subjectTag: "CN=server.example.com,O=Example",
+		issuerTag:  "CN=server.example.com,O=Example",
+		serialTag:  "2",
+		sourceTag:  path,
+	})
+}
+
+func TestGather_Endpoints(t *testing.T) {
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+	endpoint := server.Listener.Addr().String()
+	c := newTestCertificates(t, nil, []string{endpoint, "localhost"})
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	require.Len(t, acc.Errors, 1)
+	assert.ErrorContains(t, acc.Errors[0], "invalid endpoint")
+	require.Len(t, acc.Metrics, 1)
+	cert := server.Certificate()
+	assert.Equal(t, map[string]string{
+		subjectTag: cert.Subject.String(),
+		issuerTag:  cert.Issuer.String(),
+		serialTag:  cert.SerialNumber.Text(16),
+		sourceTag:  endpoint,
+	}, acc.Metrics[0].Tags)
+	assert.InDelta(t, cert.NotAfter.Sub(testNow).Hours()/24, acc.Metrics[0].Fields["days_until_expiry"], 0.001)
+}
+
+func TestInit(t *testing.T) {
+	assert.Error(t, (&Certificates{}).Init())
+	assert.Error(t, (&Certificates{Files: []string{"/etc/ssl/**/["}}).Init())
+}
@@ -0,0 +1,293 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cgroups
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/filter"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/psi"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	defaultCgroupPath = "/sys/fs/cgroup"
+	serviceNameTag    = "ServiceName"
+)
+
+var (
+	defaultUnits = []string{"*.service"}
+	// unitSuffixes are the systemd units that own a cgroup.
+	unitSuffixes      = []string{".service", ".slice", ".scope"}
+	pressureResources = []string{"cpu", "memory", "io"}
+)
+
+// Cgroups collects the CPU, memory, IO, pids and pressure accounting of the systemd
+// units in the cgroup v2 unified hierarchy.
+type Cgroups struct {
+	CgroupPath string          `toml:"cgroup_path"`
+	Units      []string        `toml:"units"`
+	Log        telegraf.Logger `toml:"-"`
+
+	filter filter.Filter
+	// previous holds the cumulative usage of each cgroup from the last gather to
+	// calculate the utilization and throughput.
+	previous map[string]usage
+	now      func() time.Time
+}
+
+type usage struct {
+	timestamp time.Time
+	cpu       *cpuUsage
+	io        *ioUsage
+}
+
+type cpuUsage struct {
+	usageUsec     uint64
+	throttledUsec uint64
+}
+
+type ioUsage struct {
+	readBytes  uint64
+	writeBytes uint64
+	readOps    uint64
+	writeOps   uint64
+}
+
+func (c *Cgroups) Description() string {
+	return "Read the resource usage of systemd units from the cgroup v2 hierarchy"
+}
+
+func (*Cgroups) SampleConfig() string {
+	return sampleConfig
+}
+
+func (c *Cgroups) Init() error {
+	units := c.Units
+	if len(units) == 0 {
+		units = defaultUnits
+	}
+	var err error
+	if c.filter, err = filter.Compile(units); err != nil {
+		return fmt.Errorf("invalid units %v: %w", units, err)
+	}
+	return nil
+}
+
+func (c *Cgroups) Gather(acc telegraf.Accumulator) error {
+	if _, err := os.Stat(filepath.Join(c.CgroupPath, "cgroup.controllers")); err != nil {
+		return fmt.Errorf("cgroup v2 unified hierarchy is not mounted at %s: %w", c.CgroupPath, err)
+	}
+	now := c.now()
+	current := map[string]usage{}
+	err := filepath.WalkDir(c.CgroupPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// cgroups are removed when their unit stops
+			if path != c.CgroupPath && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() || path == c.CgroupPath || !isUnit(d.Name()) || !c.filter.Match(d.Name()) {
+			return nil
+		}
+		fields, u := c.gatherUnit(acc, path, now)
+		current[path] = u
+		if len(fields) > 0 {
+			acc.AddGauge("cgroups", fields, map[string]string{serviceNameTag: d.Name()}, now)
+		}
+		return nil
+	})
+	c.previous = current
+	return err
+}
+
+func (c *Cgroups) gatherUnit(acc telegraf.Accumulator, path string, now time.Time) (map[string]interface{}, usage) {
+	fields := map[string]interface{}{}
+	u := usage{timestamp: now}
+	prev, hasPrev := c.previous[path]
+	elapsed := now.Sub(prev.timestamp)
+	hasPrev = hasPrev && elapsed > 0
+
+	if stat, err := readKeyValues(filepath.Join(path, "cpu.stat")); err == nil {
+		u.cpu = &cpuUsage{usageUsec: stat["usage_usec"], throttledUsec: stat["throttled_usec"]}
+		if hasPrev && prev.cpu != nil {
+			if d, ok := delta(prev.cpu.usageUsec, u.cpu.usageUsec); ok {
+				fields["cpu_usage_percent"] = float64(d) / float64(elapsed.Microseconds()) * 100
+			}
+			if d, ok := delta(prev.cpu.throttledUsec, u.cpu.throttledUsec); ok {
+				fields["cpu_throttled_percent"] = float64(d) / float64(elapsed.Microseconds()) * 100
+			}
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		acc.AddError(err)
+	}
+
+	if stat, err := readIOStat(filepath.Join(path, "io.stat")); err == nil {
+		u.io = stat
+		if hasPrev && prev.io != nil {
+			addRate(fields, "io_read_bytes_per_sec", prev.io.readBytes, u.io.readBytes, elapsed)
+			addRate(fields, "io_write_bytes_per_sec", prev.io.writeBytes, u.io.writeBytes, elapsed)
+			addRate(fields, "io_read_ops_per_sec", prev.io.readOps, u.io.readOps, elapsed)
+			addRate(fields, "io_write_ops_per_sec", prev.io.writeOps, u.io.writeOps, elapsed)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		acc.AddError(err)
+	}
+
+	memoryCurrent, err := readUint(filepath.Join(path, "memory.current"))
+	if err == nil {
+		fields["memory_current"] = memoryCurrent
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		acc.AddError(err)
+	}
+	// memory.max is "max" when the unit has no memory limit
+	if memoryMax, err := readUint(filepath.Join(path, "memory.max")); err == nil && memoryMax > 0 {
+		fields["memory_max"] = memoryMax
+		if _, ok := fields["memory_current"]; ok {
+			fields["memory_used_percent"] = float64(memoryCurrent) / float64(memoryMax) * 100
+		}
+	}
+	if swapCurrent, err := readUint(filepath.Join(path, "memory.swap.current")); err == nil {
+		fields["memory_swap_current"] = swapCurrent
+	}
+	if pidsCurrent, err := readUint(filepath.Join(path, "pids.current")); err == nil {
+		fields["pids_current"] = pidsCurrent
+	}
+
+	for _, resource := range pressureResources {
+		if pressures, err := psi.ReadPressureFile(filepath.Join(path, resource+".pressure")); err == nil {
+			psi.AddPressureFields(fields, resource+"_pressure", pressures)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			acc.AddError(err)
+		}
+	}
+	return fields, u
+}
+
+func isUnit(name string) bool {
+	for _, suffix := range unitSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// delta returns the increase of a counter. Returns false if the counter was reset,
+// e.g. the unit was restarted between gathers.
+func delta(prev, cur uint64) (uint64, bool) {
+	if cur < prev {
+		return 0, false
+	}
+	return cur - prev, true
+}
+
+func addRate(fields map[string]interface{}, key string, prev, cur uint64, elapsed time.Duration) {
+	if d, ok := delta(prev, cur); ok {
+		fields[key] = float64(d) / elapsed.Seconds()
+	}
+}
+
+func readUint(path string) (uint64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	value := strings.TrimSpace(string(content))
+	if value == "max" {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	return v, nil
+}
+
+// readKeyValues reads a flat keyed file, e.g. cpu.stat, with a "<key> <value>" pair
+// on each line.
+func readKeyValues(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	values := map[string]uint64{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+		values[key] = v
+	}
+	return values, scanner.Err()
+}
+
+// readIOStat sums the per device lines of io.stat, e.g.
+// 8:0 rbytes=1048576 wbytes=2097152 rios=100 wios=200 dbytes=0 dios=0
+func readIOStat(path string) (*ioUsage, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var stat ioUsage
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+			}
+			switch key {
+			case "rbytes":
+				stat.readBytes += v
+			case "wbytes":
+				stat.writeBytes += v
+			case "rios":
+				stat.readOps += v
+			case "wios":
+				stat.writeOps += v
+			}
+		}
+	}
+	return &stat, scanner.Err()
+}
+
+func init() {
+	inputs.Add("cgroups", func() telegraf.Input {
+		return &Cgroups{
+			CgroupPath: defaultCgroupPath,
+			now:        time.Now,
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cgroups
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCgroups(t *testing.T, path string, units []string, now func() time.Time) *Cgroups {
+	c := &Cgroups{CgroupPath: path, Units: units, now: now}
+	require.NoError(t, c.Init())
+	return c
+}
+
+func serviceNames(acc *testutil.Accumulator) []string {
+	var names []string
+	for _, m := range acc.GetTelegrafMetrics() {
+		names = append(names, m.Tags()[serviceNameTag])
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestGather(t *testing.T) {
+	c := newTestCgroups(t, filepath.Join("testdata", "cgroup"), nil, time.Now)
+	acc := &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	assert.Equal(t, []string{"nginx.service", "sshd.service"}, serviceNames(acc))
+
+	acc.AssertContainsTaggedFields(t, "cgroups", map[string]interface{}{
+		"memory_current":              uint64(104857600),
+		"memory_max":                  uint64(419430400),
+		"memory_used_percent":         25.0,
+		"memory_swap_current":         uint64(4096),
+		"pids_current":                uint64(12),
+		"cpu_pressure_some_avg10":     1.5,
+		"cpu_pressure_some_avg60":     0.75,
+		"cpu_pressure_some_avg300":    0.25,
+		"cpu_pressure_full_avg10":     0.5,
+		"cpu_pressure_full_avg60":     0.25,
+		"cpu_pressure_full_avg300":    0.1,
+		"memory_pressure_some_avg10":  0.0,
+		"memory_pressure_some_avg60":  0.0,
+		"memory_pressure_some_avg300": 0.0,
+		"memory_pressure_full_avg10":  0.0,
+		"memory_pressure_full_avg60":  0.0,
+		"memory_pressure_full_avg300": 0.0,
+		"io_pressure_some_avg10":      4.0,
+		"io_pressure_some_avg60":      2.0,
+		"io_pressure_some_avg300":     1.0,
+		"io_pressure_full_avg10":      3.0,
+		"io_pressure_full_avg60":      1.5,
+		"io_pressure_full_avg300":     0.75,
+	}, map[string]string{serviceNameTag: "nginx.service"})
+	// no memory limit
+	acc.AssertContainsTaggedFields(t, "cgroups", map[string]interface{}{
+		"memory_current": uint64(8388608),
+		"pids_current":   uint64(1),
+	}, map[string]string{serviceNameTag: "sshd.service"})
+}
+
+func TestGather_Units(t *testing.T) {
+	testCases := map[string]struct {
+		units []string
+		want  []string
+	}{
+		"Slices": {
+			units: []string{"*.slice"},
+			want:  []string{"system.slice", "user-1000.slice"},
+		},
+		"All": {
+			units: []string{"*"},
+			want:  []string{"init.scope", "nginx.service", "session-1.scope", "sshd.service", "system.slice", "user-1000.slice"},
+		},
+		"Single": {
+			units: []string{"nginx.service"},
+			want:  []string{"nginx.service"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestCgroups(t, filepath.Join("testdata", "cgroup"), testCase.units, time.Now)
+			acc := &testutil.Accumulator{}
+			require.NoError(t, c.Gather(acc))
+			assert.Equal(t, testCase.want, serviceNames(acc))
+		})
+	}
+}
+
+func TestGather_Rates(t *testing.T) {
+	root := t.TempDir()
+	unit := filepath.Join(root, "system.slice", "app.service")
+	require.NoError(t, os.MkdirAll(unit, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu io memory pids\n"), 0600))
+	write := func(cpuStat, ioStat string) {
+		require.NoError(t, os.WriteFile(filepath.Join(unit, "cpu.stat"), []byte(cpuStat), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(unit, "io.stat"), []byte(ioStat), 0600))
+	}
+	now := time.Unix(1700000000, 0)
+	c := newTestCgroups(t, root, nil, func() time.Time { return now })
+
+	write("usage_usec 1000000\nthrottled_usec 0\n", "8:0 rbytes=0 wbytes=0 rios=0 wios=0\n")
+	acc := &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	assert.Empty(t, acc.Metrics, "rates need a previous sample")
+
+	now = now.Add(10 * time.Second)
+	write("usage_usec 6000000\nthrottled_usec 1000000\n", "8:0 rbytes=10240 wbytes=20480 rios=10 wios=20\n8:16 rbytes=10240 wbytes=0 rios=10 wios=0\n")
+	acc = &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	acc.AssertContainsTaggedFields(t, "cgroups", map[string]interface{}{
+		"cpu_usage_percent":      50.0,
+		"cpu_throttled_percent":  10.0,
+		"io_read_bytes_per_sec":  2048.0,
+		"io_write_bytes_per_sec": 2048.0,
+		"io_read_ops_per_sec":    2.0,
+		"io_write_ops_per_sec":   2.0,
+	}, map[string]string{serviceNameTag: "app.service"})
+
+	// the unit restarted, so the counters were reset
+	now = now.Add(10 * time.Second)
+	write("usage_usec 100\nthrottled_usec 0\n", "8:0 rbytes=0 wbytes=0 rios=0 wios=0\n")
+	acc = &testutil.Accumulator{}
+	require.NoError(t, c.Gather(acc))
+	assert.Empty(t, acc.Metrics)
+}
+
+func TestGather_NotCgroupV2(t *testing.T) {
+	c := newTestCgroups(t, t.TempDir(), nil, time.Now)
+	assert.ErrorContains(t, c.Gather(&testutil.Accumulator{}), "cgroup v2 unified hierarchy is not mounted")
+}
+
+func TestInit_InvalidUnits(t *testing.T) {
+	c := &Cgroups{Units: []string{"["}}
+	assert.Error(t, c.Init())
+}
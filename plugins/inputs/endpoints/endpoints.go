@@ -0,0 +1,328 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package endpoints
+
+import (
+	"context"
+	"crypto/tls"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/eventsrc"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement = "endpoints"
+	targetTag   = "Target"
+
+	protocolHTTP = "http"
+	protocolTCP  = "tcp"
+
+	defaultMethod      = http.MethodGet
+	defaultTimeout     = 10 * time.Second
+	defaultDestination = "cloudwatchlogs"
+	// maxBodySize limits the response body read for the body_regex match.
+	maxBodySize = 1 << 20
+)
+
+// Endpoints probes HTTP(S) URLs and TCP ports and reports whether they are reachable
+// and how long they take to respond.
+type Endpoints struct {
+	URLs                 []string          `toml:"urls"`
+	TCPAddresses         []string          `toml:"tcp_addresses"`
+	Method               string            `toml:"method"`
+	Headers              map[string]string `toml:"headers"`
+	ExpectedStatusCodes  []int             `toml:"expected_status_codes"`
+	BodyRegex            string            `toml:"body_regex"`
+	FollowRedirects      bool              `toml:"follow_redirects"`
+	InsecureSkipVerify   bool              `toml:"insecure_skip_verify"`
+	Timeout              config.Duration   `toml:"timeout"`
+	FailureLogGroupName  string            `toml:"failure_log_group_name"`
+	FailureLogStreamName string            `toml:"failure_log_stream_name"`
+	Destination          string            `toml:"destination"`
+	Log                  telegraf.Logger   `toml:"-"`
+
+	bodyRegex *regexp.Regexp
+	client    *http.Client
+	now       func() time.Time
+	// failureLog is the source of the failure events, nil if failure_log_group_name is not
+	// configured.
+	failureLog *eventsrc.Src
+	// logSrcFound is set once the failure log source is returned to the log agent.
+	logSrcFound bool
+}
+
+var (
+	_ telegraf.ServiceInput = (*Endpoints)(nil)
+	_ logs.LogCollection    = (*Endpoints)(nil)
+)
+
+// result is the outcome of a single probe.
+type result struct {
+	err              error
+	statusCode       int
+	latency          time.Duration
+	tlsHandshake     time.Duration
+	bodyMatch        *bool
+	unexpectedStatus bool
+	hasStatusCode    bool
+	hasTLSHandshake  bool
+}
+
+func (r result) success() bool {
+	return r.err == nil && !r.unexpectedStatus && (r.bodyMatch == nil || *r.bodyMatch)
+}
+
+func (e *Endpoints) Description() string {
+	return "Probe HTTP(S) URLs and TCP ports"
+}
+
+func (*Endpoints) SampleConfig() string {
+	return sampleConfig
+}
+
+func (e *Endpoints) Init() error {
+	if len(e.URLs) == 0 && len(e.TCPAddresses) == 0 {
+		return errors.New("no urls or tcp_addresses configured")
+	}
+	if e.BodyRegex != "" {
+		var err error
+		if e.bodyRegex, err = regexp.Compile(e.BodyRegex); err != nil {
+			return fmt.Errorf("invalid body_regex %q: %w", e.BodyRegex, err)
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// every probe opens a new connection so that the connect and TLS handshake are measured
+	transport.DisableKeepAlives = true
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: e.InsecureSkipVerify, // nolint:gosec
+	}
+	e.client = &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(e.Timeout),
+	}
+	if !e.FollowRedirects {
+		e.client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	if e.FailureLogGroupName != "" {
+		e.failureLog = newFailureLog(e.Destination, e.FailureLogGroupName, e.FailureLogStreamName)
+	}
+	return nil
+}
+
+// Start is a no-op. The input only implements telegraf.ServiceInput to be a
+// logs.LogCollection for the failure events.
+func (e *Endpoints) Start(telegraf.Accumulator) error {
+	return nil
+}
+
+// Stop closes the output of the failure events.
+func (e *Endpoints) Stop() {
+	if e.failureLog != nil {
+		e.failureLog.Stop()
+	}
+}
+
+// FindLogSrc returns the source of the failure events once if failure_log_group_name
+// is configured.
+func (e *Endpoints) FindLogSrc() []logs.LogSrc {
+	if e.failureLog == nil || e.logSrcFound {
+		return nil
+	}
+	e.logSrcFound = true
+	return []logs.LogSrc{e.failureLog}
+}
+
+func (e *Endpoints) Gather(acc telegraf.Accumulator) error {
+	var wg sync.WaitGroup
+	for _, url := range e.URLs {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			start := e.now()
+			e.addResult(acc, url, protocolHTTP, e.probeHTTP(url), start)
+		}(url)
+	}
+	for _, address := range e.TCPAddresses {
+		wg.Add(1)
+		go func(address string) {
+			defer wg.Done()
+			start := e.now()
+			e.addResult(acc, address, protocolTCP, e.probeTCP(address), start)
+		}(address)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (e *Endpoints) probeHTTP(url string) (r result) {
+	// the connection may be dialed in another goroutine that outlives a timed out request
+	var mu sync.Mutex
+	var tlsStart time.Time
+	var tlsHandshake time.Duration
+	trace := &httptrace.ClientTrace{
+		TLSHandshakeStart: func() {
+			mu.Lock()
+			defer mu.Unlock()
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			// only the handshake of the first request is measured if redirects are followed
+			if tlsHandshake == 0 && !tlsStart.IsZero() {
+				tlsHandshake = time.Since(tlsStart)
+			}
+		},
+	}
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if tlsHandshake > 0 {
+			r.tlsHandshake = tlsHandshake
+			r.hasTLSHandshake = true
+		}
+	}()
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(context.Background(), trace), e.Method, url, nil)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	for k, v := range e.Headers {
+		if strings.EqualFold(k, "Host") {
+			req.Host = v
+		} else {
+			req.Header.Set(k, v)
+		}
+	}
+	start := time.Now()
+	resp, err := e.client.Do(req)
+	if err != nil {
+		r.latency = time.Since(start)
+		r.err = err
+		return r
+	}
+	defer resp.Body.Close()
+	r.statusCode = resp.StatusCode
+	r.hasStatusCode = true
+	r.unexpectedStatus = !e.isExpectedStatus(resp.StatusCode)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	r.latency = time.Since(start)
+	if err != nil {
+		r.err = fmt.Errorf("unable to read the response body: %w", err)
+		return r
+	}
+	if e.bodyRegex != nil {
+		match := e.bodyRegex.Match(body)
+		r.bodyMatch = &match
+	}
+	return r
+}
+
+func (e *Endpoints) probeTCP(address string) result {
+	var r result
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", address, time.Duration(e.Timeout))
+	r.latency = time.Since(start)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	conn.Close()
+	return r
+}
+
+// isExpectedStatus returns true for the expected_status_codes, or any status below
+// 400 if none are configured.
+func (e *Endpoints) isExpectedStatus(code int) bool {
+	if len(e.ExpectedStatusCodes) == 0 {
+		return code < http.StatusBadRequest
+	}
+	for _, expected := range e.ExpectedStatusCodes {
+		if code == expected {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Endpoints) addResult(acc telegraf.Accumulator, target, protocol string, r result, t time.Time) {
+	fields := map[string]interface{}{
+		"success": boolToInt(r.success()),
+		"latency": float64(r.latency) / float64(time.Millisecond),
+	}
+	if r.hasStatusCode {
+		fields["status_code"] = r.statusCode
+	}
+	if r.hasTLSHandshake {
+		fields["tls_handshake_time"] = float64(r.tlsHandshake) / float64(time.Millisecond)
+	}
+	if r.bodyMatch != nil {
+		fields["body_match"] = boolToInt(*r.bodyMatch)
+	}
+	acc.AddGauge(measurement, fields, map[string]string{targetTag: target}, t)
+
+	if r.success() {
+		return
+	}
+	if r.err != nil {
+		e.Log.Debugf("Probe of %s failed: %v", target, r.err)
+	}
+	if e.failureLog == nil {
+		return
+	}
+	event := failureEvent{
+		Target:    target,
+		Protocol:  protocol,
+		BodyMatch: r.bodyMatch,
+		Latency:   fields["latency"].(float64),
+		Timestamp: t.UTC().Format(time.RFC3339Nano),
+	}
+	if r.err != nil {
+		event.Error = r.err.Error()
+	}
+	if r.hasStatusCode {
+		event.StatusCode = r.statusCode
+	}
+	if !e.publishFailure(event, t) {
+		e.Log.Debugf("Dropped the failure event of %s", target)
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func init() {
+	inputs.Add("endpoints", func() telegraf.Input {
+		return &Endpoints{
+			Method:      defaultMethod,
+			Timeout:     config.Duration(defaultTimeout),
+			Destination: defaultDestination,
+			now:         time.Now,
+		}
+	})
+}
@@ -0,0 +1,229 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package endpoints
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+func newTestEndpoints(t *testing.T, e *Endpoints) *Endpoints {
+	e.Method = defaultMethod
+	e.Destination = defaultDestination
+	e.Log = testutil.Logger{}
+	e.now = time.Now
+	if e.Timeout == 0 {
+		e.Timeout = config.Duration(5 * time.Second)
+	}
+	require.NoError(t, e.Init())
+	return e
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/health", http.StatusFound)
+	})
+	mux.HandleFunc("/host", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getFields(t *testing.T, acc *testutil.Accumulator, target string) map[string]interface{} {
+	t.Helper()
+	for _, m := range acc.GetTelegrafMetrics() {
+		if m.Tags()[targetTag] == target {
+			return m.Fields()
+		}
+	}
+	require.Failf(t, "missing metric", "no metric for %s", target)
+	return nil
+}
+
+func TestGather_HTTP(t *testing.T) {
+	server := newTestServer(t)
+	testCases := map[string]struct {
+		endpoints *Endpoints
+		path      string
+		want      map[string]interface{}
+	}{
+		"Success": {
+			endpoints: &Endpoints{},
+			path:      "/health",
+			want:      map[string]interface{}{"success": int64(1), "status_code": int64(200)},
+		},
+		"ErrorStatus": {
+			endpoints: &Endpoints{},
+			path:      "/error",
+			want:      map[string]interface{}{"success": int64(0), "status_code": int64(503)},
+		},
+		"ExpectedStatus": {
+			endpoints: &Endpoints{ExpectedStatusCodes: []int{503}},
+			path:      "/error",
+			want:      map[string]interface{}{"success": int64(1), "status_code": int64(503)},
+		},
+		"Redirect": {
+			endpoints: &Endpoints{},
+			path:      "/redirect",
+			want:      map[string]interface{}{"success": int64(1), "status_code": int64(302)},
+		},
+		"FollowRedirects": {
+			endpoints: &Endpoints{FollowRedirects: true, ExpectedStatusCodes: []int{200}},
+			path:      "/redirect",
+			want:      map[string]interface{}{"success": int64(1), "status_code": int64(200)},
+		},
+		"BodyMatch": {
+			endpoints: &Endpoints{BodyRegex: `"status":\s*"ok"`},
+			path:      "/health",
+			want:      map[string]interface{}{"success": int64(1), "status_code": int64(200), "body_match": int64(1)},
+		},
+		"BodyMismatch": {
+			endpoints: &Endpoints{BodyRegex: `"status":\s*"degraded"`},
+			path:      "/health",
+			want:      map[string]interface{}{"success": int64(0), "status_code": int64(200), "body_match": int64(0)},
+		},
+		"HostHeader": {
+			endpoints: &Endpoints{Headers: map[string]string{"Host": "example.com"}, BodyRegex: "^example.com$"},
+			path:      "/host",
+			want:      map[string]interface{}{"success": int64(1), "status_code": int64(200), "body_match": int64(1)},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			url := server.URL + testCase.path
+			testCase.endpoints.URLs = []string{url}
+			e := newTestEndpoints(t, testCase.endpoints)
+			acc := &testutil.Accumulator{}
+			require.NoError(t, e.Gather(acc))
+			fields := getFields(t, acc, url)
+			assert.Contains(t, fields, "latency")
+			delete(fields, "latency")
+			assert.Equal(t, testCase.want, fields)
+		})
+	}
+}
+
+func TestGather_HTTPS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	e := newTestEndpoints(t, &Endpoints{URLs: []string{server.URL}, InsecureSkipVerify: true})
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Gather(acc))
+	fields := getFields(t, acc, server.URL)
+	assert.EqualValues(t, 1, fields["success"])
+	assert.EqualValues(t, 204, fields["status_code"])
+	assert.Contains(t, fields, "tls_handshake_time")
+
+	// the certificate of the test server is self-signed
+	e = newTestEndpoints(t, &Endpoints{URLs: []string{server.URL}})
+	acc = &testutil.Accumulator{}
+	require.NoError(t, e.Gather(acc))
+	fields = getFields(t, acc, server.URL)
+	assert.EqualValues(t, 0, fields["success"])
+	assert.NotContains(t, fields, "status_code")
+}
+
+func TestGather_TCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	open := listener.Addr().String()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	defer listener.Close()
+
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closed := closedListener.Addr().String()
+	closedListener.Close()
+
+	e := newTestEndpoints(t, &Endpoints{TCPAddresses: []string{open, closed}})
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Gather(acc))
+	assert.EqualValues(t, 1, getFields(t, acc, open)["success"])
+	assert.EqualValues(t, 0, getFields(t, acc, closed)["success"])
+	assert.NotContains(t, getFields(t, acc, open), "status_code")
+}
+
+func TestGather_FailureLog(t *testing.T) {
+	server := newTestServer(t)
+	e := newTestEndpoints(t, &Endpoints{
+		URLs:                []string{server.URL + "/health", server.URL + "/error"},
+		FailureLogGroupName: t.Name(),
+	})
+
+	// the log agent finds the source on the same instance of the input
+	srcs := e.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Empty(t, e.FindLogSrc())
+	assert.Equal(t, t.Name(), srcs[0].Group())
+	assert.Equal(t, defaultDestination, srcs[0].Destination())
+	events := make(chan logs.LogEvent, 10)
+	srcs[0].SetOutput(func(event logs.LogEvent) {
+		events <- event
+	})
+	defer e.Stop()
+
+	require.NoError(t, e.Gather(&testutil.Accumulator{}))
+	select {
+	case event := <-events:
+		var got map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(event.Message()), &got))
+		assert.Equal(t, server.URL+"/error", got["target"])
+		assert.Equal(t, "http", got["protocol"])
+		assert.EqualValues(t, 503, got["status_code"])
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "no failure event")
+	}
+	select {
+	case event := <-events:
+		assert.Failf(t, "unexpected event", "%s", event.Message())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestGather_FailureLogWithoutOutput(t *testing.T) {
+	e := newTestEndpoints(t, &Endpoints{
+		TCPAddresses:        []string{"127.0.0.1:0"},
+		FailureLogGroupName: t.Name(),
+	})
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Gather(acc))
+	assert.EqualValues(t, 0, getFields(t, acc, "127.0.0.1:0")["success"])
+}
+
+func TestInit(t *testing.T) {
+	assert.Error(t, (&Endpoints{}).Init())
+	assert.Error(t, (&Endpoints{URLs: []string{"http://localhost"}, BodyRegex: "("}).Init())
+	assert.NoError(t, (&Endpoints{TCPAddresses: []string{"localhost:22"}}).Init())
+}
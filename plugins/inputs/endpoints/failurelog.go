@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package endpoints
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/eventsrc"
+)
+
+// failureBufferSize is the number of failure events buffered while they are published.
+// Events are dropped once the buffer is full so that a slow destination does not
+// delay the probes.
+const failureBufferSize = 100
+
+// failureEvent is the structured log event published for a failed probe.
+type failureEvent struct {
+	Target     string  `json:"target"`
+	Protocol   string  `json:"protocol"`
+	Error      string  `json:"error,omitempty"`
+	StatusCode int     `json:"status_code,omitempty"`
+	BodyMatch  *bool   `json:"body_match,omitempty"`
+	Latency    float64 `json:"latency_ms"`
+	Timestamp  string  `json:"timestamp"`
+}
+
+func newFailureLog(destination, group, stream string) *eventsrc.Src {
+	if destination == "" {
+		destination = defaultDestination
+	}
+	return eventsrc.New(eventsrc.Config{
+		Description: "endpoints failures",
+		Destination: destination,
+		Group:       group,
+		Stream:      stream,
+		Retention:   -1,
+	}, failureBufferSize)
+}
+
+// publishFailure queues the failure event without blocking. Returns false if the event was
+// dropped.
+func (e *Endpoints) publishFailure(event failureEvent, t time.Time) bool {
+	msg, err := json.Marshal(event)
+	if err != nil {
+		return false
+	}
+	return e.failureLog.Publish(string(msg), t)
+}
@@ -0,0 +1,190 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	defaultTimeout = 5 * time.Second
+	// maxStderrSize limits the stderr of a failed command that is added to the error.
+	maxStderrSize = 512
+)
+
+// Exec runs commands on every collection and parses the metrics they write to stdout
+// with the configured data_format.
+type Exec struct {
+	Commands  []string        `toml:"commands"`
+	Timeout   config.Duration `toml:"timeout"`
+	RunAsUser string          `toml:"run_as_user"`
+	Log       telegraf.Logger `toml:"-"`
+
+	parser telegraf.Parser
+	// commands are the parsed commands, with the program first.
+	commands [][]string
+}
+
+var _ telegraf.ParserInput = (*Exec)(nil)
+
+func (e *Exec) Description() string {
+	return "Run commands and parse the metrics they write to stdout"
+}
+
+func (*Exec) SampleConfig() string {
+	return sampleConfig
+}
+
+func (e *Exec) SetParser(parser telegraf.Parser) {
+	e.parser = parser
+}
+
+func (e *Exec) Init() error {
+	if len(e.Commands) == 0 {
+		return errors.New("no commands configured")
+	}
+	for _, command := range e.Commands {
+		args, err := splitCommand(command)
+		if err != nil {
+			return fmt.Errorf("invalid command %q: %w", command, err)
+		}
+		if len(args) == 0 {
+			return errors.New("empty command")
+		}
+		e.commands = append(e.commands, args)
+	}
+	return checkRunAsUser(e.RunAsUser)
+}
+
+func (e *Exec) Gather(acc telegraf.Accumulator) error {
+	var wg sync.WaitGroup
+	for _, args := range e.commands {
+		wg.Add(1)
+		go func(args []string) {
+			defer wg.Done()
+			if err := e.gatherCommand(acc, args); err != nil {
+				acc.AddError(fmt.Errorf("command %q: %w", strings.Join(args, " "), err))
+			}
+		}(args)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (e *Exec) gatherCommand(acc telegraf.Accumulator, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(e.Timeout))
+	defer cancel()
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	if err := setRunAsUser(cmd, e.RunAsUser); err != nil {
+		return err
+	}
+	out, err := e.run(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	metrics, err := e.parser.Parse(out)
+	if err != nil {
+		return fmt.Errorf("unable to parse the output: %w", err)
+	}
+	for _, m := range metrics {
+		acc.AddMetric(m)
+	}
+	return nil
+}
+
+func (e *Exec) run(ctx context.Context, cmd *exec.Cmd) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("timed out after %s", time.Duration(e.Timeout))
+	}
+	if err != nil {
+		if msg := truncate(strings.TrimSpace(stderr.String()), maxStderrSize); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// splitCommand splits the command into arguments on whitespace like a POSIX shell,
+// keeping the text in single or double quotes together and removing backslash escapes.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	var quote rune
+	inArg := false
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && quote != '\'':
+			if i+1 == len(runes) {
+				return nil, errors.New("unterminated escape")
+			}
+			// In double quotes the backslash only escapes the characters special to them.
+			if quote == '"' && !strings.ContainsRune("$`\"\\\n", runes[i+1]) {
+				arg.WriteRune(r)
+				continue
+			}
+			i++
+			arg.WriteRune(runes[i])
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+func truncate(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	return s[:size] + "..."
+}
+
+func init() {
+	inputs.Add("exec", func() telegraf.Input {
+		return &Exec{Timeout: config.Duration(defaultTimeout)}
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCommand(t *testing.T) {
+	testCases := map[string]struct {
+		command string
+		want    []string
+		wantErr bool
+	}{
+		"WithSpaces":       {command: "  /bin/echo  a\tb ", want: []string{"/bin/echo", "a", "b"}},
+		"WithSingleQuotes": {command: `echo 'a b' 'c\d'`, want: []string{"echo", "a b", `c\d`}},
+		"WithDoubleQuotes": {command: `echo "a 'b'" "c\"d" "e\f"`, want: []string{"echo", "a 'b'", `c"d`, `e\f`}},
+		"WithEscape":       {command: `echo a\ b`, want: []string{"echo", "a b"}},
+		"WithEmptyArg":     {command: `echo ""`, want: []string{"echo", ""}},
+		"WithEmpty":        {command: "  "},
+		"WithOpenQuote":    {command: `echo "a`, wantErr: true},
+		"WithOpenEscape":   {command: `echo a\`, wantErr: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := splitCommand(testCase.command)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestInit(t *testing.T) {
+	assert.Error(t, (&Exec{}).Init())
+	assert.Error(t, (&Exec{Commands: []string{" "}}).Init())
+	assert.Error(t, (&Exec{Commands: []string{`echo "a`}}).Init())
+
+	e := &Exec{Commands: []string{"echo a", "/bin/true"}}
+	assert.NoError(t, e.Init())
+	assert.Equal(t, [][]string{{"echo", "a"}, {"/bin/true"}}, e.commands)
+}
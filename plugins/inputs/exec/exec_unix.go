@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux || darwin
+
+package exec
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/user"
+)
+
+func checkRunAsUser(runAsUser string) error {
+	if runAsUser == "" {
+		return nil
+	}
+	if _, err := user.LookupExecUser(runAsUser); err != nil {
+		return fmt.Errorf("invalid run_as_user %q: %w", runAsUser, err)
+	}
+	return nil
+}
+
+// setRunAsUser runs the command in its own process group so that the children of the
+// command are killed with it on a timeout, and as the user if it is set. The user is
+// looked up every time since the IDs may change while the agent is running.
+func setRunAsUser(cmd *exec.Cmd, runAsUser string) error {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	if runAsUser == "" {
+		return nil
+	}
+	execUser, err := user.LookupExecUser(runAsUser)
+	if err != nil {
+		return fmt.Errorf("unable to look up run_as_user %q: %w", runAsUser, err)
+	}
+	groups := make([]uint32, len(execUser.Gids))
+	for i, gid := range execUser.Gids {
+		groups[i] = uint32(gid)
+	}
+	cmd.SysProcAttr.Credential = &syscall.Credential{
+		Uid:    uint32(execUser.Uid),
+		Gid:    uint32(execUser.Gid),
+		Groups: groups,
+	}
+	cmd.Env = append(os.Environ(), "HOME="+execUser.Home)
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux || darwin
+
+package exec
+
+import (
+	"os/user"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/parsers/influx"
+	"github.com/influxdata/telegraf/plugins/parsers/prometheus"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExec(t *testing.T, commands ...string) *Exec {
+	t.Helper()
+	e := &Exec{
+		Commands: commands,
+		Timeout:  config.Duration(defaultTimeout),
+		Log:      testutil.Logger{},
+	}
+	e.SetParser(influx.NewParser(influx.NewMetricHandler()))
+	require.NoError(t, e.Init())
+	return e
+}
+
+func TestGather(t *testing.T) {
+	e := newTestExec(t,
+		`sh -c "echo 'queue,name=orders depth=3i'"`,
+		`sh -c "echo 'queue,name=payments depth=5i'"`,
+	)
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	acc.AssertContainsTaggedFields(t, "queue", map[string]interface{}{"depth": int64(3)}, map[string]string{"name": "orders"})
+	acc.AssertContainsTaggedFields(t, "queue", map[string]interface{}{"depth": int64(5)}, map[string]string{"name": "payments"})
+}
+
+func TestGatherPrometheus(t *testing.T) {
+	e := newTestExec(t, `printf "# TYPE queue_depth gauge\nqueue_depth{name=\"orders\"} 3\n"`)
+	e.SetParser(&prometheus.Parser{})
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	acc.AssertContainsTaggedFields(t, "prometheus", map[string]interface{}{"queue_depth": float64(3)}, map[string]string{"name": "orders"})
+}
+
+func TestGatherErrors(t *testing.T) {
+	testCases := map[string]struct {
+		command string
+		timeout time.Duration
+		wantErr string
+	}{
+		"WithExitCode": {
+			command: `sh -c "echo failed to connect >&2; exit 2"`,
+			wantErr: "exit status 2: failed to connect",
+		},
+		"WithTimeout": {
+			command: `sh -c "sleep 10 & sleep 10"`,
+			timeout: 100 * time.Millisecond,
+			wantErr: "timed out after 100ms",
+		},
+		"WithInvalidOutput": {
+			command: "echo not line protocol",
+			wantErr: "unable to parse the output",
+		},
+		"WithMissingCommand": {
+			command: "/nonexistent/command",
+			wantErr: "no such file or directory",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			e := newTestExec(t, testCase.command)
+			if testCase.timeout != 0 {
+				e.Timeout = config.Duration(testCase.timeout)
+			}
+			acc := &testutil.Accumulator{}
+			start := time.Now()
+			require.NoError(t, e.Gather(acc))
+			assert.Less(t, time.Since(start), 5*time.Second)
+			assert.Empty(t, acc.Metrics)
+			require.Len(t, acc.Errors, 1)
+			assert.Contains(t, acc.Errors[0].Error(), testCase.wantErr)
+		})
+	}
+}
+
+func TestGatherRunAsUser(t *testing.T) {
+	current, err := user.Current()
+	require.NoError(t, err)
+	if current.Uid != "0" {
+		t.Skip("running as another user requires root")
+	}
+	e := newTestExec(t, `sh -c "echo whoami,user=$(id -un) uid=$(id -u)i"`)
+	e.RunAsUser = "nobody"
+	acc := &testutil.Accumulator{}
+	require.NoError(t, e.Gather(acc))
+	if len(acc.Errors) > 0 && strings.Contains(acc.Errors[0].Error(), "run_as_user") {
+		t.Skip("nobody user is not available")
+	}
+	assert.Empty(t, acc.Errors)
+	acc.AssertContainsTaggedFields(t, "whoami", map[string]interface{}{"uid": int64(65534)}, map[string]string{"user": "nobody"})
+}
+
+func TestInitRunAsUser(t *testing.T) {
+	e := &Exec{Commands: []string{"id"}, RunAsUser: "no_such_user_exists"}
+	assert.ErrorContains(t, e.Init(), "invalid run_as_user")
+}
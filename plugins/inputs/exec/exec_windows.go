@@ -0,0 +1,22 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package exec
+
+import (
+	"errors"
+	"os/exec"
+)
+
+func checkRunAsUser(runAsUser string) error {
+	if runAsUser != "" {
+		return errors.New("run_as_user is not supported on Windows")
+	}
+	return nil
+}
+
+func setRunAsUser(*exec.Cmd, string) error {
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package filesystem_paths
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/globpath"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement = "filesystem_paths"
+	pathTag     = "Path"
+)
+
+// FilesystemPaths reports the number, size and age of the files matching each of the
+// configured globs.
+type FilesystemPaths struct {
+	Paths   []string        `toml:"paths"`
+	Exclude []string        `toml:"exclude"`
+	Log     telegraf.Logger `toml:"-"`
+
+	paths   []*path
+	exclude []*exclude
+	now     func() time.Time
+}
+
+type path struct {
+	pattern string
+	glob    *globpath.GlobPath
+	// dirGlob matches the files under the path if the path is a directory.
+	dirGlob *globpath.GlobPath
+}
+
+type exclude struct {
+	glob *globpath.GlobPath
+	// baseName is set if the pattern is matched against the name of the files instead
+	// of their path.
+	baseName bool
+}
+
+type stats struct {
+	count  int64
+	bytes  int64
+	oldest time.Time
+	newest time.Time
+}
+
+func (f *FilesystemPaths) Description() string {
+	return "Report the number, size and age of the files matching globs"
+}
+
+func (*FilesystemPaths) SampleConfig() string {
+	return sampleConfig
+}
+
+func (f *FilesystemPaths) Init() error {
+	if len(f.Paths) == 0 {
+		return errors.New("no paths configured")
+	}
+	for _, pattern := range f.Paths {
+		g, err := globpath.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid path %q: %w", pattern, err)
+		}
+		p := &path{pattern: pattern, glob: g}
+		if !g.HasMeta() {
+			if p.dirGlob, err = globpath.Compile(filepath.Join(pattern, "**")); err != nil {
+				return fmt.Errorf("invalid path %q: %w", pattern, err)
+			}
+		}
+		f.paths = append(f.paths, p)
+	}
+	for _, pattern := range f.Exclude {
+		g, err := globpath.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+		f.exclude = append(f.exclude, &exclude{
+			glob:     g,
+			baseName: !strings.ContainsRune(pattern, os.PathSeparator),
+		})
+	}
+	return nil
+}
+
+func (f *FilesystemPaths) Gather(acc telegraf.Accumulator) error {
+	now := f.now()
+	for _, p := range f.paths {
+		s := f.gatherPath(p)
+		fields := map[string]interface{}{
+			"file_count":  s.count,
+			"total_bytes": s.bytes,
+		}
+		if s.count > 0 {
+			fields["oldest_file_age"] = now.Sub(s.oldest).Seconds()
+			fields["newest_file_mtime"] = s.newest.Unix()
+		}
+		acc.AddGauge(measurement, fields, map[string]string{pathTag: p.pattern}, now)
+	}
+	return nil
+}
+
+func (f *FilesystemPaths) gatherPath(p *path) stats {
+	var s stats
+	matches := p.glob.Match()
+	// a path without glob characters that is a directory reports all of the files in it
+	if p.dirGlob != nil {
+		if info, ok := matches[p.pattern]; ok && info.IsDir() {
+			matches = p.dirGlob.Match()
+		}
+	}
+	for name, info := range matches {
+		if f.isExcluded(name) {
+			continue
+		}
+		// the files are only followed by the glob if it is not recursive, so resolve
+		// the symlinks the same way for every glob
+		if info.Mode()&os.ModeSymlink != 0 {
+			var err error
+			if info, err = os.Stat(name); err != nil {
+				f.Log.Debugf("Skipping %s: %v", name, err)
+				continue
+			}
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		s.count++
+		s.bytes += info.Size()
+		if s.oldest.IsZero() || info.ModTime().Before(s.oldest) {
+			s.oldest = info.ModTime()
+		}
+		if info.ModTime().After(s.newest) {
+			s.newest = info.ModTime()
+		}
+	}
+	return s
+}
+
+func (f *FilesystemPaths) isExcluded(name string) bool {
+	for _, e := range f.exclude {
+		if e.baseName {
+			if e.glob.MatchString(filepath.Base(name)) {
+				return true
+			}
+		} else if e.glob.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	inputs.Add("filesystem_paths", func() telegraf.Input {
+		return &FilesystemPaths{now: time.Now}
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package filesystem_paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Unix(1700000000, 0)
+
+// writeFile creates the file with the size and modification time age seconds ago.
+func writeFile(t *testing.T, name string, size int, age time.Duration) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(name), 0755))
+	require.NoError(t, os.WriteFile(name, make([]byte, size), 0600))
+	mtime := now.Add(-age)
+	require.NoError(t, os.Chtimes(name, mtime, mtime))
+}
+
+func newTestFilesystemPaths(t *testing.T, paths, exclude []string) *FilesystemPaths {
+	f := &FilesystemPaths{Paths: paths, Exclude: exclude, Log: testutil.Logger{}, now: func() time.Time { return now }}
+	require.NoError(t, f.Init())
+	return f
+}
+
+func TestGather(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "spool", "a"), 100, time.Hour)
+	writeFile(t, filepath.Join(dir, "spool", "b"), 200, time.Minute)
+	writeFile(t, filepath.Join(dir, "spool", "nested", "c"), 300, 2*time.Hour)
+	writeFile(t, filepath.Join(dir, "log", "app.log"), 10, time.Second)
+	writeFile(t, filepath.Join(dir, "log", "app.log.1.gz"), 20, time.Hour)
+	writeFile(t, filepath.Join(dir, "log", "web", "web.log"), 30, time.Minute)
+	require.NoError(t, os.Symlink(filepath.Join(dir, "log", "app.log"), filepath.Join(dir, "log", "current.log")))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "log", "dangling.log")))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "spool"), filepath.Join(dir, "log", "spool.log")))
+
+	testCases := map[string]struct {
+		paths   []string
+		exclude []string
+		want    map[string]interface{}
+	}{
+		"Directory": {
+			paths: []string{filepath.Join(dir, "spool")},
+			want: map[string]interface{}{
+				"file_count":        int64(3),
+				"total_bytes":       int64(600),
+				"oldest_file_age":   float64(7200),
+				"newest_file_mtime": now.Add(-time.Minute).Unix(),
+			},
+		},
+		"File": {
+			paths: []string{filepath.Join(dir, "spool", "a")},
+			want: map[string]interface{}{
+				"file_count":        int64(1),
+				"total_bytes":       int64(100),
+				"oldest_file_age":   float64(3600),
+				"newest_file_mtime": now.Add(-time.Hour).Unix(),
+			},
+		},
+		"Glob": {
+			// the symlinks to the file and directory are followed the same way
+			paths: []string{filepath.Join(dir, "log", "*.log")},
+			want: map[string]interface{}{
+				"file_count":        int64(2),
+				"total_bytes":       int64(20),
+				"oldest_file_age":   float64(1),
+				"newest_file_mtime": now.Add(-time.Second).Unix(),
+			},
+		},
+		"RecursiveGlob": {
+			paths: []string{filepath.Join(dir, "log", "**.log")},
+			want: map[string]interface{}{
+				"file_count":        int64(3),
+				"total_bytes":       int64(50),
+				"oldest_file_age":   float64(60),
+				"newest_file_mtime": now.Add(-time.Second).Unix(),
+			},
+		},
+		"Exclude": {
+			paths:   []string{filepath.Join(dir, "log", "**")},
+			exclude: []string{"*.gz", filepath.Join(dir, "log", "web", "*")},
+			want: map[string]interface{}{
+				"file_count":        int64(2),
+				"total_bytes":       int64(20),
+				"oldest_file_age":   float64(1),
+				"newest_file_mtime": now.Add(-time.Second).Unix(),
+			},
+		},
+		"NoMatch": {
+			paths: []string{filepath.Join(dir, "missing", "*")},
+			want: map[string]interface{}{
+				"file_count":  int64(0),
+				"total_bytes": int64(0),
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			f := newTestFilesystemPaths(t, testCase.paths, testCase.exclude)
+			acc := &testutil.Accumulator{}
+			require.NoError(t, f.Gather(acc))
+			require.Len(t, acc.Metrics, 1)
+			assert.Equal(t, testCase.want, acc.Metrics[0].Fields)
+			assert.Equal(t, map[string]string{pathTag: testCase.paths[0]}, acc.Metrics[0].Tags)
+		})
+	}
+}
+
+func TestInit(t *testing.T) {
+	assert.Error(t, (&FilesystemPaths{}).Init())
+	assert.Error(t, (&FilesystemPaths{Paths: []string{"/var/log/**/["}}).Init())
+	assert.Error(t, (&FilesystemPaths{Paths: []string{"/var/log"}, Exclude: []string{"**/["}}).Init())
+}
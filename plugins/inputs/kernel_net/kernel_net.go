@@ -0,0 +1,341 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_net
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement     = "kernel_net"
+	defaultProcPath = "/proc"
+)
+
+// snmpCounters maps the "<section>:<key>" of /proc/net/snmp and /proc/net/netstat to
+// the reported counter fields.
+var snmpCounters = map[string]string{
+	"Tcp:ActiveOpens":             "tcp_active_opens",
+	"Tcp:PassiveOpens":            "tcp_passive_opens",
+	"Tcp:AttemptFails":            "tcp_attempt_fails",
+	"Tcp:EstabResets":             "tcp_estab_resets",
+	"Tcp:InSegs":                  "tcp_in_segs",
+	"Tcp:OutSegs":                 "tcp_out_segs",
+	"Tcp:RetransSegs":             "tcp_retrans_segs",
+	"Tcp:InErrs":                  "tcp_in_errs",
+	"Tcp:OutRsts":                 "tcp_out_rsts",
+	"Udp:InDatagrams":             "udp_in_datagrams",
+	"Udp:OutDatagrams":            "udp_out_datagrams",
+	"Udp:NoPorts":                 "udp_no_ports",
+	"Udp:InErrors":                "udp_in_errors",
+	"Udp:RcvbufErrors":            "udp_rcvbuf_errors",
+	"Udp:SndbufErrors":            "udp_sndbuf_errors",
+	"TcpExt:ListenOverflows":      "tcp_listen_overflows",
+	"TcpExt:ListenDrops":          "tcp_listen_drops",
+	"TcpExt:SyncookiesSent":       "tcp_syncookies_sent",
+	"TcpExt:TCPTimeouts":          "tcp_timeouts",
+	"TcpExt:TCPSynRetrans":        "tcp_syn_retrans",
+	"TcpExt:TCPFastRetrans":       "tcp_fast_retrans",
+	"TcpExt:TCPLostRetransmit":    "tcp_lost_retransmit",
+	"TcpExt:TCPBacklogDrop":       "tcp_backlog_drop",
+	"TcpExt:TCPAbortOnTimeout":    "tcp_abort_on_timeout",
+	"TcpExt:TCPAbortOnMemory":     "tcp_abort_on_memory",
+	"TcpExt:TCPRcvQDrop":          "tcp_rcvq_drop",
+	"TcpExt:TCPZeroWindowDrop":    "tcp_zero_window_drop",
+	"TcpExt:TCPReqQFullDrop":      "tcp_reqq_full_drop",
+	"TcpExt:TCPReqQFullDoCookies": "tcp_reqq_full_do_cookies",
+}
+
+// snmpGauges are the values of /proc/net/snmp that are not counters.
+var snmpGauges = map[string]string{
+	"Tcp:CurrEstab": "tcp_curr_estab",
+}
+
+// sockstatGauges maps the "<protocol>:<key>" of /proc/net/sockstat to the reported
+// fields.
+var sockstatGauges = map[string]string{
+	"sockets:used": "sockets_used",
+	"TCP:inuse":    "tcp_inuse",
+	"TCP:orphan":   "tcp_orphan",
+	"TCP:tw":       "tcp_tw",
+	"TCP:alloc":    "tcp_alloc",
+	"TCP:mem":      "tcp_mem_pages",
+	"UDP:inuse":    "udp_inuse",
+	"UDP:mem":      "udp_mem_pages",
+}
+
+// KernelNet collects the kernel network and file handle statistics that are not
+// reported by the net and netstat inputs. Counters are added as cumulative counters
+// so that they are converted to deltas by the pipeline, the rest as gauges.
+type KernelNet struct {
+	ProcPath string          `toml:"proc_path"`
+	Log      telegraf.Logger `toml:"-"`
+
+	now func() time.Time
+}
+
+func (k *KernelNet) Description() string {
+	return "Read conntrack, softnet, TCP/UDP and file handle statistics of the kernel"
+}
+
+func (*KernelNet) SampleConfig() string {
+	return sampleConfig
+}
+
+func (k *KernelNet) Gather(acc telegraf.Accumulator) error {
+	gauges := map[string]interface{}{}
+	counters := map[string]interface{}{}
+	for _, gather := range []func(gauges, counters map[string]interface{}) error{
+		k.gatherConntrack,
+		k.gatherSoftnet,
+		k.gatherSNMP,
+		k.gatherSockstat,
+		k.gatherFileNr,
+	} {
+		if err := gather(gauges, counters); err != nil {
+			acc.AddError(err)
+		}
+	}
+	now := k.now()
+	if len(gauges) > 0 {
+		acc.AddGauge(measurement, gauges, nil, now)
+	}
+	if len(counters) > 0 {
+		acc.AddCounter(measurement, counters, nil, now)
+	}
+	return nil
+}
+
+func (k *KernelNet) path(elem ...string) string {
+	return filepath.Join(append([]string{k.ProcPath}, elem...)...)
+}
+
+// gatherConntrack reads the number of tracked connections and the limit. Nothing is
+// reported if the nf_conntrack module is not loaded.
+func (k *KernelNet) gatherConntrack(gauges, _ map[string]interface{}) error {
+	count, err := readUint(k.path("sys", "net", "netfilter", "nf_conntrack_count"))
+	if errors.Is(err, fs.ErrNotExist) {
+		// older kernels only have the table
+		count, err = countLines(k.path("net", "nf_conntrack"))
+	}
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+	gauges["conntrack_count"] = count
+	limit, err := readUint(k.path("sys", "net", "netfilter", "nf_conntrack_max"))
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+	gauges["conntrack_max"] = limit
+	if limit > 0 {
+		gauges["conntrack_used_percent"] = float64(count) / float64(limit) * 100
+	}
+	return nil
+}
+
+// gatherSoftnet sums the per CPU softnet_stat. The first three columns are the packets
+// processed, dropped since the backlog was full and the times the budget ran out.
+func (k *KernelNet) gatherSoftnet(_, counters map[string]interface{}) error {
+	path := k.path("net", "softnet_stat")
+	f, err := os.Open(path)
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+	defer f.Close()
+	var processed, dropped, timeSqueeze uint64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		columns := strings.Fields(scanner.Text())
+		if len(columns) < 3 {
+			continue
+		}
+		values := make([]uint64, 3)
+		for i := range values {
+			if values[i], err = strconv.ParseUint(columns[i], 16, 64); err != nil {
+				return fmt.Errorf("unable to parse %s: %w", path, err)
+			}
+		}
+		processed += values[0]
+		dropped += values[1]
+		timeSqueeze += values[2]
+	}
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+	counters["softnet_processed"] = processed
+	counters["softnet_dropped"] = dropped
+	counters["softnet_time_squeeze"] = timeSqueeze
+	return nil
+}
+
+// gatherSNMP reads the TCP and UDP statistics of /proc/net/snmp and the extended TCP
+// statistics of /proc/net/netstat.
+func (k *KernelNet) gatherSNMP(gauges, counters map[string]interface{}) error {
+	var errs []error
+	for _, name := range []string{"snmp", "netstat"} {
+		values, err := readSNMP(k.path("net", name))
+		if err != nil {
+			errs = append(errs, ignoreNotExist(err))
+			continue
+		}
+		for key, value := range values {
+			if field, ok := snmpCounters[key]; ok {
+				counters[field] = value
+			} else if field, ok := snmpGauges[key]; ok {
+				gauges[field] = value
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (k *KernelNet) gatherSockstat(gauges, _ map[string]interface{}) error {
+	path := k.path("net", "sockstat")
+	f, err := os.Open(path)
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// e.g. TCP: inuse 4 orphan 0 tw 0 alloc 4 mem 0
+		protocol, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		pairs := strings.Fields(rest)
+		for i := 0; i+1 < len(pairs); i += 2 {
+			field, ok := sockstatGauges[protocol+":"+pairs[i]]
+			if !ok {
+				continue
+			}
+			value, err := strconv.ParseUint(pairs[i+1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("unable to parse %s: %w", path, err)
+			}
+			gauges[field] = value
+		}
+	}
+	return scanner.Err()
+}
+
+// gatherFileNr reads the allocated file handles and the limit of the system. The
+// unused handles have always been 0 since Linux 2.6.
+func (k *KernelNet) gatherFileNr(gauges, _ map[string]interface{}) error {
+	path := k.path("sys", "fs", "file-nr")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+	columns := strings.Fields(string(content))
+	if len(columns) != 3 {
+		return fmt.Errorf("unable to parse %s: expected 3 values, got %d", path, len(columns))
+	}
+	allocated, err := strconv.ParseUint(columns[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	limit, err := strconv.ParseUint(columns[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	gauges["file_nr_allocated"] = allocated
+	gauges["file_nr_max"] = limit
+	if limit > 0 {
+		gauges["file_nr_used_percent"] = float64(allocated) / float64(limit) * 100
+	}
+	return nil
+}
+
+// readSNMP reads a file with pairs of header and value lines, e.g.
+//
+//	Tcp: RtoAlgorithm RtoMin RtoMax MaxConn ActiveOpens ...
+//	Tcp: 1 200 120000 -1 557 ...
+//
+// and returns the values keyed by "<section>:<header>". Negative values, e.g.
+// MaxConn, are skipped.
+func readSNMP(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	values := map[string]uint64{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		headers := strings.Fields(scanner.Text())
+		if !scanner.Scan() {
+			break
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(headers) == 0 || len(headers) != len(fields) || headers[0] != fields[0] {
+			return nil, fmt.Errorf("unable to parse %s: mismatched lines for %v", path, headers)
+		}
+		section := strings.TrimSuffix(headers[0], ":")
+		for i := 1; i < len(headers); i++ {
+			if v, err := strconv.ParseUint(fields[i], 10, 64); err == nil {
+				values[section+":"+headers[i]] = v
+			}
+		}
+	}
+	return values, scanner.Err()
+}
+
+func readUint(path string) (uint64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	return v, nil
+}
+
+func countLines(path string) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	var count uint64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		count++
+	}
+	return count, scanner.Err()
+}
+
+// ignoreNotExist drops the errors of the files that do not exist since they depend on
+// the kernel version and loaded modules.
+func ignoreNotExist(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func init() {
+	inputs.Add("kernel_net", func() telegraf.Input {
+		return &KernelNet{
+			ProcPath: defaultProcPath,
+			now:      time.Now,
+		}
+	})
+}
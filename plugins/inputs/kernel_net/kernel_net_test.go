@@ -0,0 +1,125 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_net
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKernelNet(path string) *KernelNet {
+	return &KernelNet{ProcPath: path, Log: testutil.Logger{}, now: time.Now}
+}
+
+func getMetric(t *testing.T, acc *testutil.Accumulator, valueType telegraf.ValueType) map[string]interface{} {
+	t.Helper()
+	for _, m := range acc.Metrics {
+		if m.Type == valueType {
+			return m.Fields
+		}
+	}
+	require.Failf(t, "missing metric", "no metric of type %v", valueType)
+	return nil
+}
+
+func TestGather(t *testing.T) {
+	k := newTestKernelNet(filepath.Join("testdata", "proc"))
+	acc := &testutil.Accumulator{}
+	require.NoError(t, k.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	require.Len(t, acc.Metrics, 2)
+
+	assert.Equal(t, map[string]interface{}{
+		"conntrack_count":        uint64(2048),
+		"conntrack_max":          uint64(262144),
+		"conntrack_used_percent": 0.78125,
+		"tcp_curr_estab":         uint64(2),
+		"sockets_used":           uint64(18),
+		"tcp_inuse":              uint64(4),
+		"tcp_orphan":             uint64(1),
+		"tcp_tw":                 uint64(6),
+		"tcp_alloc":              uint64(5),
+		"tcp_mem_pages":          uint64(2),
+		"udp_inuse":              uint64(3),
+		"udp_mem_pages":          uint64(1),
+		"file_nr_allocated":      uint64(3200),
+		"file_nr_max":            uint64(64000),
+		"file_nr_used_percent":   5.0,
+	}, getMetric(t, acc, telegraf.Gauge))
+
+	assert.Equal(t, map[string]interface{}{
+		"softnet_processed":    uint64(0x8307 + 0x1000),
+		"softnet_dropped":      uint64(5),
+		"softnet_time_squeeze": uint64(17),
+		"tcp_active_opens":     uint64(557),
+		"tcp_passive_opens":    uint64(336),
+		"tcp_attempt_fails":    uint64(10),
+		"tcp_estab_resets":     uint64(235),
+		"tcp_in_segs":          uint64(33477),
+		"tcp_out_segs":         uint64(36303),
+		"tcp_retrans_segs":     uint64(96),
+		"tcp_in_errs":          uint64(1),
+		"tcp_out_rsts":         uint64(33),
+		"udp_in_datagrams":     uint64(12),
+		"udp_out_datagrams":    uint64(14),
+		"udp_no_ports":         uint64(3),
+		"udp_in_errors":        uint64(2),
+		"udp_rcvbuf_errors":    uint64(1),
+		"udp_sndbuf_errors":    uint64(0),
+		"tcp_listen_overflows": uint64(7),
+		"tcp_listen_drops":     uint64(9),
+		"tcp_syncookies_sent":  uint64(5),
+		"tcp_timeouts":         uint64(20),
+		"tcp_syn_retrans":      uint64(4),
+		"tcp_fast_retrans":     uint64(11),
+		"tcp_lost_retransmit":  uint64(2),
+		"tcp_backlog_drop":     uint64(1),
+		"tcp_abort_on_timeout": uint64(3),
+	}, getMetric(t, acc, telegraf.Counter))
+}
+
+func TestGather_ConntrackTable(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "net"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sys", "net", "netfilter"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "net", "nf_conntrack"), []byte(
+		"ipv4     2 tcp      6 431999 ESTABLISHED src=10.0.0.1 dst=10.0.0.2 sport=22 dport=5000 [ASSURED] mark=0 zone=0 use=2\n"+
+			"ipv4     2 udp      17 29 src=10.0.0.1 dst=10.0.0.3 sport=5353 dport=53 mark=0 zone=0 use=2\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sys", "net", "netfilter", "nf_conntrack_max"), []byte("100\n"), 0600))
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, newTestKernelNet(dir).Gather(acc))
+	assert.Empty(t, acc.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"conntrack_count":        uint64(2),
+		"conntrack_max":          uint64(100),
+		"conntrack_used_percent": 2.0,
+	}, getMetric(t, acc, telegraf.Gauge))
+}
+
+func TestGather_Missing(t *testing.T) {
+	acc := &testutil.Accumulator{}
+	require.NoError(t, newTestKernelNet(t.TempDir()).Gather(acc))
+	assert.Empty(t, acc.Errors)
+	assert.Empty(t, acc.Metrics)
+}
+
+func TestGather_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sys", "fs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sys", "fs", "file-nr"), []byte("3200 0\n"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "net"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "net", "snmp"), []byte("Tcp: ActiveOpens PassiveOpens\nTcp: 1\n"), 0600))
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, newTestKernelNet(dir).Gather(acc))
+	assert.Len(t, acc.Errors, 2)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
 	"time"
@@ -168,6 +169,29 @@ func (config *FileConfig) init() error {
 	return nil
 }
 
+// equal reports whether the configured fields of two initialized file configs are equal.
+func (config *FileConfig) equal(other *FileConfig) bool {
+	v, o := reflect.ValueOf(config).Elem(), reflect.ValueOf(other).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.Tag.Get("toml") == "" || field.Name == "Filters" {
+			continue
+		}
+		if !reflect.DeepEqual(v.Field(i).Interface(), o.Field(i).Interface()) {
+			return false
+		}
+	}
+	if len(config.Filters) != len(other.Filters) {
+		return false
+	}
+	for i, filter := range config.Filters {
+		if filter.Type != other.Filters[i].Type || filter.Expression != other.Filters[i].Expression {
+			return false
+		}
+	}
+	return true
+}
+
 // Try to parse the timestampFromLogLine value from the log entry line.
 // The parser logic will be based on the timestampFromLogLine regex, and time zone info.
 // If the parsing operation encounters any issue, int64(0) is returned.
@@ -103,6 +103,22 @@ func TestInfrequent_accessAndEmptyLogGroupClassInit(t *testing.T) {
 	assert.Equal(t, "", fileConfig.LogGroupClass)
 }
 
+func TestFileConfigEqual(t *testing.T) {
+	newFileConfig := func(filters ...*LogFilter) *FileConfig {
+		fileConfig := &FileConfig{FilePath: "/tmp/test.log", TimestampRegex: "^(\\d+)", Filters: filters}
+		require.NoError(t, fileConfig.init())
+		return fileConfig
+	}
+	fileConfig := newFileConfig(&LogFilter{Type: includeFilterType, Expression: "foo"})
+	assert.True(t, fileConfig.equal(newFileConfig(&LogFilter{Type: includeFilterType, Expression: "foo"})))
+	assert.False(t, fileConfig.equal(newFileConfig(&LogFilter{Type: includeFilterType, Expression: "bar"})))
+	assert.False(t, fileConfig.equal(newFileConfig()))
+
+	other := newFileConfig(&LogFilter{Type: includeFilterType, Expression: "foo"})
+	other.LogStreamName = "stream"
+	assert.False(t, fileConfig.equal(other))
+}
+
 func TestLogGroupName(t *testing.T) {
 	filepath := "/tmp/logfile.log.2017-06-19-13"
 	expectLogGroup := "/tmp/logfile.log"
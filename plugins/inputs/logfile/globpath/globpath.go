@@ -77,6 +77,20 @@ func (g *GlobPath) Match() map[string]os.FileInfo {
 	return walkFilePath(g.root, g.g)
 }
 
+// MatchString reports whether the path matches the glob without reading the file
+// system.
+func (g *GlobPath) MatchString(path string) bool {
+	if !g.hasMeta && !g.hasSuperMeta {
+		return path == g.path
+	}
+	return g.g.Match(path)
+}
+
+// HasMeta reports whether the path of the glob contains any glob characters.
+func (g *GlobPath) HasMeta() bool {
+	return g.hasMeta || g.hasSuperMeta
+}
+
 // walk the filepath from the given root and return a list of files that match
 // the given glob.
 func walkFilePath(root string, g glob.Glob) map[string]os.FileInfo {
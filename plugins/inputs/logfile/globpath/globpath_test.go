@@ -191,6 +191,32 @@ func TestCompileAndMatch(t *testing.T) {
 	assert.Len(t, matches, 0)
 }
 
+func TestMatchString(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/var/log/messages", "/var/log/messages", true},
+		{"/var/log/messages", "/var/log/messages.1", false},
+		{"/var/log/*.log", "/var/log/app.log", true},
+		{"/var/log/*.log", "/var/log/app/app.log", false},
+		{"/var/log/**.log", "/var/log/app/app.log", true},
+		{"/var/log/{app,web}.log", "/var/log/web.log", true},
+		{"*.gz", "app.log.gz", true},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern+" "+test.path, func(t *testing.T) {
+			if runtime.GOOS == "windows" {
+				t.Skip("unix paths")
+			}
+			g, err := Compile(test.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, g.MatchString(test.path))
+		})
+	}
+}
+
 func TestFindRootDir(t *testing.T) {
 	tests := []struct {
 		input  string
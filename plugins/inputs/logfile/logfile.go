@@ -4,6 +4,7 @@
 package logfile
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -142,6 +143,53 @@ func (t *LogFile) Stop() {
 	close(t.done)
 }
 
+// Reload takes over the file configs of the given LogFile. The tailers of file configs that
+// did not change keep running. The tailers of the other file configs are stopped, and the
+// files are picked up again by FindLogSrc from their saved offset.
+func (t *LogFile) Reload(collection logs.LogCollection) error {
+	n, ok := collection.(*LogFile)
+	if !ok {
+		return fmt.Errorf("cannot reload from %T", collection)
+	}
+	if n.FileStateFolder != t.FileStateFolder || n.Destination != t.Destination {
+		return errors.New("file_state_folder and destination cannot be reloaded")
+	}
+	if !t.started {
+		t.FileConfig = n.FileConfig
+		return nil
+	}
+
+	fileConfigs := make([]FileConfig, len(n.FileConfig))
+	copy(fileConfigs, n.FileConfig)
+	for i := range fileConfigs {
+		if err := fileConfigs[i].init(); err != nil {
+			return fmt.Errorf("invalid file config init %v with err %v", fileConfigs[i], err)
+		}
+	}
+
+	t.cleanUpStoppedTailerSrc()
+	configs := make(map[*FileConfig]map[string]*tailerSrc)
+	for i := range fileConfigs {
+		for fileconfig, dests := range t.configs {
+			if fileconfig.equal(&fileConfigs[i]) {
+				configs[&fileConfigs[i]] = dests
+				delete(t.configs, fileconfig)
+				break
+			}
+		}
+	}
+	for fileconfig, dests := range t.configs {
+		t.Log.Infof("Stopping %d tailer(s) of file config %v", len(dests), fileconfig.FilePath)
+		for _, src := range dests {
+			// The log agent stops the tailer src once its remaining events are published.
+			go src.tailer.Stop()
+		}
+	}
+	t.FileConfig = fileConfigs
+	t.configs = configs
+	return nil
+}
+
 // Try to find if there is any new file needs to be added for monitoring.
 func (t *LogFile) FindLogSrc() []logs.LogSrc {
 	if !t.started {
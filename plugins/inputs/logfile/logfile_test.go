@@ -1111,6 +1111,75 @@ func TestLogFileMultiLogsReadingWithBlacklist(t *testing.T) {
 	tt.Stop()
 }
 
+func TestLogFileReload(t *testing.T) {
+	multilineWaitPeriod = 10 * time.Millisecond
+	dir := t.TempDir()
+	keptFile := filepath.Join(dir, "kept.log")
+	changedFile := filepath.Join(dir, "changed.log")
+	for _, name := range []string{keptFile, changedFile} {
+		require.NoError(t, os.WriteFile(name, []byte("line\n"), 0600))
+	}
+
+	tt := NewLogFile()
+	tt.Log = TestLogger{t}
+	tt.FileStateFolder = filepath.Join(dir, "state")
+	tt.FileConfig = []FileConfig{
+		{FilePath: keptFile, FromBeginning: true, LogGroupName: "kept"},
+		{FilePath: changedFile, FromBeginning: true, LogGroupName: "before"},
+	}
+	require.NoError(t, tt.Start(nil))
+	defer tt.Stop()
+
+	stopped := make(map[string]chan struct{})
+	for _, src := range tt.FindLogSrc() {
+		done := make(chan struct{})
+		stopped[src.Group()] = done
+		src.SetOutput(func(e logs.LogEvent) {
+			if e == nil {
+				close(done)
+				return
+			}
+			e.Done()
+		})
+		defer src.Stop()
+	}
+	require.Len(t, stopped, 2)
+
+	next := NewLogFile()
+	next.FileStateFolder = tt.FileStateFolder
+	next.FileConfig = []FileConfig{
+		{FilePath: keptFile, FromBeginning: true, LogGroupName: "kept"},
+		{FilePath: changedFile, FromBeginning: true, LogGroupName: "after"},
+	}
+	require.NoError(t, tt.Reload(next))
+
+	select {
+	case <-stopped["before"]:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tailer src of the changed file config should have stopped")
+	}
+	select {
+	case <-stopped["kept"]:
+		t.Fatal("tailer src of the unchanged file config should keep running")
+	default:
+	}
+
+	srcs := tt.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Equal(t, "after", srcs[0].Group())
+	srcs[0].Stop()
+
+	invalid := NewLogFile()
+	invalid.FileStateFolder = tt.FileStateFolder
+	invalid.FileConfig = []FileConfig{{FilePath: keptFile, TimestampRegex: "("}}
+	assert.Error(t, tt.Reload(invalid))
+	assert.Len(t, tt.FileConfig, 2)
+
+	moved := NewLogFile()
+	moved.FileStateFolder = filepath.Join(dir, "other")
+	assert.Error(t, tt.Reload(moved))
+}
+
 func TestGenerateLogGroupName(t *testing.T) {
 	multilineWaitPeriod = 10 * time.Millisecond
 	fileName := "C:\\tmp\\soak Test\\tmp0.log"
@@ -5,10 +5,12 @@ package logfile
 
 import (
 	"bytes"
+	"io"
 	"log"
 	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/text/encoding"
@@ -17,6 +19,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/tail"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/service/admin"
 )
 
 const (
@@ -77,6 +80,7 @@ type tailerSrc struct {
 	isMLStart       func(string) bool
 	filters         []*LogFilter
 	offsetCh        chan fileOffset
+	publishedOffset atomic.Int64
 	done            chan struct{}
 	startTailerOnce sync.Once
 	cleanUpFns      []func()
@@ -84,6 +88,7 @@ type tailerSrc struct {
 
 // Verify tailerSrc implements LogSrc
 var _ logs.LogSrc = (*tailerSrc)(nil)
+var _ admin.Component = (*tailerSrc)(nil)
 
 func NewTailerSrc(
 	group, stream, destination, stateFilePath, logClass, fileGlobPath string,
@@ -117,6 +122,10 @@ func NewTailerSrc(
 		offsetCh: make(chan fileOffset, 2000),
 		done:     make(chan struct{}),
 	}
+	// The file is published up to the restored offset it is tailed from.
+	if location := tailer.Location; location != nil && location.Whence == io.SeekStart {
+		ts.publishedOffset.Store(location.Offset)
+	}
 	go ts.runSaveState()
 	return ts
 }
@@ -170,6 +179,18 @@ func (ts *tailerSrc) AddCleanUpFn(f func()) {
 	ts.cleanUpFns = append(ts.cleanUpFns, f)
 }
 
+// Status reports the tailed file with the offset up to which it was published.
+func (ts *tailerSrc) Status() admin.Status {
+	return admin.Status{
+		Files: []admin.File{{
+			Path:      ts.tailer.Filename,
+			LogGroup:  ts.group,
+			LogStream: ts.stream,
+			Offset:    ts.publishedOffset.Load(),
+		}},
+	}
+}
+
 func (ts *tailerSrc) Entity() *cloudwatchlogs.Entity {
 	es := entitystore.GetEntityStore()
 	if es != nil {
@@ -313,6 +334,8 @@ func (ts *tailerSrc) cleanUp() {
 func (ts *tailerSrc) runSaveState() {
 	t := time.NewTicker(100 * time.Millisecond)
 	defer t.Stop()
+	unregister := admin.Register("logfile:"+ts.tailer.Filename, ts)
+	defer unregister()
 
 	var offset, lastSavedOffset fileOffset
 	for {
@@ -320,6 +343,7 @@ func (ts *tailerSrc) runSaveState() {
 		case o := <-ts.offsetCh:
 			if o.seq > offset.seq || (o.seq == offset.seq && o.offset > offset.offset) {
 				offset = o
+				ts.publishedOffset.Store(offset.offset)
 			}
 		case <-t.C:
 			if offset == lastSavedOffset {
@@ -0,0 +1,288 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nfs
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement = "nfs"
+
+	pathTag      = "path"
+	deviceTag    = "device"
+	fstypeTag    = "fstype"
+	operationTag = "operation"
+
+	defaultMountstatsPath = "/proc/self/mountstats"
+)
+
+var defaultOperations = []string{"READ", "WRITE", "GETATTR", "LOOKUP", "ACCESS", "COMMIT"}
+
+// NFS reports the client statistics of the NFS mounts read from mountstats. The
+// statistics are cumulative in the kernel, so every collection reports the change
+// since the previous one and nothing is reported for a mount until it has been
+// collected twice.
+type NFS struct {
+	MountstatsPath string          `toml:"mountstats_path"`
+	MountPoints    []string        `toml:"mount_points"`
+	IgnoreFS       []string        `toml:"ignore_fs"`
+	Operations     []string        `toml:"operations"`
+	Log            telegraf.Logger `toml:"-"`
+
+	// previous holds the statistics of the last collection keyed by mount point.
+	previous map[string]*mount
+	now      func() time.Time
+}
+
+type mount struct {
+	device string
+	path   string
+	fstype string
+	// readBytes and writeBytes are the bytes read from and written to the server.
+	readBytes  uint64
+	writeBytes uint64
+	ops        map[string]opStats
+}
+
+// opStats are the columns of a line of the per-op statistics.
+type opStats struct {
+	ops          uint64
+	transmits    uint64
+	majorTimeout uint64
+	rtt          uint64
+	execute      uint64
+}
+
+func (n *NFS) Description() string {
+	return "Read the client statistics of the NFS mounts from mountstats"
+}
+
+func (*NFS) SampleConfig() string {
+	return sampleConfig
+}
+
+func (n *NFS) Gather(acc telegraf.Accumulator) error {
+	f, err := os.Open(n.MountstatsPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	mounts, err := parseMountstats(f)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %w", n.MountstatsPath, err)
+	}
+
+	now := n.now()
+	current := make(map[string]*mount, len(mounts))
+	for _, m := range mounts {
+		if !n.isIncluded(m) {
+			continue
+		}
+		current[m.path] = m
+		prev, ok := n.previous[m.path]
+		// the statistics are reset if the mount point is mounted again
+		if !ok || prev.device != m.device || m.readBytes < prev.readBytes || m.writeBytes < prev.writeBytes {
+			continue
+		}
+		tags := map[string]string{
+			pathTag:   m.path,
+			deviceTag: m.device,
+			fstypeTag: m.fstype,
+		}
+		acc.AddGauge(measurement, map[string]interface{}{
+			"read_bytes":  m.readBytes - prev.readBytes,
+			"write_bytes": m.writeBytes - prev.writeBytes,
+		}, tags, now)
+		for _, op := range n.operations() {
+			cur, ok := m.ops[op]
+			if !ok {
+				continue
+			}
+			fields := cur.delta(prev.ops[op])
+			if fields == nil {
+				continue
+			}
+			opTags := map[string]string{operationTag: op}
+			for k, v := range tags {
+				opTags[k] = v
+			}
+			acc.AddGauge(measurement, fields, opTags, now)
+		}
+	}
+	n.previous = current
+	return nil
+}
+
+func (n *NFS) operations() []string {
+	if len(n.Operations) == 0 {
+		return defaultOperations
+	}
+	return n.Operations
+}
+
+// isIncluded matches the mount against the mount_points and ignore_fs the same way as
+// the disk input.
+func (n *NFS) isIncluded(m *mount) bool {
+	if len(n.MountPoints) > 0 && !contains(n.MountPoints, m.path) {
+		return false
+	}
+	return !contains(n.IgnoreFS, m.fstype)
+}
+
+// delta returns the fields of the operation since the previous collection. The round
+// trip and execute times are the average per operation in milliseconds. Returns nil if
+// the counters were reset.
+func (s opStats) delta(prev opStats) map[string]interface{} {
+	if s.ops < prev.ops || s.transmits < prev.transmits || s.majorTimeout < prev.majorTimeout ||
+		s.rtt < prev.rtt || s.execute < prev.execute {
+		return nil
+	}
+	ops := s.ops - prev.ops
+	transmits := s.transmits - prev.transmits
+	fields := map[string]interface{}{
+		"ops":      ops,
+		"retrans":  uint64(0),
+		"timeouts": s.majorTimeout - prev.majorTimeout,
+	}
+	if transmits > ops {
+		fields["retrans"] = transmits - ops
+	}
+	if ops > 0 {
+		fields["rtt"] = float64(s.rtt-prev.rtt) / float64(ops)
+		fields["exec_time"] = float64(s.execute-prev.execute) / float64(ops)
+	}
+	return fields
+}
+
+// parseMountstats returns the NFS mounts of a mountstats file, e.g.
+//
+//	device 1.2.3.4:/export mounted on /mnt/data with fstype nfs4 statvers=1.1
+//	    bytes:  2048 1024 0 0 4096 8192 2 3
+//	    per-op statistics
+//	            READ: 10 10 0 1280 40960 5 50 60
+func parseMountstats(r io.Reader) ([]*mount, error) {
+	var mounts []*mount
+	var cur *mount
+	perOp := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			perOp = false
+			continue
+		}
+		if fields[0] == "device" {
+			cur, perOp = nil, false
+			// device <device> mounted on <path> with fstype <fstype> [statvers=<version>]
+			if len(fields) < 8 || fields[2] != "mounted" || fields[3] != "on" || fields[5] != "with" || fields[6] != "fstype" {
+				continue
+			}
+			if fields[7] != "nfs" && fields[7] != "nfs4" {
+				continue
+			}
+			cur = &mount{
+				device: unescape(fields[1]),
+				path:   unescape(fields[4]),
+				fstype: fields[7],
+				ops:    map[string]opStats{},
+			}
+			mounts = append(mounts, cur)
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		switch {
+		case fields[0] == "bytes:":
+			// normal read/write, direct read/write, server read/write, pages read/written
+			values, err := parseUints(fields[1:])
+			if err != nil || len(values) < 6 {
+				return nil, fmt.Errorf("invalid bytes of %s: %q", cur.path, line)
+			}
+			cur.readBytes = values[4]
+			cur.writeBytes = values[5]
+		case strings.TrimSpace(line) == "per-op statistics":
+			perOp = true
+		case perOp && strings.HasSuffix(fields[0], ":"):
+			// ops, transmissions, major timeouts, bytes sent, bytes received, queue,
+			// round trip and execute time in milliseconds
+			values, err := parseUints(fields[1:])
+			if err != nil || len(values) < 8 {
+				return nil, fmt.Errorf("invalid statistics of %s: %q", cur.path, line)
+			}
+			cur.ops[strings.TrimSuffix(fields[0], ":")] = opStats{
+				ops:          values[0],
+				transmits:    values[1],
+				majorTimeout: values[2],
+				rtt:          values[6],
+				execute:      values[7],
+			}
+		}
+	}
+	return mounts, scanner.Err()
+}
+
+func parseUints(fields []string) ([]uint64, error) {
+	values := make([]uint64, len(fields))
+	for i, field := range fields {
+		v, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// unescape replaces the octal escapes of the kernel, e.g. \040 for a space.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	inputs.Add("nfs", func() telegraf.Input {
+		return &NFS{
+			MountstatsPath: defaultMountstatsPath,
+			now:            time.Now,
+		}
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nfs
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNFS(n *NFS) *NFS {
+	n.MountstatsPath = filepath.Join("testdata", "mountstats")
+	n.Log = testutil.Logger{}
+	n.now = time.Now
+	return n
+}
+
+// gatherTwice gathers the first and then the next statistics and returns the metrics
+// of the second collection.
+func gatherTwice(t *testing.T, n *NFS) *testutil.Accumulator {
+	t.Helper()
+	acc := &testutil.Accumulator{}
+	require.NoError(t, n.Gather(acc))
+	assert.Empty(t, acc.Metrics)
+	n.MountstatsPath = filepath.Join("testdata", "mountstats_next")
+	acc = &testutil.Accumulator{}
+	require.NoError(t, n.Gather(acc))
+	return acc
+}
+
+func getFields(acc *testutil.Accumulator, path, operation string) map[string]interface{} {
+	for _, m := range acc.Metrics {
+		if m.Tags[pathTag] == path && m.Tags[operationTag] == operation {
+			return m.Fields
+		}
+	}
+	return nil
+}
+
+func TestGather(t *testing.T) {
+	acc := gatherTwice(t, newTestNFS(&NFS{}))
+	assert.Empty(t, acc.Errors)
+
+	for _, m := range acc.Metrics {
+		assert.Equal(t, measurement, m.Measurement)
+	}
+	efs := getFields(acc, "/mnt/efs", "")
+	require.NotNil(t, efs)
+	assert.Equal(t, map[string]interface{}{
+		"read_bytes":  uint64(8192),
+		"write_bytes": uint64(4096),
+	}, efs)
+	assert.Equal(t, map[string]interface{}{
+		"ops":       uint64(10),
+		"retrans":   uint64(0),
+		"timeouts":  uint64(0),
+		"rtt":       6.0,
+		"exec_time": 8.0,
+	}, getFields(acc, "/mnt/efs", "READ"))
+	assert.Equal(t, map[string]interface{}{
+		"ops":       uint64(10),
+		"retrans":   uint64(3),
+		"timeouts":  uint64(1),
+		"rtt":       8.0,
+		"exec_time": 10.0,
+	}, getFields(acc, "/mnt/efs", "WRITE"))
+	// no operations since the previous collection
+	assert.Equal(t, map[string]interface{}{
+		"ops":      uint64(0),
+		"retrans":  uint64(0),
+		"timeouts": uint64(0),
+	}, getFields(acc, "/mnt/efs", "GETATTR"))
+	assert.Nil(t, getFields(acc, "/mnt/efs", "NULL"))
+
+	for _, m := range acc.Metrics {
+		if m.Tags[pathTag] == "/mnt/build cache" {
+			assert.Equal(t, "10.0.0.5:/export/builds", m.Tags[deviceTag])
+			assert.Equal(t, "nfs", m.Tags[fstypeTag])
+		}
+	}
+	assert.Equal(t, map[string]interface{}{
+		"ops":       uint64(4),
+		"retrans":   uint64(1),
+		"timeouts":  uint64(1),
+		"rtt":       5.0,
+		"exec_time": 7.5,
+	}, getFields(acc, "/mnt/build cache", "READ"))
+	assert.Nil(t, getFields(acc, "/", ""))
+}
+
+func TestGather_Filters(t *testing.T) {
+	testCases := map[string]struct {
+		nfs       *NFS
+		wantPaths []string
+	}{
+		"MountPoints": {
+			nfs:       &NFS{MountPoints: []string{"/mnt/efs", "/"}},
+			wantPaths: []string{"/mnt/efs"},
+		},
+		"IgnoreFS": {
+			nfs:       &NFS{IgnoreFS: []string{"nfs4"}},
+			wantPaths: []string{"/mnt/build cache"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			acc := gatherTwice(t, newTestNFS(testCase.nfs))
+			var paths []string
+			for _, m := range acc.Metrics {
+				if m.Tags[operationTag] == "" {
+					paths = append(paths, m.Tags[pathTag])
+				}
+			}
+			assert.ElementsMatch(t, testCase.wantPaths, paths)
+		})
+	}
+}
+
+func TestGather_Operations(t *testing.T) {
+	acc := gatherTwice(t, newTestNFS(&NFS{Operations: []string{"WRITE"}}))
+	var operations []string
+	for _, m := range acc.Metrics {
+		if op := m.Tags[operationTag]; op != "" {
+			operations = append(operations, op)
+		}
+	}
+	assert.Equal(t, []string{"WRITE", "WRITE"}, operations)
+}
+
+func TestGather_Remount(t *testing.T) {
+	n := newTestNFS(&NFS{})
+	n.MountstatsPath = filepath.Join("testdata", "mountstats_next")
+	require.NoError(t, n.Gather(&testutil.Accumulator{}))
+	// the statistics are lower after the mount points are mounted again
+	n.MountstatsPath = filepath.Join("testdata", "mountstats")
+	acc := &testutil.Accumulator{}
+	require.NoError(t, n.Gather(acc))
+	assert.Empty(t, acc.Metrics)
+}
+
+func TestGather_MissingFile(t *testing.T) {
+	n := newTestNFS(&NFS{})
+	n.MountstatsPath = filepath.Join("testdata", "missing")
+	assert.Error(t, n.Gather(&testutil.Accumulator{}))
+}
+
+func TestParseMountstats(t *testing.T) {
+	_, err := parseMountstats(strings.NewReader("device a:/ mounted on /a with fstype nfs\n\tbytes: 1 2\n"))
+	assert.Error(t, err)
+	mounts, err := parseMountstats(strings.NewReader("device a:/ mounted on /a with fstype nfs\n"))
+	require.NoError(t, err)
+	require.Len(t, mounts, 1)
+	assert.Equal(t, "/a", mounts[0].path)
+}
@@ -0,0 +1,111 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package procstat_aggregate
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	psprocess "github.com/shirou/gopsutil/v3/process"
+)
+
+// process is the resource usage of a single process.
+type process struct {
+	pid        int32
+	name       string
+	cmdline    string
+	user       string
+	cgroup     string
+	createTime time.Time
+	// cpuTime is the user and system CPU time in seconds since the process started.
+	cpuTime    float64
+	memoryRSS  uint64
+	memoryVMS  uint64
+	memorySwap uint64
+	numThreads int32
+}
+
+type processLister interface {
+	list() ([]process, error)
+}
+
+// nativeLister lists the processes of the host.
+type nativeLister struct {
+	procPath string
+}
+
+func newNativeLister() *nativeLister {
+	return &nativeLister{procPath: "/proc"}
+}
+
+func (l *nativeLister) list() ([]process, error) {
+	procs, err := psprocess.Processes()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]process, 0, len(procs))
+	for _, proc := range procs {
+		// the processes that exit while they are listed are skipped
+		p, err := l.read(proc)
+		if err != nil {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func (l *nativeLister) read(proc *psprocess.Process) (process, error) {
+	p := process{pid: proc.Pid}
+	var err error
+	if p.name, err = proc.Name(); err != nil {
+		return p, err
+	}
+	createTime, err := proc.CreateTime()
+	if err != nil {
+		return p, err
+	}
+	p.createTime = time.UnixMilli(createTime)
+	times, err := proc.Times()
+	if err != nil {
+		return p, err
+	}
+	p.cpuTime = times.User + times.System
+	memory, err := proc.MemoryInfo()
+	if err != nil {
+		return p, err
+	}
+	p.memoryRSS, p.memoryVMS, p.memorySwap = memory.RSS, memory.VMS, memory.Swap
+	// the rest are best effort since they may need more permissions
+	p.cmdline, _ = proc.Cmdline()
+	p.numThreads, _ = proc.NumThreads()
+	if p.user, err = proc.Username(); err != nil {
+		if uids, err := proc.Uids(); err == nil && len(uids) > 0 {
+			p.user = strconv.Itoa(int(uids[0]))
+		}
+	}
+	p.cgroup, _ = l.readCgroup(proc.Pid)
+	return p, nil
+}
+
+// readCgroup returns the cgroup v2 path of the process, e.g. /system.slice/sshd.service.
+func (l *nativeLister) readCgroup(pid int32) (string, error) {
+	f, err := os.Open(fmt.Sprintf("%s/%d/cgroup", l.procPath, pid))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// the unified hierarchy has the ID 0 and no controllers, e.g. 0::/user.slice
+		if path, ok := strings.CutPrefix(scanner.Text(), "0::"); ok {
+			return path, nil
+		}
+	}
+	return "", scanner.Err()
+}
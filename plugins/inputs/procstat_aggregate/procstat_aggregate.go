@@ -0,0 +1,240 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package procstat_aggregate
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	// measurement is the same as the procstat input so that the aggregated metrics have
+	// the same names, with the group as the dimension instead of the process.
+	measurement = "procstat"
+
+	aggregateByUser    = "user"
+	aggregateByCgroup  = "cgroup"
+	aggregateByCommand = "command"
+
+	defaultTopBy = "cpu_usage"
+)
+
+// groupTags are the tags of the group for each of the aggregation modes.
+var groupTags = map[string]string{
+	aggregateByUser:    "user",
+	aggregateByCgroup:  "cgroup",
+	aggregateByCommand: "process_name",
+}
+
+var topByFields = map[string]bool{
+	"cpu_usage":   true,
+	"memory_rss":  true,
+	"memory_vms":  true,
+	"memory_swap": true,
+	"num_threads": true,
+	"pid_count":   true,
+}
+
+// ProcstatAggregate reports the total resource usage of the processes grouped by
+// user, cgroup or command name instead of per process.
+type ProcstatAggregate struct {
+	AggregateBy string          `toml:"aggregate_by"`
+	CgroupDepth int             `toml:"cgroup_depth"`
+	TopN        int             `toml:"top_n"`
+	TopBy       string          `toml:"top_by"`
+	Exe         string          `toml:"exe"`
+	Pattern     string          `toml:"pattern"`
+	Log         telegraf.Logger `toml:"-"`
+
+	exe     *regexp.Regexp
+	pattern *regexp.Regexp
+	lister  processLister
+	// previous holds the CPU time of the processes at the last collection to
+	// calculate the CPU usage over the interval.
+	previous map[int32]cpuSample
+	now      func() time.Time
+}
+
+type cpuSample struct {
+	createTime time.Time
+	cpuTime    float64
+	at         time.Time
+}
+
+type group struct {
+	name   string
+	fields map[string]float64
+}
+
+func (p *ProcstatAggregate) Description() string {
+	return "Report the resource usage of the processes aggregated by user, cgroup or command"
+}
+
+func (*ProcstatAggregate) SampleConfig() string {
+	return sampleConfig
+}
+
+func (p *ProcstatAggregate) Init() error {
+	if _, ok := groupTags[p.AggregateBy]; !ok {
+		return fmt.Errorf("invalid aggregate_by %q, must be one of user, cgroup or command", p.AggregateBy)
+	}
+	if p.TopN < 0 {
+		return fmt.Errorf("invalid top_n %d", p.TopN)
+	}
+	if p.CgroupDepth < 0 {
+		return fmt.Errorf("invalid cgroup_depth %d", p.CgroupDepth)
+	}
+	if p.TopBy == "" {
+		p.TopBy = defaultTopBy
+	} else if !topByFields[p.TopBy] {
+		return fmt.Errorf("invalid top_by %q", p.TopBy)
+	}
+	var err error
+	if p.Exe != "" {
+		if p.exe, err = regexp.Compile(p.Exe); err != nil {
+			return fmt.Errorf("invalid exe %q: %w", p.Exe, err)
+		}
+	}
+	if p.Pattern != "" {
+		if p.pattern, err = regexp.Compile(p.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", p.Pattern, err)
+		}
+	}
+	if p.lister == nil {
+		p.lister = newNativeLister()
+	}
+	return nil
+}
+
+func (p *ProcstatAggregate) Gather(acc telegraf.Accumulator) error {
+	procs, err := p.lister.list()
+	if err != nil {
+		return fmt.Errorf("unable to list the processes: %w", err)
+	}
+	now := p.now()
+	groups := map[string]*group{}
+	current := make(map[int32]cpuSample, len(procs))
+	for _, proc := range procs {
+		current[proc.pid] = cpuSample{createTime: proc.createTime, cpuTime: proc.cpuTime, at: now}
+		if !p.matches(proc) {
+			continue
+		}
+		name := p.groupName(proc)
+		g, ok := groups[name]
+		if !ok {
+			g = &group{name: name, fields: map[string]float64{}}
+			groups[name] = g
+		}
+		g.fields["cpu_usage"] += p.cpuUsage(proc, now)
+		g.fields["memory_rss"] += float64(proc.memoryRSS)
+		g.fields["memory_vms"] += float64(proc.memoryVMS)
+		g.fields["memory_swap"] += float64(proc.memorySwap)
+		g.fields["num_threads"] += float64(proc.numThreads)
+		g.fields["pid_count"]++
+	}
+	p.previous = current
+
+	for _, g := range p.top(groups) {
+		fields := map[string]interface{}{
+			"cpu_usage":   g.fields["cpu_usage"],
+			"memory_rss":  uint64(g.fields["memory_rss"]),
+			"memory_vms":  uint64(g.fields["memory_vms"]),
+			"memory_swap": uint64(g.fields["memory_swap"]),
+			"num_threads": int64(g.fields["num_threads"]),
+			"pid_count":   int64(g.fields["pid_count"]),
+		}
+		acc.AddGauge(measurement, fields, map[string]string{groupTags[p.AggregateBy]: g.name}, now)
+	}
+	return nil
+}
+
+func (p *ProcstatAggregate) matches(proc process) bool {
+	if p.exe != nil && !p.exe.MatchString(proc.name) {
+		return false
+	}
+	return p.pattern == nil || p.pattern.MatchString(proc.cmdline)
+}
+
+func (p *ProcstatAggregate) groupName(proc process) string {
+	switch p.AggregateBy {
+	case aggregateByUser:
+		return proc.user
+	case aggregateByCgroup:
+		return trimCgroup(proc.cgroup, p.CgroupDepth)
+	default:
+		return proc.name
+	}
+}
+
+// cpuUsage returns the CPU usage of the process in percent since the last collection,
+// or since the process started if it was not running at the last collection.
+func (p *ProcstatAggregate) cpuUsage(proc process, now time.Time) float64 {
+	start, cpuTime := proc.createTime, proc.cpuTime
+	if prev, ok := p.previous[proc.pid]; ok && prev.createTime.Equal(proc.createTime) {
+		start, cpuTime = prev.at, proc.cpuTime-prev.cpuTime
+	}
+	elapsed := now.Sub(start).Seconds()
+	if elapsed <= 0 || cpuTime < 0 {
+		return 0
+	}
+	return cpuTime / elapsed * 100
+}
+
+// top returns the groups sorted by name, or the top_n groups with the highest top_by
+// value if top_n is set.
+func (p *ProcstatAggregate) top(groups map[string]*group) []*group {
+	sorted := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	if p.TopN == 0 {
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].name < sorted[j].name
+		})
+		return sorted
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if a, b := sorted[i].fields[p.TopBy], sorted[j].fields[p.TopBy]; a != b {
+			return a > b
+		}
+		return sorted[i].name < sorted[j].name
+	})
+	if len(sorted) > p.TopN {
+		sorted = sorted[:p.TopN]
+	}
+	return sorted
+}
+
+// trimCgroup returns the first depth levels of the cgroup path, e.g. /system.slice for
+// /system.slice/docker.service with a depth of 1. The whole path is returned if the
+// depth is 0.
+func trimCgroup(cgroup string, depth int) string {
+	if depth == 0 || cgroup == "" {
+		return cgroup
+	}
+	parts := strings.Split(strings.TrimPrefix(cgroup, "/"), "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+func init() {
+	inputs.Add("procstat_aggregate", func() telegraf.Input {
+		return &ProcstatAggregate{
+			TopBy: defaultTopBy,
+			now:   time.Now,
+		}
+	})
+}
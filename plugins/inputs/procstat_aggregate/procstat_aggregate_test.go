@@ -0,0 +1,211 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package procstat_aggregate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLister struct {
+	procs []process
+	err   error
+}
+
+func (l *fakeLister) list() ([]process, error) {
+	return l.procs, l.err
+}
+
+var (
+	baseTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testNow  = baseTime.Add(100 * time.Second)
+)
+
+func testProcesses() []process {
+	return []process{
+		{pid: 1, name: "systemd", cmdline: "/sbin/init", user: "root", cgroup: "/init.scope", createTime: baseTime, cpuTime: 1, memoryRSS: 100, memoryVMS: 1000, numThreads: 1},
+		{pid: 10, name: "dockerd", cmdline: "/usr/bin/dockerd -H fd://", user: "root", cgroup: "/system.slice/docker.service", createTime: baseTime, cpuTime: 20, memoryRSS: 300, memoryVMS: 3000, numThreads: 10},
+		{pid: 20, name: "java", cmdline: "java -jar app.jar", user: "app", cgroup: "/system.slice/docker-abc.scope", createTime: baseTime, cpuTime: 50, memoryRSS: 2000, memoryVMS: 8000, memorySwap: 10, numThreads: 40},
+		{pid: 21, name: "java", cmdline: "java -jar worker.jar", user: "app", cgroup: "/system.slice/docker-def.scope", createTime: baseTime, cpuTime: 10, memoryRSS: 1000, memoryVMS: 4000, numThreads: 20},
+	}
+}
+
+func newTestProcstatAggregate(t *testing.T, p *ProcstatAggregate, procs []process) *ProcstatAggregate {
+	p.Log = testutil.Logger{}
+	p.lister = &fakeLister{procs: procs}
+	p.now = func() time.Time { return testNow }
+	require.NoError(t, p.Init())
+	return p
+}
+
+func gather(t *testing.T, p *ProcstatAggregate) map[string]map[string]interface{} {
+	t.Helper()
+	acc := &testutil.Accumulator{}
+	require.NoError(t, p.Gather(acc))
+	got := map[string]map[string]interface{}{}
+	for _, m := range acc.Metrics {
+		assert.Equal(t, measurement, m.Measurement)
+		require.Len(t, m.Tags, 1)
+		for _, v := range m.Tags {
+			got[v] = m.Fields
+		}
+	}
+	return got
+}
+
+func TestGather_AggregateBy(t *testing.T) {
+	testCases := map[string]struct {
+		p    *ProcstatAggregate
+		want map[string]map[string]interface{}
+	}{
+		"User": {
+			p: &ProcstatAggregate{AggregateBy: "user"},
+			want: map[string]map[string]interface{}{
+				"root": {"cpu_usage": 21.0, "memory_rss": uint64(400), "memory_vms": uint64(4000), "memory_swap": uint64(0), "num_threads": int64(11), "pid_count": int64(2)},
+				"app":  {"cpu_usage": 60.0, "memory_rss": uint64(3000), "memory_vms": uint64(12000), "memory_swap": uint64(10), "num_threads": int64(60), "pid_count": int64(2)},
+			},
+		},
+		"Cgroup": {
+			p: &ProcstatAggregate{AggregateBy: "cgroup", CgroupDepth: 1},
+			want: map[string]map[string]interface{}{
+				"/init.scope":   {"cpu_usage": 1.0, "memory_rss": uint64(100), "memory_vms": uint64(1000), "memory_swap": uint64(0), "num_threads": int64(1), "pid_count": int64(1)},
+				"/system.slice": {"cpu_usage": 80.0, "memory_rss": uint64(3300), "memory_vms": uint64(15000), "memory_swap": uint64(10), "num_threads": int64(70), "pid_count": int64(3)},
+			},
+		},
+		"CommandWithPattern": {
+			p: &ProcstatAggregate{AggregateBy: "command", Pattern: `\.jar`},
+			want: map[string]map[string]interface{}{
+				"java": {"cpu_usage": 60.0, "memory_rss": uint64(3000), "memory_vms": uint64(12000), "memory_swap": uint64(10), "num_threads": int64(60), "pid_count": int64(2)},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newTestProcstatAggregate(t, testCase.p, testProcesses())
+			assert.Equal(t, testCase.want, gather(t, p))
+		})
+	}
+}
+
+func TestGather_FullCgroupPath(t *testing.T) {
+	p := newTestProcstatAggregate(t, &ProcstatAggregate{AggregateBy: "cgroup"}, testProcesses())
+	got := gather(t, p)
+	assert.Len(t, got, 4)
+	assert.Contains(t, got, "/system.slice/docker-abc.scope")
+}
+
+func TestGather_TopN(t *testing.T) {
+	testCases := map[string]struct {
+		p    *ProcstatAggregate
+		want []string
+	}{
+		"ByCPU": {
+			p:    &ProcstatAggregate{AggregateBy: "command", TopN: 2},
+			want: []string{"java", "dockerd"},
+		},
+		"ByMemory": {
+			p:    &ProcstatAggregate{AggregateBy: "command", TopN: 1, TopBy: "memory_rss"},
+			want: []string{"java"},
+		},
+		"ByPidCount": {
+			p:    &ProcstatAggregate{AggregateBy: "user", TopN: 5, TopBy: "pid_count"},
+			want: []string{"app", "root"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newTestProcstatAggregate(t, testCase.p, testProcesses())
+			acc := &testutil.Accumulator{}
+			require.NoError(t, p.Gather(acc))
+			var got []string
+			for _, m := range acc.Metrics {
+				got = append(got, m.Tags["process_name"]+m.Tags["user"])
+			}
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestGather_CPUUsageSinceLastCollection(t *testing.T) {
+	procs := testProcesses()
+	lister := &fakeLister{procs: procs}
+	p := newTestProcstatAggregate(t, &ProcstatAggregate{AggregateBy: "user"}, nil)
+	p.lister = lister
+	gather(t, p)
+
+	next := testProcesses()
+	next[0].cpuTime += 5  // root
+	next[1].cpuTime += 10 // root
+	next[2].cpuTime += 25 // app
+	// the process was restarted with the same pid
+	next[3].createTime = testNow.Add(40 * time.Second)
+	next[3].cpuTime = 10
+	lister.procs = next
+	p.now = func() time.Time { return testNow.Add(50 * time.Second) }
+	got := gather(t, p)
+	assert.Equal(t, 30.0, got["root"]["cpu_usage"])
+	assert.Equal(t, 150.0, got["app"]["cpu_usage"])
+}
+
+func TestGather_Error(t *testing.T) {
+	p := newTestProcstatAggregate(t, &ProcstatAggregate{AggregateBy: "user"}, nil)
+	p.lister = &fakeLister{err: errors.New("permission denied")}
+	assert.Error(t, p.Gather(&testutil.Accumulator{}))
+}
+
+func TestInit(t *testing.T) {
+	testCases := map[string]*ProcstatAggregate{
+		"MissingAggregateBy": {},
+		"InvalidAggregateBy": {AggregateBy: "pid"},
+		"InvalidTopN":        {AggregateBy: "user", TopN: -1},
+		"InvalidTopBy":       {AggregateBy: "user", TopBy: "pid"},
+		"InvalidExe":         {AggregateBy: "user", Exe: "("},
+		"InvalidCgroupDepth": {AggregateBy: "cgroup", CgroupDepth: -1},
+	}
+	for name, p := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, p.Init())
+		})
+	}
+}
+
+func TestTrimCgroup(t *testing.T) {
+	assert.Equal(t, "/system.slice/docker.service", trimCgroup("/system.slice/docker.service", 0))
+	assert.Equal(t, "/system.slice", trimCgroup("/system.slice/docker.service", 1))
+	assert.Equal(t, "/system.slice/docker.service", trimCgroup("/system.slice/docker.service", 3))
+	assert.Equal(t, "/", trimCgroup("/", 1))
+}
+
+func TestReadCgroup(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "42"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "42", "cgroup"), []byte("0::/system.slice/sshd.service\n"), 0600))
+	l := &nativeLister{procPath: dir}
+	cgroup, err := l.readCgroup(42)
+	require.NoError(t, err)
+	assert.Equal(t, "/system.slice/sshd.service", cgroup)
+	_, err = l.readCgroup(43)
+	assert.Error(t, err)
+}
+
+func TestNativeLister(t *testing.T) {
+	procs, err := newNativeLister().list()
+	require.NoError(t, err)
+	pid := int32(os.Getpid())
+	for _, proc := range procs {
+		if proc.pid == pid {
+			assert.NotEmpty(t, proc.name)
+			assert.NotZero(t, proc.memoryRSS)
+			return
+		}
+	}
+	assert.Failf(t, "missing process", "pid %d is not listed", pid)
+}
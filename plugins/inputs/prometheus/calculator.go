@@ -25,6 +25,8 @@ func (c *Calculator) Calculate(pmb PrometheusMetricBatch) (result PrometheusMetr
 	var gauges PrometheusMetricBatch
 	var counters PrometheusMetricBatch
 	var summaries PrometheusMetricBatch
+	var histograms PrometheusMetricBatch
+	classic := newClassicHistograms()
 
 	for _, pm := range pmb {
 		if pm.isGauge() {
@@ -43,15 +45,52 @@ func (c *Calculator) Calculate(pmb PrometheusMetricBatch) (result PrometheusMetr
 			} else {
 				summaries = appendValidValue(summaries, pm)
 			}
+		} else if pm.isHistogram() {
+			if pm.nativeHistogram != nil {
+				if calculatedMetric := c.deltaCalculator.calculateHistogram(pm); calculatedMetric != nil {
+					histograms = append(histograms, convertNativeHistogram(calculatedMetric)...)
+				}
+			} else if strings.HasSuffix(pm.metricName, histogramBucketSuffix) {
+				// buckets are converted together once the whole histogram is collected
+				classic.add(pm)
+			} else if strings.HasSuffix(pm.metricName, histogramSummaryCountSuffix) ||
+				strings.HasSuffix(pm.metricName, histogramSummarySumSuffix) {
+				if calculatedMetric := c.deltaCalculator.calculate(pm); calculatedMetric != nil {
+					histograms = append(histograms, calculatedMetric)
+				}
+			}
 		}
 	}
+	histograms = append(histograms, classic.convert(c.deltaCalculator)...)
 
 	result = append(result, gauges...)
 	result = append(result, counters...)
 	result = append(result, summaries...)
+	result = append(result, histograms...)
 	return
 }
 
+// convertNativeHistogram splits the delta of a native histogram into a distribution and
+// the <basename>_sum and <basename>_count metrics that classic histograms provide.
+func convertNativeHistogram(pm *PrometheusMetric) (result PrometheusMetricBatch) {
+	fh := pm.nativeHistogram
+	pm.nativeHistogram = nil
+	sum := *pm
+	sum.metricName += histogramSummarySumSuffix
+	sum.metricValue = fh.Sum
+	sum.exemplar = nil
+	count := *pm
+	count.metricName += histogramSummaryCountSuffix
+	count.metricValue = fh.Count
+	count.exemplar = nil
+	result = append(result, &sum, &count)
+	if d := nativeHistogramToDistribution(fh); d.SampleCount() > 0 {
+		pm.distribution = d
+		result = append(result, pm)
+	}
+	return result
+}
+
 func NewCalculator() *Calculator {
 	return &Calculator{
 		deltaCalculator: NewDeltaCalculator(),
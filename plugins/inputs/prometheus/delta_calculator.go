@@ -7,6 +7,9 @@ import (
 	"log"
 	"time"
 
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/model/value"
+
 	"github.com/aws/amazon-cloudwatch-agent/internal/mapWithExpiry"
 )
 
@@ -19,6 +22,11 @@ type dataPoint struct {
 	value    float64
 	timeInMS int64
 }
+type histogramDataPoint struct {
+	histogram *histogram.FloatHistogram
+	timeInMS  int64
+}
+
 type DeltaCalculator struct {
 	preDataPoints       *mapWithExpiry.MapWithExpiry
 	lastCleanUpTimeInMs int64
@@ -39,16 +47,18 @@ func (dc *DeltaCalculator) calculate(pm *PrometheusMetric) (res *PrometheusMetri
 	curVal := pm.metricValue
 	curTimeInMS := pm.timeInMS
 	if v, ok := dc.preDataPoints.Get(metricKey); ok {
-		preDataPoint := v.(dataPoint)
-		if curTimeInMS > preDataPoint.timeInMS {
-			if curVal >= preDataPoint.value {
-				pm.metricValue = curVal - preDataPoint.value
-			} else {
-				// the counter has been reset, keep the current value as delta
-				pm.metricValue = curVal
+		// the previous value of the series is reset if it was a native histogram
+		if preDataPoint, ok := v.(dataPoint); ok {
+			if curTimeInMS > preDataPoint.timeInMS {
+				if curVal >= preDataPoint.value {
+					pm.metricValue = curVal - preDataPoint.value
+				} else {
+					// the counter has been reset, keep the current value as delta
+					pm.metricValue = curVal
+				}
 			}
+			res = pm
 		}
-		res = pm
 	}
 
 	// Clean up the stale cache periodically
@@ -62,6 +72,42 @@ func (dc *DeltaCalculator) calculate(pm *PrometheusMetric) (res *PrometheusMetri
 	return
 }
 
+// calculateHistogram replaces the cumulative native histogram of the metric with the
+// delta to the previously seen histogram of the same series.
+func (dc *DeltaCalculator) calculateHistogram(pm *PrometheusMetric) (res *PrometheusMetric) {
+	metricKey := getUniqMetricKey(pm)
+
+	cur := pm.nativeHistogram
+	if value.IsStaleNaN(cur.Sum) {
+		log.Printf("D! DeltaCalculator.calculateHistogram: Drop stale histogram: %v", pm)
+		dc.preDataPoints.Delete(metricKey)
+		return nil
+	}
+
+	curTimeInMS := pm.timeInMS
+	if v, ok := dc.preDataPoints.Get(metricKey); ok {
+		if preDataPoint, ok := v.(histogramDataPoint); ok && curTimeInMS > preDataPoint.timeInMS {
+			if cur.DetectReset(preDataPoint.histogram) {
+				// the histogram has been reset, keep the current value as delta
+				pm.nativeHistogram = cur.Copy()
+			} else {
+				pm.nativeHistogram = cur.Copy().Sub(preDataPoint.histogram)
+			}
+			res = pm
+		}
+	}
+
+	// Clean up the stale cache periodically
+	if curTimeInMS-dc.lastCleanUpTimeInMs >= CleanUpTimeThreshold {
+		dc.preDataPoints.CleanUp(time.Now())
+		dc.lastCleanUpTimeInMs = curTimeInMS
+	}
+
+	dc.preDataPoints.Set(metricKey, histogramDataPoint{histogram: cur, timeInMS: curTimeInMS})
+
+	return
+}
+
 func NewDeltaCalculator() *DeltaCalculator {
 	return &DeltaCalculator{preDataPoints: mapWithExpiry.NewMapWithExpiry(CacheTTL), lastCleanUpTimeInMs: 0}
 }
@@ -0,0 +1,167 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
+
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution/seh1"
+)
+
+// newDistribution uses the distribution type chosen by the CloudWatch output when it
+// is configured, otherwise falls back to SEH1 which is what the output uses by default.
+func newDistribution() distribution.Distribution {
+	if distribution.NewDistribution != nil {
+		return distribution.NewDistribution()
+	}
+	return seh1.NewSEH1Distribution()
+}
+
+type histogramBucket struct {
+	upperBound float64
+	pm         *PrometheusMetric
+}
+
+// classicHistogram groups the <basename>_bucket series that belong to the same histogram.
+type classicHistogram struct {
+	metricName string
+	tags       map[string]string
+	timeInMS   int64
+	buckets    []histogramBucket
+}
+
+type classicHistograms struct {
+	order      []string
+	histograms map[string]*classicHistogram
+}
+
+func newClassicHistograms() *classicHistograms {
+	return &classicHistograms{histograms: map[string]*classicHistogram{}}
+}
+
+// add the <basename>_bucket metric to its histogram. The le tag is removed from the
+// histogram tags so that the converted distribution shares the tags of <basename>_sum
+// and <basename>_count and is merged with them.
+func (ch *classicHistograms) add(pm *PrometheusMetric) {
+	upperBound, err := strconv.ParseFloat(pm.tags[model.BucketLabel], 64)
+	if err != nil {
+		log.Printf("D! Drop histogram bucket with invalid %s tag: %v", model.BucketLabel, pm)
+		return
+	}
+	tags := make(map[string]string, len(pm.tags))
+	for k, v := range pm.tags {
+		if k != model.BucketLabel {
+			tags[k] = v
+		}
+	}
+	metricName := strings.TrimSuffix(pm.metricName, histogramBucketSuffix)
+	key := getUniqMetricKey(&PrometheusMetric{metricName: metricName, tags: tags})
+	h, ok := ch.histograms[key]
+	if !ok {
+		h = &classicHistogram{metricName: metricName, tags: tags, timeInMS: pm.timeInMS}
+		ch.histograms[key] = h
+		ch.order = append(ch.order, key)
+	}
+	h.buckets = append(h.buckets, histogramBucket{upperBound: upperBound, pm: pm})
+}
+
+// convert calculates the delta of every bucket and converts each histogram into a
+// metric holding a distribution. Histograms with a bucket seen for the first time are
+// skipped since a delta cannot be calculated for them yet, as are histograms without
+// new samples.
+func (ch *classicHistograms) convert(dc *DeltaCalculator) (result PrometheusMetricBatch) {
+	for _, key := range ch.order {
+		h := ch.histograms[key]
+		sort.Slice(h.buckets, func(i, j int) bool {
+			return h.buckets[i].upperBound < h.buckets[j].upperBound
+		})
+		complete := true
+		for _, bucket := range h.buckets {
+			if dc.calculate(bucket.pm) == nil {
+				complete = false
+			}
+		}
+		if !complete {
+			continue
+		}
+		d := bucketsToDistribution(h.buckets)
+		if d.SampleCount() == 0 {
+			continue
+		}
+		result = append(result, &PrometheusMetric{
+			metricName:   h.metricName,
+			metricType:   string(v1.MetricTypeHistogram),
+			tags:         h.tags,
+			timeInMS:     h.timeInMS,
+			distribution: d,
+			exemplar:     latestExemplar(h.buckets),
+		})
+	}
+	return result
+}
+
+// latestExemplar returns the most recent exemplar across the buckets of the histogram.
+func latestExemplar(buckets []histogramBucket) (latest *exemplar.Exemplar) {
+	for _, bucket := range buckets {
+		if e := bucket.pm.exemplar; e != nil && (latest == nil || e.Ts > latest.Ts) {
+			latest = e
+		}
+	}
+	return latest
+}
+
+// bucketsToDistribution converts cumulative bucket counts sorted by upper bound into a
+// distribution. The samples of a bucket are recorded at the middle of the bucket, so
+// the exact sum and count are left to the <basename>_sum and <basename>_count metrics.
+func bucketsToDistribution(buckets []histogramBucket) distribution.Distribution {
+	d := newDistribution()
+	var lowerBound, previousCount float64
+	for _, bucket := range buckets {
+		count := bucket.pm.metricValue - previousCount
+		previousCount = bucket.pm.metricValue
+		value := lowerBound
+		if !math.IsInf(bucket.upperBound, 1) {
+			value = (lowerBound + bucket.upperBound) / 2
+			lowerBound = bucket.upperBound
+		}
+		addDistributionEntry(d, value, count)
+	}
+	return d
+}
+
+// nativeHistogramToDistribution converts the zero and positive buckets of a native
+// histogram into a distribution. Negative buckets are dropped since distributions only
+// support non-negative values.
+func nativeHistogramToDistribution(fh *histogram.FloatHistogram) distribution.Distribution {
+	d := newDistribution()
+	addDistributionEntry(d, 0, fh.ZeroCount)
+	it := fh.PositiveBucketIterator()
+	for it.Next() {
+		bucket := it.At()
+		addDistributionEntry(d, (bucket.Lower+bucket.Upper)/2, bucket.Count)
+	}
+	if len(fh.NegativeSpans) > 0 {
+		log.Printf("D! Drop negative buckets of native histogram with sum %v", fh.Sum)
+	}
+	return d
+}
+
+func addDistributionEntry(d distribution.Distribution, value float64, count float64) {
+	if count <= 0 {
+		return
+	}
+	if err := d.AddEntry(value, count); err != nil {
+		log.Printf("D! Drop histogram bucket with value %v and count %v: %v", value, count, err)
+	}
+}
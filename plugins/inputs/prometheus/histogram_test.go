@@ -0,0 +1,182 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildClassicHistogram(timeInMS int64, buckets map[string]float64, sum float64, count float64) (result PrometheusMetricBatch) {
+	for le, value := range buckets {
+		result = append(result, &PrometheusMetric{
+			metricName:  "request_duration_bucket",
+			metricType:  "histogram",
+			metricValue: value,
+			timeInMS:    timeInMS,
+			tags:        map[string]string{"le": le, "job": "test"},
+		})
+	}
+	result = append(result,
+		&PrometheusMetric{
+			metricName:  "request_duration_sum",
+			metricType:  "histogram",
+			metricValue: sum,
+			timeInMS:    timeInMS,
+			tags:        map[string]string{"job": "test"},
+		},
+		&PrometheusMetric{
+			metricName:  "request_duration_count",
+			metricType:  "histogram",
+			metricValue: count,
+			timeInMS:    timeInMS,
+			tags:        map[string]string{"job": "test"},
+		},
+	)
+	return result
+}
+
+func findMetric(pmb PrometheusMetricBatch, name string) *PrometheusMetric {
+	for _, pm := range pmb {
+		if pm.metricName == name {
+			return pm
+		}
+	}
+	return nil
+}
+
+func TestCalculator_ClassicHistogram(t *testing.T) {
+	c := NewCalculator()
+
+	// first scrape only initializes the deltas
+	result := c.Calculate(buildClassicHistogram(1000, map[string]float64{"1": 1, "5": 3, "+Inf": 4}, 10, 4))
+	assert.Empty(t, result)
+
+	result = c.Calculate(buildClassicHistogram(2000, map[string]float64{"1": 3, "5": 6, "+Inf": 8}, 30, 8))
+	require.Len(t, result, 3)
+	assert.Equal(t, 20.0, findMetric(result, "request_duration_sum").metricValue)
+	assert.Equal(t, 4.0, findMetric(result, "request_duration_count").metricValue)
+
+	pm := findMetric(result, "request_duration")
+	require.NotNil(t, pm)
+	assert.Equal(t, map[string]string{"job": "test"}, pm.tags)
+	require.NotNil(t, pm.distribution)
+	assert.Equal(t, 4.0, pm.distribution.SampleCount())
+	assert.Equal(t, 0.5, pm.distribution.Minimum())
+	assert.Equal(t, 5.0, pm.distribution.Maximum())
+
+	// no new samples, so only the _sum and _count deltas are emitted
+	result = c.Calculate(buildClassicHistogram(3000, map[string]float64{"1": 3, "5": 6, "+Inf": 8}, 30, 8))
+	require.Len(t, result, 2)
+	assert.Nil(t, findMetric(result, "request_duration"))
+}
+
+func TestCalculator_ClassicHistogramExemplar(t *testing.T) {
+	c := NewCalculator()
+	c.Calculate(buildClassicHistogram(1000, map[string]float64{"1": 1, "+Inf": 1}, 1, 1))
+	pmb := buildClassicHistogram(2000, map[string]float64{"1": 2, "+Inf": 3}, 2, 3)
+	older := &exemplar.Exemplar{Labels: labels.FromStrings("trace_id", "older"), Ts: 1500}
+	newer := &exemplar.Exemplar{Labels: labels.FromStrings("trace_id", "newer"), Ts: 1900}
+	for _, pm := range pmb {
+		switch pm.tags["le"] {
+		case "1":
+			pm.exemplar = older
+		case "+Inf":
+			pm.exemplar = newer
+		}
+	}
+	pm := findMetric(c.Calculate(pmb), "request_duration")
+	require.NotNil(t, pm)
+	assert.Equal(t, newer, pm.exemplar)
+}
+
+func TestCalculator_ClassicHistogramInvalidBucket(t *testing.T) {
+	c := NewCalculator()
+	buckets := map[string]float64{"invalid": 1, "+Inf": 1}
+	c.Calculate(buildClassicHistogram(1000, buckets, 1, 1))
+	buckets = map[string]float64{"invalid": 2, "+Inf": 2}
+	result := c.Calculate(buildClassicHistogram(2000, buckets, 2, 2))
+	pm := findMetric(result, "request_duration")
+	require.NotNil(t, pm)
+	assert.Equal(t, 1.0, pm.distribution.SampleCount())
+}
+
+func TestDeltaCalculator_TypeChange(t *testing.T) {
+	dc := NewDeltaCalculator()
+	assert.Nil(t, dc.calculateHistogram(buildNativeHistogram(1000, 1, []float64{2, 3})))
+	counter := func(timeInMS int64, value float64) *PrometheusMetric {
+		return &PrometheusMetric{
+			metricName:  "request_duration",
+			metricType:  "counter",
+			metricValue: value,
+			timeInMS:    timeInMS,
+			tags:        map[string]string{"job": "test"},
+		}
+	}
+	// the previous native histogram of the series is reset
+	assert.Nil(t, dc.calculate(counter(2000, 5)))
+	pm := dc.calculate(counter(3000, 8))
+	require.NotNil(t, pm)
+	assert.Equal(t, 3.0, pm.metricValue)
+	// and so is the previous counter
+	assert.Nil(t, dc.calculateHistogram(buildNativeHistogram(4000, 1, []float64{2, 3})))
+}
+
+func buildNativeHistogram(timeInMS int64, zeroCount float64, counts []float64) *PrometheusMetric {
+	return &PrometheusMetric{
+		metricName: "request_duration",
+		metricType: "histogram",
+		timeInMS:   timeInMS,
+		tags:       map[string]string{"job": "test"},
+		nativeHistogram: &histogram.FloatHistogram{
+			Schema:          0,
+			ZeroThreshold:   0.001,
+			ZeroCount:       zeroCount,
+			Count:           zeroCount + counts[0] + counts[1],
+			Sum:             float64(10 * timeInMS / 1000),
+			PositiveSpans:   []histogram.Span{{Offset: 0, Length: 2}},
+			PositiveBuckets: counts,
+		},
+	}
+}
+
+func TestCalculator_NativeHistogram(t *testing.T) {
+	c := NewCalculator()
+
+	result := c.Calculate(PrometheusMetricBatch{buildNativeHistogram(1000, 1, []float64{2, 3})})
+	assert.Empty(t, result)
+
+	result = c.Calculate(PrometheusMetricBatch{buildNativeHistogram(2000, 2, []float64{4, 3})})
+	require.Len(t, result, 3)
+	assert.Equal(t, 10.0, findMetric(result, "request_duration_sum").metricValue)
+	assert.Equal(t, 3.0, findMetric(result, "request_duration_count").metricValue)
+	pm := findMetric(result, "request_duration")
+	require.NotNil(t, pm)
+	assert.Nil(t, pm.nativeHistogram)
+	assert.Equal(t, 3.0, pm.distribution.SampleCount())
+	assert.Equal(t, 0.0, pm.distribution.Minimum())
+
+	// the counts went down, so the histogram has been reset
+	result = c.Calculate(PrometheusMetricBatch{buildNativeHistogram(3000, 0, []float64{1, 0})})
+	require.Len(t, result, 3)
+	assert.Equal(t, 1.0, findMetric(result, "request_duration_count").metricValue)
+	assert.Equal(t, 1.0, findMetric(result, "request_duration").distribution.SampleCount())
+}
+
+func TestCalculator_NativeHistogramStale(t *testing.T) {
+	c := NewCalculator()
+	c.Calculate(PrometheusMetricBatch{buildNativeHistogram(1000, 1, []float64{2, 3})})
+	stale := buildNativeHistogram(2000, 1, []float64{2, 3})
+	stale.nativeHistogram.Sum = math.Float64frombits(value.StaleNaN)
+	assert.Empty(t, c.Calculate(PrometheusMetricBatch{stale}))
+	// the previous value has been reset, so the next histogram is the first one again
+	assert.Empty(t, c.Calculate(PrometheusMetricBatch{buildNativeHistogram(3000, 2, []float64{4, 3})}))
+}
@@ -9,15 +9,19 @@ import (
 	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/prometheus/prometheus/model/exemplar"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/containerinsightscommon"
+	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 )
 
 // Use metricMaterial instead of mbMetric to avoid unnecessary tags&fields copy
 type metricMaterial struct {
-	tags     map[string]string
-	fields   map[string]interface{}
-	timeInMS int64
+	tags      map[string]string
+	fields    map[string]interface{}
+	timeInMS  int64
+	exemplars map[string]*exemplar.Exemplar
 }
 
 type metricsHandler struct {
@@ -46,10 +50,10 @@ func (mh *metricsHandler) handle(pmb PrometheusMetricBatch) {
 	// Add metric type info
 	pmb = mh.mtHandler.Handle(pmb)
 
-	// Filter out Histogram and untyped Metrics and adding logging
+	// Filter out untyped Metrics and adding logging
 	pmb = mh.filter.Filter(pmb)
 
-	// do calculation: calculate delta for counter
+	// do calculation: calculate delta for counter and convert histogram buckets to distributions
 	pmb = mh.calculator.Calculate(pmb)
 
 	// do merge: merge metrics which are sharing same tags
@@ -59,7 +63,27 @@ func (mh *metricsHandler) handle(pmb PrometheusMetricBatch) {
 	mh.setEmfMetadata(metricMaterials)
 
 	for _, metricMaterial := range metricMaterials {
-		mh.acc.AddFields("prometheus", metricMaterial.fields, metricMaterial.tags, time.UnixMilli(metricMaterial.timeInMS))
+		mh.addMetricMaterial(metricMaterial)
+	}
+}
+
+func (mh *metricsHandler) addMetricMaterial(mm *metricMaterial) {
+	t := time.UnixMilli(mm.timeInMS)
+	// distributions have to be added as histograms to be converted by the accumulator
+	distributions := map[string]interface{}{}
+	for name, field := range mm.fields {
+		if d, ok := field.(distribution.Distribution); ok {
+			distributions[name] = withExemplar(d, mm.exemplars[name], t)
+			delete(mm.fields, name)
+		} else {
+			mm.fields[name] = withExemplar(field, mm.exemplars[name], t)
+		}
+	}
+	if len(mm.fields) > 0 {
+		mh.acc.AddFields("prometheus", mm.fields, mm.tags, t)
+	}
+	if len(distributions) > 0 {
+		mh.acc.AddHistogram("prometheus", distributions, mm.tags, t)
 	}
 }
 
@@ -93,3 +117,23 @@ func (mh *metricsHandler) setEmfMetadata(mms []*metricMaterial) {
 		}
 	}
 }
+
+// withExemplar returns the field value with its exemplar, which the adapter converts to an
+// exemplar of the OTel data point instead of attributes, so that the exemplar labels, e.g. the
+// trace_id, do not become dimensions. Exemplars without a timestamp are at the time of the sample.
+func withExemplar(value interface{}, e *exemplar.Exemplar, t time.Time) interface{} {
+	if e == nil {
+		return value
+	}
+	if e.HasTs {
+		t = time.UnixMilli(e.Ts)
+	}
+	return metric.ValueWithExemplar{
+		Value: value,
+		Exemplar: metric.Exemplar{
+			Labels:    e.Labels.Map(),
+			Value:     e.Value,
+			Timestamp: t,
+		},
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
+	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter/accumulator"
+)
+
+func TestMetricsHandler_Exemplar(t *testing.T) {
+	exemplars := map[string]*exemplar.Exemplar{
+		"requests_total": {
+			Labels: labels.FromStrings("span_id", "00f067aa0ba902b7", "trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"),
+			Value:  1,
+			Ts:     1000,
+			HasTs:  true,
+		},
+		"request_duration": {Labels: labels.FromStrings("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"), Value: 0.5},
+	}
+	handle := func(exemplars map[string]*exemplar.Exemplar) *testutil.Accumulator {
+		acc := &testutil.Accumulator{}
+		mh := &metricsHandler{acc: acc}
+		d := newDistribution()
+		require.NoError(t, d.AddEntry(0.5, 1))
+		pmb := PrometheusMetricBatch{
+			{metricName: "requests_total", tags: map[string]string{"job": "test"}, metricValue: 1, timeInMS: 2000, exemplar: exemplars["requests_total"]},
+			{metricName: "errors_total", tags: map[string]string{"job": "test"}, metricValue: 2, timeInMS: 2000},
+			{metricName: "request_duration", tags: map[string]string{"job": "test"}, distribution: d, timeInMS: 2000, exemplar: exemplars["request_duration"]},
+		}
+		mms := mergeMetrics(pmb)
+		mh.setEmfMetadata(mms)
+		for _, mm := range mms {
+			mh.addMetricMaterial(mm)
+		}
+		require.Len(t, acc.Metrics, 2)
+		return acc
+	}
+
+	acc := handle(exemplars)
+	assert.Equal(t, map[string]string{"job": "test"}, acc.Metrics[0].Tags)
+	assert.Equal(t, 2.0, acc.Metrics[0].Fields["errors_total"])
+	got, ok := acc.Metrics[0].Fields["requests_total"].(metric.ValueWithExemplar)
+	require.True(t, ok)
+	assert.Equal(t, metric.ValueWithExemplar{
+		Value: 1.0,
+		Exemplar: metric.Exemplar{
+			Labels:    map[string]string{"span_id": "00f067aa0ba902b7", "trace_id": "4bf92f3577b34da6a3ce929d0e0e4736"},
+			Value:     1,
+			Timestamp: time.UnixMilli(1000),
+		},
+	}, got)
+	got, ok = acc.Metrics[1].Fields["request_duration"].(metric.ValueWithExemplar)
+	require.True(t, ok)
+	assert.Equal(t, time.UnixMilli(2000), got.Exemplar.Timestamp, "exemplars without a timestamp are at the time of the sample")
+
+	// the exemplars are not attributes, so they do not change the dimensions
+	withoutExemplars := handle(nil)
+	for i, m := range acc.Metrics {
+		md, err := accumulator.ConvertTelegrafToOtelMetrics(m.Measurement, m.Fields, m.Tags, m.Type, m.Time)
+		require.NoError(t, err)
+		want, err := accumulator.ConvertTelegrafToOtelMetrics(m.Measurement, withoutExemplars.Metrics[i].Fields, withoutExemplars.Metrics[i].Tags, m.Type, m.Time)
+		require.NoError(t, err)
+		assert.Equal(t, dataPointAttributes(want), dataPointAttributes(md))
+		assert.Positive(t, dataPointExemplars(md))
+		assert.Zero(t, dataPointExemplars(want))
+	}
+}
+
+func dataPointAttributes(md pmetric.Metrics) map[string]map[string]interface{} {
+	attributes := map[string]map[string]interface{}{}
+	metric.RangeMetrics(md, func(m pmetric.Metric) {
+		metric.RangeDataPointAttributes(m, func(attrs pcommon.Map) {
+			attributes[m.Name()] = attrs.AsRaw()
+		})
+	})
+	return attributes
+}
+
+func dataPointExemplars(md pmetric.Metrics) (count int) {
+	metric.RangeMetrics(md, func(m pmetric.Metric) {
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			metric.RangeDataPoints(m.Gauge().DataPoints(), func(dp pmetric.NumberDataPoint) {
+				count += dp.Exemplars().Len()
+			})
+		case pmetric.MetricTypeHistogram:
+			metric.RangeDataPoints(m.Histogram().DataPoints(), func(dp pmetric.HistogramDataPoint) {
+				count += dp.Exemplars().Len()
+			})
+		}
+	})
+	return count
+}
+
+func TestMetricsHandler_Histogram(t *testing.T) {
+	acc := &testutil.Accumulator{}
+	mh := &metricsHandler{acc: acc}
+	d := newDistribution()
+	require.NoError(t, d.AddEntry(1, 1))
+	pmb := PrometheusMetricBatch{
+		{metricName: "request_duration", tags: map[string]string{"job": "test"}, distribution: d},
+		{metricName: "request_duration_count", tags: map[string]string{"job": "test"}, metricValue: 1},
+	}
+	for _, mm := range mergeMetrics(pmb) {
+		mh.addMetricMaterial(mm)
+	}
+	require.Len(t, acc.Metrics, 2)
+	assert.Equal(t, map[string]interface{}{"request_duration_count": 1.0}, acc.Metrics[0].Fields)
+	got, ok := acc.Metrics[1].Fields["request_duration"].(distribution.Distribution)
+	require.True(t, ok)
+	assert.Equal(t, 1.0, got.SampleCount())
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/prometheus/prometheus/model/metadata"
 	"github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/storage"
+
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 )
 
 type PrometheusMetricBatch []*PrometheusMetric
@@ -31,6 +33,15 @@ type PrometheusMetric struct {
 	metricValue             float64
 	metricType              string
 	timeInMS                int64 // Unix time in milli-seconds
+	// nativeHistogram is only set for native histogram samples, which carry
+	// all of their buckets in a single sample instead of metricValue.
+	nativeHistogram *histogram.FloatHistogram
+	// distribution is set once the buckets of a histogram have been converted
+	// and replaces metricValue when the metric is emitted.
+	distribution distribution.Distribution
+	// exemplar is the latest exemplar scraped for the series, which links the
+	// sample to the trace it was recorded in.
+	exemplar *exemplar.Exemplar
 }
 
 func (pm *PrometheusMetric) isValueValid() bool {
@@ -67,6 +78,16 @@ func (mr *metricsReceiver) feed(batch PrometheusMetricBatch) error {
 }
 
 func (ma *metricAppender) Append(ref storage.SeriesRef, ls labels.Labels, t int64, v float64) (storage.SeriesRef, error) {
+	pm, err := newPrometheusMetric(ls, t)
+	if err != nil {
+		return 0, err
+	}
+	pm.metricValue = v
+	ma.batch = append(ma.batch, pm)
+	return 0, nil //return 0 to indicate caching is not supported
+}
+
+func newPrometheusMetric(ls labels.Labels, t int64) (*PrometheusMetric, error) {
 	metricName := ""
 
 	labelMap := make(map[string]string, len(ls))
@@ -81,7 +102,7 @@ func (ma *metricAppender) Append(ref storage.SeriesRef, ls labels.Labels, t int6
 	if metricName == "" {
 		// The error should never happen, print log here for debugging
 		log.Println("E! receive invalid prometheus metric, metricName is missing")
-		return 0, errors.New("metricName of the times-series is missing")
+		return nil, errors.New("metricName of the times-series is missing")
 	}
 
 	pm := &PrometheusMetric{
@@ -89,7 +110,6 @@ func (ma *metricAppender) Append(ref storage.SeriesRef, ls labels.Labels, t int6
 		metricNameBeforeRelabel: ls.Get(savedScrapeNameLabel),
 		jobBeforeRelabel:        ls.Get(savedScrapeJobLabel),
 		instanceBeforeRelabel:   ls.Get(savedScrapeInstanceLabel),
-		metricValue:             v,
 		timeInMS:                t,
 	}
 
@@ -99,8 +119,7 @@ func (ma *metricAppender) Append(ref storage.SeriesRef, ls labels.Labels, t int6
 	delete(labelMap, savedScrapeInstanceLabel)
 
 	pm.tags = labelMap
-	ma.batch = append(ma.batch, pm)
-	return 0, nil //return 0 to indicate caching is not supported
+	return pm, nil
 }
 
 func (ma *metricAppender) Commit() error {
@@ -113,9 +132,24 @@ func (ma *metricAppender) Rollback() error {
 	return nil
 }
 
+// AppendExemplar attaches the exemplar to the sample of the same series. The scrape loop
+// appends exemplars right after their sample, so the batch is searched from the end.
 func (ma *metricAppender) AppendExemplar(ref storage.SeriesRef, l labels.Labels, e exemplar.Exemplar) (storage.SeriesRef, error) {
-	ma.Append(ref, l, e.Ts, e.Value)
-	return 0, nil
+	series, err := newPrometheusMetric(l, e.Ts)
+	if err != nil {
+		return 0, err
+	}
+	seriesKey := getUniqMetricKey(series)
+	for i := len(ma.batch) - 1; i >= 0; i-- {
+		pm := ma.batch[i]
+		if getUniqMetricKey(pm) == seriesKey {
+			// exemplars are appended in order, so the last one is the most recent
+			pm.exemplar = &e
+			return 0, nil
+		}
+	}
+	log.Printf("D! Drop exemplar without a matching sample: %v", series.metricName)
+	return 0, storage.ErrNotFound
 }
 
 func (ma *metricAppender) UpdateMetadata(ref storage.SeriesRef, l labels.Labels, m metadata.Metadata) (storage.SeriesRef, error) {
@@ -123,7 +157,21 @@ func (ma *metricAppender) UpdateMetadata(ref storage.SeriesRef, l labels.Labels,
 	return ref, nil
 }
 
+// AppendHistogram is only called for native histograms, which are scraped when the
+// protobuf exposition format is negotiated. Classic histograms arrive through Append
+// as individual _bucket, _sum and _count samples.
 func (ma *metricAppender) AppendHistogram(ref storage.SeriesRef, l labels.Labels, t int64, h *histogram.Histogram, fh *histogram.FloatHistogram) (storage.SeriesRef, error) {
-	// This code should no longer be used
-	return ref, nil
+	pm, err := newPrometheusMetric(l, t)
+	if err != nil {
+		return 0, err
+	}
+	if fh == nil {
+		if h == nil {
+			return 0, nil
+		}
+		fh = h.ToFloat(nil)
+	}
+	pm.nativeHistogram = fh
+	ma.batch = append(ma.batch, pm)
+	return 0, nil
 }
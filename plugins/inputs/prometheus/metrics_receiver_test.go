@@ -6,6 +6,8 @@ package prometheus
 import (
 	"testing"
 
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/stretchr/testify/assert"
@@ -52,6 +54,74 @@ func Test_metricAppender_Add(t *testing.T) {
 	assert.Equal(t, expected, *mac.batch[0])
 }
 
+func Test_metricAppender_AppendHistogram(t *testing.T) {
+	mr := metricsReceiver{}
+	ma := mr.Appender(nil)
+	var ts int64 = 10
+	ls := []labels.Label{
+		{Name: "__name__", Value: "metric_name"},
+		{Name: "tag_a", Value: "a"},
+	}
+	h := &histogram.Histogram{
+		Count:           3,
+		Sum:             5,
+		PositiveSpans:   []histogram.Span{{Offset: 0, Length: 2}},
+		PositiveBuckets: []int64{1, 1},
+	}
+
+	ref, err := ma.AppendHistogram(0, ls, ts, h, nil)
+	assert.Equal(t, ref, storage.SeriesRef(0))
+	assert.Nil(t, err)
+	mac, _ := ma.(*metricAppender)
+	assert.Equal(t, 1, len(mac.batch))
+
+	expected := PrometheusMetric{
+		metricName:      "metric_name",
+		timeInMS:        ts,
+		tags:            map[string]string{"tag_a": "a"},
+		nativeHistogram: h.ToFloat(nil),
+	}
+	assert.Equal(t, expected, *mac.batch[0])
+}
+
+func Test_metricAppender_AppendExemplar(t *testing.T) {
+	mr := metricsReceiver{}
+	ma := mr.Appender(nil)
+	var ts int64 = 10
+	ls := []labels.Label{
+		{Name: "__name__", Value: "metric_name"},
+		{Name: "tag_a", Value: "a"},
+	}
+	other := []labels.Label{
+		{Name: "__name__", Value: "metric_name"},
+		{Name: "tag_a", Value: "b"},
+	}
+	e := exemplar.Exemplar{
+		Labels: labels.FromStrings("trace_id", "5b8aa5a2d2c872e8321cf37308d69df2"),
+		Value:  2,
+		Ts:     ts,
+		HasTs:  true,
+	}
+
+	_, err := ma.Append(0, ls, ts, 10.0)
+	assert.Nil(t, err)
+	_, err = ma.Append(0, other, ts, 20.0)
+	assert.Nil(t, err)
+	ref, err := ma.AppendExemplar(0, ls, e)
+	assert.Equal(t, ref, storage.SeriesRef(0))
+	assert.Nil(t, err)
+
+	mac, _ := ma.(*metricAppender)
+	// the exemplar is kept with its series instead of being appended as a sample
+	assert.Equal(t, 2, len(mac.batch))
+	assert.Equal(t, &e, mac.batch[0].exemplar)
+	assert.Equal(t, 10.0, mac.batch[0].metricValue)
+	assert.Nil(t, mac.batch[1].exemplar)
+
+	_, err = ma.AppendExemplar(0, labels.FromStrings("__name__", "missing"), e)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
 func Test_metricAppender_isValueStale(t *testing.T) {
 	nonStaleValue := PrometheusMetric{
 		metricValue: 10.0,
@@ -59,7 +59,7 @@ func (p *Prometheus) Start(accIn telegraf.Accumulator) error {
 	p.wg.Add(1)
 	go Start(p.PrometheusConfigPath, receiver, p.shutDownChan, &p.wg, mth)
 
-	// Start filter our prometheus metrics, calculate delta value if its a Counter or Summary count sum
+	// Start filter our prometheus metrics, calculate delta value if its a Counter, Summary count sum or Histogram
 	// and convert Prometheus metrics to Telegraf Metrics
 	p.wg.Add(1)
 	go handler.start(p.shutDownChan, &p.wg)
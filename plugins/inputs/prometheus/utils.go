@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/prometheus/prometheus/model/exemplar"
 )
 
 func getTagsKey(pm *PrometheusMetric) *bytes.Buffer {
@@ -56,7 +58,18 @@ func mergePrometheusMetrics(mm *metricMaterial, pm *PrometheusMetric) *metricMat
 		mm = &metricMaterial{tags: pm.tags, fields: map[string]interface{}{}, timeInMS: pm.timeInMS}
 	}
 
-	mm.fields[pm.metricName] = pm.metricValue
+	if pm.exemplar != nil {
+		if mm.exemplars == nil {
+			mm.exemplars = map[string]*exemplar.Exemplar{}
+		}
+		mm.exemplars[pm.metricName] = pm.exemplar
+	}
+
+	if pm.distribution != nil {
+		mm.fields[pm.metricName] = pm.distribution
+	} else {
+		mm.fields[pm.metricName] = pm.metricValue
+	}
 	return mm
 }
 
@@ -0,0 +1,166 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package psi
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const defaultPressurePath = "/proc/pressure"
+
+// defaultResources are the resources the kernel reports the pressure of. irq is only
+// available on kernels built with CONFIG_IRQ_TIME_ACCOUNTING.
+var defaultResources = []string{"cpu", "memory", "io", "irq"}
+
+// Pressure is a line of a pressure file, e.g.
+// some avg10=0.12 avg60=0.05 avg300=0.01 total=123456
+// The averages are the percentage of wall time that tasks were stalled on the
+// resource and the total is the cumulative stall time in microseconds.
+type Pressure struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+	Total  uint64
+}
+
+// PSI collects the pressure stall information of the host. The averages are added as
+// gauges and the totals as cumulative counters, so that they are converted to deltas by
+// the pipeline.
+type PSI struct {
+	PressurePath string          `toml:"pressure_path"`
+	Resources    []string        `toml:"resources"`
+	Log          telegraf.Logger `toml:"-"`
+}
+
+func (p *PSI) Description() string {
+	return "Read Linux pressure stall information (PSI) of the host"
+}
+
+func (*PSI) SampleConfig() string {
+	return sampleConfig
+}
+
+func (p *PSI) Gather(acc telegraf.Accumulator) error {
+	if _, err := os.Stat(p.PressurePath); err != nil {
+		return fmt.Errorf("pressure stall information is not available, requires Linux 4.20+ with CONFIG_PSI enabled: %w", err)
+	}
+	resources := p.Resources
+	if len(resources) == 0 {
+		resources = defaultResources
+	}
+	gauges := map[string]interface{}{}
+	counters := map[string]interface{}{}
+	for _, resource := range resources {
+		pressures, err := ReadPressureFile(filepath.Join(p.PressurePath, resource))
+		if err != nil {
+			// resources that are not explicitly configured might not be supported by the kernel
+			if errors.Is(err, fs.ErrNotExist) && len(p.Resources) == 0 {
+				continue
+			}
+			acc.AddError(err)
+			continue
+		}
+		AddPressureFields(gauges, resource, pressures)
+		AddPressureTotals(counters, resource, pressures)
+	}
+	now := time.Now()
+	if len(gauges) > 0 {
+		acc.AddGauge("psi", gauges, nil, now)
+	}
+	if len(counters) > 0 {
+		acc.AddCounter("psi", counters, nil, now)
+	}
+	return nil
+}
+
+// ReadPressureFile reads the stall lines, keyed by some or full, of a pressure file.
+func ReadPressureFile(path string) (map[string]Pressure, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	pressures, err := ParsePressure(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	return pressures, nil
+}
+
+// ParsePressure parses the stall lines, keyed by some or full, of a pressure file.
+func ParsePressure(r io.Reader) (map[string]Pressure, error) {
+	pressures := map[string]Pressure{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var p Pressure
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid field %q", field)
+			}
+			var err error
+			switch key {
+			case "avg10":
+				p.Avg10, err = strconv.ParseFloat(value, 64)
+			case "avg60":
+				p.Avg60, err = strconv.ParseFloat(value, 64)
+			case "avg300":
+				p.Avg300, err = strconv.ParseFloat(value, 64)
+			case "total":
+				p.Total, err = strconv.ParseUint(value, 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of %s: %w", key, err)
+			}
+		}
+		pressures[fields[0]] = p
+	}
+	return pressures, scanner.Err()
+}
+
+// AddPressureFields adds the averages of each stall line as <prefix>_<some|full>_<avg>
+// fields.
+func AddPressureFields(fields map[string]interface{}, prefix string, pressures map[string]Pressure) {
+	for kind, p := range pressures {
+		fields[prefix+"_"+kind+"_avg10"] = p.Avg10
+		fields[prefix+"_"+kind+"_avg60"] = p.Avg60
+		fields[prefix+"_"+kind+"_avg300"] = p.Avg300
+	}
+}
+
+// AddPressureTotals adds the total stall time of each stall line as <prefix>_<some|full>_total
+// fields.
+func AddPressureTotals(fields map[string]interface{}, prefix string, pressures map[string]Pressure) {
+	for kind, p := range pressures {
+		fields[prefix+"_"+kind+"_total"] = p.Total
+	}
+}
+
+func init() {
+	inputs.Add("psi", func() telegraf.Input {
+		return &PSI{
+			PressurePath: defaultPressurePath,
+		}
+	})
+}
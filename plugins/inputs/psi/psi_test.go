@@ -0,0 +1,120 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package psi
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGather(t *testing.T) {
+	testCases := map[string]struct {
+		resources    []string
+		wantFields   map[string]interface{}
+		wantCounters map[string]interface{}
+		wantErr      bool
+	}{
+		"Default": {
+			wantFields: map[string]interface{}{
+				"cpu_some_avg10":     1.5,
+				"cpu_some_avg60":     0.75,
+				"cpu_some_avg300":    0.25,
+				"cpu_full_avg10":     0.0,
+				"cpu_full_avg60":     0.0,
+				"cpu_full_avg300":    0.0,
+				"memory_some_avg10":  2.0,
+				"memory_some_avg60":  1.0,
+				"memory_some_avg300": 0.5,
+				"memory_full_avg10":  1.0,
+				"memory_full_avg60":  0.5,
+				"memory_full_avg300": 0.1,
+				"io_some_avg10":      10.25,
+				"io_some_avg60":      5.5,
+				"io_some_avg300":     3.75,
+				"io_full_avg10":      8.0,
+				"io_full_avg60":      4.0,
+				"io_full_avg300":     2.0,
+			},
+			wantCounters: map[string]interface{}{
+				"cpu_some_total":    uint64(123456),
+				"cpu_full_total":    uint64(0),
+				"memory_some_total": uint64(2000),
+				"memory_full_total": uint64(1000),
+				"io_some_total":     uint64(987654),
+				"io_full_total":     uint64(654321),
+			},
+		},
+		"WithResources": {
+			resources: []string{"memory"},
+			wantFields: map[string]interface{}{
+				"memory_some_avg10":  2.0,
+				"memory_some_avg60":  1.0,
+				"memory_some_avg300": 0.5,
+				"memory_full_avg10":  1.0,
+				"memory_full_avg60":  0.5,
+				"memory_full_avg300": 0.1,
+			},
+			wantCounters: map[string]interface{}{
+				"memory_some_total": uint64(2000),
+				"memory_full_total": uint64(1000),
+			},
+		},
+		"WithMissingResource": {
+			resources: []string{"irq"},
+			wantErr:   true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := &PSI{
+				PressurePath: filepath.Join("testdata", "pressure"),
+				Resources:    testCase.resources,
+			}
+			acc := &testutil.Accumulator{}
+			require.NoError(t, p.Gather(acc))
+			if testCase.wantErr {
+				assert.Len(t, acc.Errors, 1)
+				assert.Empty(t, acc.Metrics)
+				return
+			}
+			assert.Empty(t, acc.Errors)
+			require.Len(t, acc.Metrics, 2)
+			for _, m := range acc.Metrics {
+				assert.Equal(t, "psi", m.Measurement)
+				switch m.Type {
+				case telegraf.Gauge:
+					assert.Equal(t, testCase.wantFields, m.Fields)
+				case telegraf.Counter:
+					assert.Equal(t, testCase.wantCounters, m.Fields)
+				default:
+					t.Errorf("unexpected metric type %v", m.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestGather_NotAvailable(t *testing.T) {
+	p := &PSI{PressurePath: filepath.Join("testdata", "missing")}
+	assert.ErrorContains(t, p.Gather(&testutil.Accumulator{}), "CONFIG_PSI")
+}
+
+func TestParsePressure(t *testing.T) {
+	got, err := ParsePressure(strings.NewReader("some avg10=0.12 avg60=0.05 avg300=0.01 total=123456\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Pressure{
+		"some": {Avg10: 0.12, Avg60: 0.05, Avg300: 0.01, Total: 123456},
+	}, got)
+
+	_, err = ParsePressure(strings.NewReader("some avg10=high\n"))
+	assert.Error(t, err)
+	_, err = ParsePressure(strings.NewReader("some avg10\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,209 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package statsd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/eventsrc"
+)
+
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	defaultEventsDestination = "cloudwatchlogs"
+)
+
+var serviceCheckStatuses = []string{"OK", "WARNING", "CRITICAL", "UNKNOWN"}
+
+// EventsConfig is the log group the DogStatsD events and service checks are
+// published to.
+type EventsConfig struct {
+	LogGroupName  string `toml:"log_group_name"`
+	LogStreamName string `toml:"log_stream_name"`
+	LogGroupClass string `toml:"log_group_class"`
+	Destination   string `toml:"destination"`
+	Retention     int    `toml:"retention_in_days"`
+}
+
+// dogStatsDEvent is the structured log event of a DogStatsD event, form is
+// _e{<title length>,<text length>}:<title>|<text>|d:<timestamp>|h:<hostname>|p:<priority>|t:<alert type>|k:<aggregation key>|s:<source type>|#<tags>
+type dogStatsDEvent struct {
+	Type           string            `json:"type"`
+	Title          string            `json:"title"`
+	Text           string            `json:"text"`
+	Timestamp      int64             `json:"timestamp"`
+	Hostname       string            `json:"hostname,omitempty"`
+	Priority       string            `json:"priority"`
+	AlertType      string            `json:"alert_type"`
+	AggregationKey string            `json:"aggregation_key,omitempty"`
+	SourceType     string            `json:"source_type_name,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+}
+
+// serviceCheck is the structured log event of a DogStatsD service check, form is
+// _sc|<name>|<status>|d:<timestamp>|h:<hostname>|#<tags>|m:<message>
+type serviceCheck struct {
+	Type       string            `json:"type"`
+	Name       string            `json:"name"`
+	Status     int               `json:"status"`
+	StatusName string            `json:"status_name"`
+	Timestamp  int64             `json:"timestamp"`
+	Hostname   string            `json:"hostname,omitempty"`
+	Message    string            `json:"message,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+}
+
+// parseEvent parses a DogStatsD event and publishes it as a structured log event.
+func (s *Statsd) parseEvent(line string) error {
+	header, body, ok := strings.Cut(line[len(eventPrefix):], "}:")
+	if !ok {
+		log.Printf("E! Error: Unable to parse statsd event: %s\n", line)
+		return errors.New("Error Parsing statsd event")
+	}
+	titleLen, textLen, err := parseEventLengths(header)
+	if err != nil || titleLen+1+textLen > len(body) || body[titleLen] != '|' {
+		log.Printf("E! Error: Invalid title or text length, Unable to parse statsd event: %s\n", line)
+		return errors.New("Error Parsing statsd event")
+	}
+	e := dogStatsDEvent{
+		Type:      "event",
+		Title:     body[:titleLen],
+		Text:      strings.ReplaceAll(body[titleLen+1:titleLen+1+textLen], "\\n", "\n"),
+		Timestamp: time.Now().Unix(),
+		Priority:  "normal",
+		AlertType: "info",
+	}
+	rest := body[titleLen+1+textLen:]
+	if rest != "" && rest[0] != '|' {
+		log.Printf("E! Error: Invalid text length, Unable to parse statsd event: %s\n", line)
+		return errors.New("Error Parsing statsd event")
+	}
+	for _, field := range strings.Split(rest, "|")[1:] {
+		switch {
+		case strings.HasPrefix(field, "d:"):
+			if e.Timestamp, err = strconv.ParseInt(field[2:], 10, 64); err != nil {
+				log.Printf("E! Error: parsing timestamp, Unable to parse statsd event: %s\n", line)
+				return errors.New("Error Parsing statsd event")
+			}
+		case strings.HasPrefix(field, "h:"):
+			e.Hostname = field[2:]
+		case strings.HasPrefix(field, "p:"):
+			e.Priority = field[2:]
+		case strings.HasPrefix(field, "t:"):
+			e.AlertType = field[2:]
+		case strings.HasPrefix(field, "k:"):
+			e.AggregationKey = field[2:]
+		case strings.HasPrefix(field, "s:"):
+			e.SourceType = field[2:]
+		case strings.HasPrefix(field, "#"):
+			e.Tags = make(map[string]string)
+			parseDataDogTags(field[1:], e.Tags)
+		default:
+			log.Printf("D! Ignoring unknown field %s of statsd event: %s\n", field, line)
+		}
+	}
+	return s.publishEvent(e, e.Timestamp)
+}
+
+// parseEventLengths parses the <title length>,<text length> header of an event.
+func parseEventLengths(header string) (int, int, error) {
+	title, text, ok := strings.Cut(header, ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid event header %s", header)
+	}
+	titleLen, err := strconv.Atoi(title)
+	if err != nil || titleLen < 0 {
+		return 0, 0, fmt.Errorf("invalid event title length %s", title)
+	}
+	textLen, err := strconv.Atoi(text)
+	if err != nil || textLen < 0 {
+		return 0, 0, fmt.Errorf("invalid event text length %s", text)
+	}
+	return titleLen, textLen, nil
+}
+
+// parseServiceCheck parses a DogStatsD service check and publishes it as a structured
+// log event.
+func (s *Statsd) parseServiceCheck(line string) error {
+	fields := strings.Split(line[len(serviceCheckPrefix):], "|")
+	if len(fields) < 2 || fields[0] == "" {
+		log.Printf("E! Error: Unable to parse statsd service check: %s\n", line)
+		return errors.New("Error Parsing statsd service check")
+	}
+	status, err := strconv.Atoi(fields[1])
+	if err != nil || status < 0 || status >= len(serviceCheckStatuses) {
+		log.Printf("E! Error: Invalid status, Unable to parse statsd service check: %s\n", line)
+		return errors.New("Error Parsing statsd service check")
+	}
+	sc := serviceCheck{
+		Type:       "service_check",
+		Name:       fields[0],
+		Status:     status,
+		StatusName: serviceCheckStatuses[status],
+		Timestamp:  time.Now().Unix(),
+	}
+parse:
+	for i, field := range fields[2:] {
+		switch {
+		case strings.HasPrefix(field, "d:"):
+			if sc.Timestamp, err = strconv.ParseInt(field[2:], 10, 64); err != nil {
+				log.Printf("E! Error: parsing timestamp, Unable to parse statsd service check: %s\n", line)
+				return errors.New("Error Parsing statsd service check")
+			}
+		case strings.HasPrefix(field, "h:"):
+			sc.Hostname = field[2:]
+		case strings.HasPrefix(field, "#"):
+			sc.Tags = make(map[string]string)
+			parseDataDogTags(field[1:], sc.Tags)
+		case strings.HasPrefix(field, "m:"):
+			// the message is the last field and may contain pipes
+			sc.Message = strings.Join(fields[2+i:], "|")[2:]
+			break parse
+		default:
+			log.Printf("D! Ignoring unknown field %s of statsd service check: %s\n", field, line)
+		}
+	}
+	return s.publishEvent(sc, sc.Timestamp)
+}
+
+func (s *Statsd) publishEvent(v interface{}, timestamp int64) error {
+	if s.events == nil {
+		log.Printf("D! Dropping statsd event since no events log group is configured")
+		return nil
+	}
+	msg, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if !s.events.Publish(string(msg), time.Unix(timestamp, 0)) {
+		drops := s.events.Drops()
+		if drops == 1 || s.AllowedPendingMessages == 0 || drops%int64(s.AllowedPendingMessages) == 0 {
+			log.Printf("E! Error: statsd event queue full. We have dropped %d events so far.\n", drops)
+		}
+	}
+	return nil
+}
+
+// newEventSrc returns the log source of the DogStatsD events and service checks.
+func newEventSrc(config EventsConfig, bufferSize int) *eventsrc.Src {
+	if config.Destination == "" {
+		config.Destination = defaultEventsDestination
+	}
+	return eventsrc.New(eventsrc.Config{
+		Description: "statsd events",
+		Destination: config.Destination,
+		Group:       config.LogGroupName,
+		Stream:      config.LogStreamName,
+		Class:       config.LogGroupClass,
+		Retention:   config.Retention,
+	}, bufferSize)
+}
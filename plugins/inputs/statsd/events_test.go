@@ -0,0 +1,164 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package statsd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+// newTestStatsdWithEvents returns a statsd with events configured, and the events published
+// by it.
+func newTestStatsdWithEvents(t *testing.T) (*Statsd, chan logs.LogEvent) {
+	s := NewTestStatsd()
+	s.events = newEventSrc(EventsConfig{LogGroupName: "statsd-events"}, 10)
+	received := make(chan logs.LogEvent, 10)
+	s.events.SetOutput(func(e logs.LogEvent) {
+		if e != nil {
+			received <- e
+		}
+	})
+	t.Cleanup(s.events.Stop)
+	return s, received
+}
+
+func nextEvent(t *testing.T, received <-chan logs.LogEvent) logs.LogEvent {
+	select {
+	case e := <-received:
+		return e
+	case <-time.After(time.Second):
+		require.Fail(t, "no event published")
+		return nil
+	}
+}
+
+func TestParse_Events(t *testing.T) {
+	testCases := map[string]struct {
+		line    string
+		want    string
+		wantErr bool
+	}{
+		"Minimal": {
+			line: "_e{5,4}:title|text|d:1700000000",
+			want: `{"type":"event","title":"title","text":"text","timestamp":1700000000,"priority":"normal","alert_type":"info"}`,
+		},
+		"AllFields": {
+			line: "_e{10,12}:disk|full!|line1\\nline2|d:1700000000|h:web-1|p:low|t:error|k:disk|s:nagios|#env:prod,oncall",
+			want: `{"type":"event","title":"disk|full!","text":"line1\nline2","timestamp":1700000000,"hostname":"web-1","priority":"low","alert_type":"error","aggregation_key":"disk","source_type_name":"nagios","tags":{"env":"prod","oncall":"<empty>"}}`,
+		},
+		"TitleLengthTooLong": {
+			line:    "_e{50,4}:title|text",
+			wantErr: true,
+		},
+		"TextLengthTooShort": {
+			line:    "_e{5,2}:title|text",
+			wantErr: true,
+		},
+		"InvalidHeader": {
+			line:    "_e{5}:title|text",
+			wantErr: true,
+		},
+		"InvalidTimestamp": {
+			line:    "_e{5,4}:title|text|d:yesterday",
+			wantErr: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s, received := newTestStatsdWithEvents(t)
+			err := s.parseStatsdLine(testCase.line)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, received)
+				return
+			}
+			require.NoError(t, err)
+			e := nextEvent(t, received)
+			assert.JSONEq(t, testCase.want, e.Message())
+			assert.Equal(t, time.Unix(1700000000, 0), e.Time())
+		})
+	}
+}
+
+func TestParse_ServiceChecks(t *testing.T) {
+	testCases := map[string]struct {
+		line    string
+		want    string
+		wantErr bool
+	}{
+		"Minimal": {
+			line: "_sc|app.health|0|d:1700000000",
+			want: `{"type":"service_check","name":"app.health","status":0,"status_name":"OK","timestamp":1700000000}`,
+		},
+		"AllFields": {
+			line: "_sc|app.health|2|d:1700000000|h:web-1|#env:prod|m:connection refused | retrying",
+			want: `{"type":"service_check","name":"app.health","status":2,"status_name":"CRITICAL","timestamp":1700000000,"hostname":"web-1","message":"connection refused | retrying","tags":{"env":"prod"}}`,
+		},
+		"InvalidStatus": {
+			line:    "_sc|app.health|4",
+			wantErr: true,
+		},
+		"MissingStatus": {
+			line:    "_sc|app.health",
+			wantErr: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s, received := newTestStatsdWithEvents(t)
+			err := s.parseStatsdLine(testCase.line)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, received)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, testCase.want, nextEvent(t, received).Message())
+		})
+	}
+}
+
+func TestParse_EventsNotConfigured(t *testing.T) {
+	s := NewTestStatsd()
+	assert.NoError(t, s.parseStatsdLine("_sc|app.health|0"))
+	assert.Nil(t, s.FindLogSrc())
+}
+
+func TestEventSrc(t *testing.T) {
+	s := NewTestStatsd()
+	s.events = newEventSrc(EventsConfig{LogGroupName: "statsd-events"}, 10)
+	srcs := s.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Empty(t, s.FindLogSrc(), "the source is only returned once")
+
+	src := srcs[0]
+	assert.Equal(t, "statsd-events", src.Group())
+	assert.Equal(t, "", src.Stream())
+	assert.Equal(t, "cloudwatchlogs", src.Destination())
+	assert.Equal(t, "statsd events", src.Description())
+
+	require.NoError(t, s.parseStatsdLine("_sc|app.health|1"))
+	received := make(chan logs.LogEvent, 2)
+	src.SetOutput(func(e logs.LogEvent) {
+		received <- e
+	})
+	select {
+	case e := <-received:
+		assert.Contains(t, e.Message(), `"status_name":"WARNING"`)
+	case <-time.After(time.Second):
+		require.Fail(t, "event was not published")
+	}
+	src.Stop()
+	select {
+	case e := <-received:
+		assert.Nil(t, e, "the output is closed when the source stops")
+	case <-time.After(time.Second):
+		require.Fail(t, "output was not closed")
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package statsd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"sync"
+)
+
+const schemeSeparator = "://"
+
+// listener receives statsd packets and hands a copy of each of them to the handler.
+type listener interface {
+	Serve(handler func([]byte))
+	Addr() string
+	Close() error
+}
+
+// newListener creates the listener for the service address. The scheme of the address
+// selects the network, e.g. tcp://:8125 or unixgram:///var/run/statsd.sock, and
+// addresses without a scheme are served over UDP.
+func newListener(serviceAddress string) (listener, error) {
+	network, address := "udp", serviceAddress
+	if i := strings.Index(serviceAddress, schemeSeparator); i >= 0 {
+		network, address = serviceAddress[:i], serviceAddress[i+len(schemeSeparator):]
+	}
+	switch network {
+	case "udp", "udp4", "udp6", "unixgram":
+		if network == "unixgram" {
+			if err := removeStaleSocket(address); err != nil {
+				return nil, err
+			}
+		}
+		conn, err := net.ListenPacket(network, address)
+		if err != nil {
+			return nil, fmt.Errorf("unable to listen on %s: %w", serviceAddress, err)
+		}
+		return &packetListener{conn: conn, network: network}, nil
+	case "tcp", "tcp4", "tcp6", "unix":
+		if network == "unix" {
+			if err := removeStaleSocket(address); err != nil {
+				return nil, err
+			}
+		}
+		l, err := net.Listen(network, address)
+		if err != nil {
+			return nil, fmt.Errorf("unable to listen on %s: %w", serviceAddress, err)
+		}
+		return &streamListener{listener: l, conns: map[net.Conn]struct{}{}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported network %q in statsd service address %s", network, serviceAddress)
+	}
+}
+
+// removeStaleSocket removes the socket file left behind by a previous run, since
+// listening on an existing path fails.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("statsd socket path %s exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}
+
+// packetListener serves datagrams over UDP or a Unix domain socket.
+type packetListener struct {
+	conn    net.PacketConn
+	network string
+}
+
+func (l *packetListener) Serve(handler func([]byte)) {
+	buf := make([]byte, UDP_MAX_PACKET_SIZE)
+	for {
+		n, _, err := l.conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			log.Printf("E! Error READ: %s\n", err.Error())
+			continue
+		}
+		bufCopy := make([]byte, n)
+		copy(bufCopy, buf[:n])
+		handler(bufCopy)
+	}
+}
+
+func (l *packetListener) Addr() string {
+	return l.conn.LocalAddr().String()
+}
+
+func (l *packetListener) Close() error {
+	err := l.conn.Close()
+	if l.network == "unixgram" {
+		// unlike stream listeners, datagram sockets do not remove their file on close
+		os.Remove(l.conn.LocalAddr().String())
+	}
+	return err
+}
+
+// streamListener serves newline delimited statsd lines over TCP or a Unix domain
+// socket.
+type streamListener struct {
+	listener net.Listener
+
+	mu    sync.Mutex
+	wg    sync.WaitGroup
+	conns map[net.Conn]struct{}
+}
+
+func (l *streamListener) Serve(handler func([]byte)) {
+	defer l.wg.Wait()
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			log.Printf("E! Error ACCEPT: %s\n", err.Error())
+			continue
+		}
+		if !l.track(conn) {
+			conn.Close()
+			return
+		}
+		l.wg.Add(1)
+		go func() {
+			defer l.wg.Done()
+			defer l.untrack(conn)
+			l.read(conn, handler)
+		}()
+	}
+}
+
+func (l *streamListener) read(conn net.Conn, handler func([]byte)) {
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, UDP_MAX_PACKET_SIZE), UDP_MAX_PACKET_SIZE)
+	for scanner.Scan() {
+		line := make([]byte, len(scanner.Bytes()))
+		copy(line, scanner.Bytes())
+		handler(line)
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		log.Printf("E! Error READ: %s\n", err.Error())
+	}
+}
+
+// track the connection so that it is closed with the listener. Returns false if the
+// listener is already closed.
+func (l *streamListener) track(conn net.Conn) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conns == nil {
+		return false
+	}
+	l.conns[conn] = struct{}{}
+	return true
+}
+
+func (l *streamListener) untrack(conn net.Conn) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	conn.Close()
+	delete(l.conns, conn)
+}
+
+func (l *streamListener) Addr() string {
+	return l.listener.Addr().String()
+}
+
+func (l *streamListener) Close() error {
+	err := l.listener.Close()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for conn := range l.conns {
+		conn.Close()
+	}
+	l.conns = nil
+	return err
+}
@@ -0,0 +1,81 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package statsd
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListeners(t *testing.T) {
+	dir := t.TempDir()
+	testCases := map[string]struct {
+		serviceAddress string
+		network        string
+	}{
+		"UDP":          {serviceAddress: "127.0.0.1:0", network: "udp"},
+		"UDPScheme":    {serviceAddress: "udp://127.0.0.1:0", network: "udp"},
+		"TCP":          {serviceAddress: "tcp://127.0.0.1:0", network: "tcp"},
+		"UnixStream":   {serviceAddress: "unix://" + filepath.Join(dir, "stream.sock"), network: "unix"},
+		"UnixDatagram": {serviceAddress: "unixgram://" + filepath.Join(dir, "datagram.sock"), network: "unixgram"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := NewTestStatsd()
+			s.ServiceAddress = testCase.serviceAddress
+			s.AllowedPendingMessages = 10
+			require.NoError(t, s.Start(nil))
+			// a second start, e.g. from the log agent, is a no-op
+			require.NoError(t, s.Start(nil))
+
+			conn, err := net.Dial(testCase.network, s.listener.Addr())
+			require.NoError(t, err)
+			_, err = conn.Write([]byte("test.counter:1|c\ntest.counter:2|c\n"))
+			require.NoError(t, err)
+			require.NoError(t, conn.Close())
+
+			acc := &testutil.Accumulator{}
+			assert.Eventually(t, func() bool {
+				acc.ClearMetrics()
+				require.NoError(t, s.Gather(acc))
+				value, ok := acc.Get("test_counter")
+				return ok && value.Fields["value"] == int64(3)
+			}, 5*time.Second, 10*time.Millisecond)
+			s.Stop()
+		})
+	}
+}
+
+func TestListeners_UnixSocketCleanup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statsd.sock")
+	for _, network := range []string{"unix", "unixgram"} {
+		t.Run(network, func(t *testing.T) {
+			// a socket left behind by a previous run is replaced
+			stale, err := newListener(network + "://" + path)
+			require.NoError(t, err)
+			l, err := newListener(network + "://" + path)
+			require.NoError(t, err)
+			stale.Close()
+			l.Close()
+			_, err = os.Stat(path)
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+	_, err := newListener("unix://" + path)
+	assert.ErrorContains(t, err, "is not a socket")
+}
+
+func TestListeners_InvalidAddress(t *testing.T) {
+	_, err := newListener("http://localhost:8125")
+	assert.ErrorContains(t, err, "unsupported network")
+}
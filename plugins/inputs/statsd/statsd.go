@@ -7,17 +7,19 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"net"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	//"github.com/influxdata/telegraf/plugins/parsers/graphite"
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/inputs"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/eventsrc"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/statsd/graphite"
 )
@@ -38,7 +40,9 @@ var dropwarn = "E! Error: statsd message queue full. " +
 	"You may want to increase allowed_pending_messages in the config\n"
 
 type Statsd struct {
-	// Address & Port to serve from
+	// Address & Port to serve from. A scheme selects the listener, e.g.
+	// tcp://:8125, unix:///var/run/statsd.sock or unixgram:///var/run/statsd.sock,
+	// and addresses without one are served over UDP.
 	ServiceAddress string
 
 	// Number of messages allowed to queue up in between calls to Gather. If this
@@ -65,7 +69,7 @@ type Statsd struct {
 	sync.Mutex
 	wg sync.WaitGroup
 	// drops tracks the number of dropped metrics.
-	drops int
+	drops atomic.Int64
 
 	// Channel for all incoming statsd packets
 	in   chan []byte
@@ -82,7 +86,14 @@ type Statsd struct {
 	// bucket -> influx templates
 	Templates []string
 
-	listener *net.UDPConn
+	// Events configures where DogStatsD events and service checks are published.
+	Events EventsConfig `toml:"events"`
+
+	started  bool
+	listener listener
+	events   *eventsrc.Src
+	// eventsFound is set once the events source has been returned to the log agent.
+	eventsFound bool
 
 	graphiteParser *graphite.GraphiteParser
 }
@@ -131,7 +142,8 @@ func (_ *Statsd) Description() string {
 }
 
 const sampleConfig = `
-  ## Address and port to host UDP listener on
+  ## Address and port to host UDP listener on. Prefix the address with
+  ## tcp://, unix:// or unixgram:// to listen on TCP or a Unix domain socket
   service_address = ":8125"
 
   ## The following configuration options control when telegraf clears it's cache
@@ -166,6 +178,10 @@ const sampleConfig = `
   ## The aggregation interval for the metrics
   metric_aggregation_interval = "60s"
 
+  ## DogStatsD events and service checks are published to this log group
+  # [inputs.statsd.events]
+  #   log_group_name = "statsd-events"
+  #   log_stream_name = "{instance_id}"
 `
 
 func (_ *Statsd) SampleConfig() string {
@@ -212,7 +228,15 @@ func (s *Statsd) Gather(acc telegraf.Accumulator) error {
 	return nil
 }
 
+// Start is called by both the metrics pipeline and the log agent, since the plugin is
+// also a log collection, so only the first call starts the service.
 func (s *Statsd) Start(_ telegraf.Accumulator) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.started {
+		return nil
+	}
+
 	// Make data structures
 	s.done = make(chan struct{})
 	s.in = make(chan []byte, s.AllowedPendingMessages)
@@ -225,49 +249,51 @@ func (s *Statsd) Start(_ telegraf.Accumulator) error {
 	if s.MetricSeparator == "" {
 		s.MetricSeparator = defaultSeparator
 	}
+	if s.Events.LogGroupName != "" {
+		s.events = newEventSrc(s.Events, s.AllowedPendingMessages)
+		s.eventsFound = false
+	}
+
+	l, err := newListener(s.ServiceAddress)
+	if err != nil {
+		return err
+	}
+	s.listener = l
+	log.Println("I! Statsd listener listening on: ", s.listener.Addr())
 
 	s.wg.Add(2)
-	// Start the UDP listener
-	go s.udpListen()
+	// Start the listener
+	go func() {
+		defer s.wg.Done()
+		s.listener.Serve(s.enqueue)
+	}()
 	// Start the line parser
 	go s.parser()
+	s.started = true
 	log.Printf("I! Started the statsd service on %s\n", s.ServiceAddress)
 	return nil
 }
 
-// udpListen starts listening for udp packets on the configured port.
-func (s *Statsd) udpListen() error {
-	defer s.wg.Done()
-	var err error
-	address, _ := net.ResolveUDPAddr("udp", s.ServiceAddress)
-	s.listener, err = net.ListenUDP("udp", address)
-	if err != nil {
-		log.Fatalf("ERROR: ListenUDP - %s", err)
+// FindLogSrc returns the source of the DogStatsD events and service checks once, the
+// first time it is called after the service is started.
+func (s *Statsd) FindLogSrc() []logs.LogSrc {
+	s.Lock()
+	defer s.Unlock()
+	if s.events == nil || s.eventsFound {
+		return nil
 	}
-	log.Println("I! Statsd listener listening on: ", s.listener.LocalAddr().String())
+	s.eventsFound = true
+	return []logs.LogSrc{s.events}
+}
 
-	buf := make([]byte, UDP_MAX_PACKET_SIZE)
-	for {
-		select {
-		case <-s.done:
-			return nil
-		default:
-			n, _, err := s.listener.ReadFromUDP(buf)
-			if err != nil && !strings.Contains(err.Error(), "closed network") {
-				log.Printf("E! Error READ: %s\n", err.Error())
-				continue
-			}
-			bufCopy := make([]byte, n)
-			copy(bufCopy, buf[:n])
-
-			select {
-			case s.in <- bufCopy:
-			default:
-				s.drops++
-				if s.drops == 1 || s.AllowedPendingMessages == 0 || s.drops%s.AllowedPendingMessages == 0 {
-					log.Printf(dropwarn, s.drops)
-				}
-			}
+// enqueue queues a packet for the parser, dropping it when the queue is full.
+func (s *Statsd) enqueue(packet []byte) {
+	select {
+	case s.in <- packet:
+	default:
+		drops := s.drops.Add(1)
+		if drops == 1 || s.AllowedPendingMessages == 0 || drops%int64(s.AllowedPendingMessages) == 0 {
+			log.Printf(dropwarn, drops)
 		}
 	}
 }
@@ -297,6 +323,12 @@ func (s *Statsd) parser() error {
 // parseStatsdLine will parse the given statsd line, validating it as it goes.
 // If the line is valid, it will be cached for the next call to Gather()
 func (s *Statsd) parseStatsdLine(line string) error {
+	if strings.HasPrefix(line, eventPrefix) {
+		return s.parseEvent(line)
+	}
+	if strings.HasPrefix(line, serviceCheckPrefix) {
+		return s.parseServiceCheck(line)
+	}
 
 	lineTags := make(map[string]string)
 	if s.ParseDataDogTags {
@@ -310,24 +342,7 @@ func (s *Statsd) parseStatsdLine(line string) error {
 		for _, segment := range pipesplit {
 			if len(segment) > 0 && segment[0] == '#' {
 				// we have ourselves a tag; they are comma separated
-				tagstr := segment[1:]
-				tags := strings.Split(tagstr, ",")
-				for _, tag := range tags {
-					ts := strings.SplitN(tag, ":", 2)
-					var k, v string
-					switch len(ts) {
-					case 1:
-						// just a tag
-						k = ts[0]
-						v = "<empty>" //cloudwatch does not allow empty string
-					case 2:
-						k = ts[0]
-						v = ts[1]
-					}
-					if k != "" {
-						lineTags[k] = v
-					}
-				}
+				parseDataDogTags(segment[1:], lineTags)
 			} else {
 				recombinedSegments = append(recombinedSegments, segment)
 			}
@@ -375,7 +390,7 @@ func (s *Statsd) parseStatsdLine(line string) error {
 
 		// Validate metric type
 		switch pipesplit[1] {
-		case "g", "c", "s", "ms", "h":
+		case "g", "c", "s", "ms", "h", "d":
 			m.mtype = pipesplit[1]
 		default:
 			log.Printf("E! Error: Statsd Metric type %s unsupported", pipesplit[1])
@@ -392,7 +407,7 @@ func (s *Statsd) parseStatsdLine(line string) error {
 		}
 
 		switch m.mtype {
-		case "g", "ms", "h":
+		case "g", "ms", "h", "d":
 			v, err := strconv.ParseFloat(pipesplit[0], 64)
 			if err != nil {
 				log.Printf("E! Error: parsing value to float64: %s\n", line)
@@ -432,6 +447,8 @@ func (s *Statsd) parseStatsdLine(line string) error {
 			m.tags["metric_type"] = "timing"
 		case "h":
 			m.tags["metric_type"] = "histogram"
+		case "d":
+			m.tags["metric_type"] = "distribution"
 		}
 
 		if len(lineTags) > 0 {
@@ -454,6 +471,27 @@ func (s *Statsd) parseStatsdLine(line string) error {
 	return nil
 }
 
+// parseDataDogTags adds the comma separated DogStatsD tags, e.g.
+// country:china,environment:production, to the given tags.
+func parseDataDogTags(tagstr string, tags map[string]string) {
+	for _, tag := range strings.Split(tagstr, ",") {
+		ts := strings.SplitN(tag, ":", 2)
+		var k, v string
+		switch len(ts) {
+		case 1:
+			// just a tag
+			k = ts[0]
+			v = "<empty>" //cloudwatch does not allow empty string
+		case 2:
+			k = ts[0]
+			v = ts[1]
+		}
+		if k != "" {
+			tags[k] = v
+		}
+	}
+}
+
 // parseName parses the given bucket name with the list of bucket maps in the
 // config file. If there is a match, it will parse the name of the metric and
 // map of tags.
@@ -519,7 +557,7 @@ func (s *Statsd) aggregate(m metric) {
 	defer s.Unlock()
 
 	switch m.mtype {
-	case "ms", "h":
+	case "ms", "h", "d":
 		// Check if the measurement exists
 		cached, ok := s.timings[m.hash]
 		if !ok {
@@ -604,11 +642,21 @@ func (s *Statsd) aggregate(m metric) {
 }
 
 func (s *Statsd) Stop() {
+	s.Lock()
+	if !s.started {
+		s.Unlock()
+		return
+	}
+	s.started = false
+	s.Unlock()
 	log.Println("D! Stopping the statsd service")
 	close(s.done)
 	s.listener.Close()
 	s.wg.Wait()
 	close(s.in)
+	if s.events != nil {
+		s.events.Stop()
+	}
 	log.Println("D! Stopped the statsd service")
 }
 
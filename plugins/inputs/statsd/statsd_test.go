@@ -41,6 +41,7 @@ func TestParse_ValidLines(t *testing.T) {
 		"valid:45|g",
 		"valid.timer:45|ms",
 		"valid.timer:45|h",
+		"valid.timer:45|d",
 	}
 
 	for _, line := range valid_lines {
@@ -293,6 +294,36 @@ func TestParse_Timings(t *testing.T) {
 	assert.Equal(t, dist, fields[defaultFieldName])
 }
 
+func TestParse_Distributions(t *testing.T) {
+	s := NewTestStatsd()
+	acc := &testutil.Accumulator{}
+
+	valid_lines := []string{
+		"test.distribution:1|d",
+		"test.distribution:11|d|@0.5",
+	}
+
+	for _, line := range valid_lines {
+		err := s.parseStatsdLine(line)
+		if err != nil {
+			t.Errorf("Parsing line %s should not have resulted in an error\n", line)
+		}
+	}
+
+	s.Gather(acc)
+
+	dist := distribution.NewDistribution()
+	assert.NoError(t, dist.AddEntry(1, 1))
+	assert.NoError(t, dist.AddEntry(11, 2))
+
+	metrics := acc.Metrics
+	assert.Equal(t, 1, len(metrics))
+	metric := metrics[0]
+	assert.Equal(t, "test_distribution", metric.Measurement)
+	assert.Equal(t, "distribution", metric.Tags["metric_type"])
+	assert.Equal(t, dist, metric.Fields[defaultFieldName])
+}
+
 func TestParseScientificNotation(t *testing.T) {
 	s := NewTestStatsd()
 	sciNotationLines := []string{
@@ -0,0 +1,85 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package systemd_units
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+)
+
+// unitState is the state of a systemd unit.
+type unitState struct {
+	Name        string
+	ActiveState string
+	SubState    string
+	// NRestarts is only set for services.
+	NRestarts *uint32
+	// StateChangeTimestamp is zero if the unit never changed state.
+	StateChangeTimestamp time.Time
+}
+
+// backend queries the state of the systemd units. The plugin uses D-Bus and the tests
+// inject a fake.
+type backend interface {
+	ListUnits(ctx context.Context, patterns []string) ([]unitState, error)
+	Close()
+}
+
+// dbusBackend queries systemd over the system D-Bus. The connection is opened on the
+// first query and reopened if it is lost, e.g. when D-Bus restarts.
+type dbusBackend struct {
+	conn *dbus.Conn
+}
+
+var _ backend = (*dbusBackend)(nil)
+
+func (b *dbusBackend) ListUnits(ctx context.Context, patterns []string) ([]unitState, error) {
+	if b.conn == nil || !b.conn.Connected() {
+		b.Close()
+		conn, err := dbus.NewSystemConnectionContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to systemd: %w", err)
+		}
+		b.conn = conn
+	}
+	units, err := b.conn.ListUnitsByPatternsContext(ctx, nil, patterns)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list systemd units: %w", err)
+	}
+	states := make([]unitState, 0, len(units))
+	for _, unit := range units {
+		state := unitState{
+			Name:        unit.Name,
+			ActiveState: unit.ActiveState,
+			SubState:    unit.SubState,
+		}
+		if p, err := b.conn.GetUnitPropertyContext(ctx, unit.Name, "StateChangeTimestamp"); err == nil {
+			// microseconds since the epoch
+			if usec, ok := p.Value.Value().(uint64); ok && usec > 0 {
+				state.StateChangeTimestamp = time.UnixMicro(int64(usec))
+			}
+		}
+		if strings.HasSuffix(unit.Name, ".service") {
+			if p, err := b.conn.GetUnitTypePropertyContext(ctx, unit.Name, "Service", "NRestarts"); err == nil {
+				// not available before systemd 235
+				if restarts, ok := p.Value.Value().(uint32); ok {
+					state.NRestarts = &restarts
+				}
+			}
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+func (b *dbusBackend) Close() {
+	if b.conn != nil {
+		b.conn.Close()
+		b.conn = nil
+	}
+}
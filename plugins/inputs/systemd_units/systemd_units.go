@@ -0,0 +1,197 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package systemd_units
+
+import (
+	"context"
+	_ "embed"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement = "systemd_units"
+	unitTag     = "Unit"
+
+	defaultTimeout = 5 * time.Second
+)
+
+var defaultUnits = []string{"*.service"}
+
+// activeStates and subStates map the systemd states to the values reported, as
+// defined in https://github.com/systemd/systemd/blob/main/src/basic/unit-def.c
+var activeStates = map[string]int{
+	"active":       0,
+	"reloading":    1,
+	"inactive":     2,
+	"failed":       3,
+	"activating":   4,
+	"deactivating": 5,
+}
+
+var subStates = map[string]int{
+	// service states
+	"running":       0x0000,
+	"dead":          0x0001,
+	"start-pre":     0x0002,
+	"start":         0x0003,
+	"exited":        0x0004,
+	"reload":        0x0005,
+	"stop":          0x0006,
+	"stop-watchdog": 0x0007,
+	"stop-sigterm":  0x0008,
+	"stop-sigkill":  0x0009,
+	"stop-post":     0x000a,
+	"final-sigterm": 0x000b,
+	"failed":        0x000c,
+	"auto-restart":  0x000d,
+	// automount states
+	"waiting": 0x0010,
+	// device states
+	"tentative": 0x0020,
+	"plugged":   0x0021,
+	// mount states
+	"mounting":           0x0030,
+	"mounting-done":      0x0031,
+	"mounted":            0x0032,
+	"remounting":         0x0033,
+	"unmounting":         0x0034,
+	"remounting-sigterm": 0x0035,
+	"remounting-sigkill": 0x0036,
+	"unmounting-sigterm": 0x0037,
+	"unmounting-sigkill": 0x0038,
+	// scope states
+	"abandoned": 0x0050,
+	// slice states
+	"active": 0x0060,
+	// socket states
+	"start-chown":      0x0070,
+	"start-post":       0x0071,
+	"listening":        0x0072,
+	"stop-pre":         0x0073,
+	"stop-pre-sigterm": 0x0074,
+	"stop-pre-sigkill": 0x0075,
+	"final-sigkill":    0x0076,
+	// swap states
+	"activating":           0x0080,
+	"activating-done":      0x0081,
+	"deactivating":         0x0082,
+	"deactivating-sigterm": 0x0083,
+	"deactivating-sigkill": 0x0084,
+	// timer states
+	"elapsed": 0x00a0,
+}
+
+// SystemdUnits reports the state of the systemd units matching the configured
+// patterns.
+type SystemdUnits struct {
+	Units   []string        `toml:"units"`
+	Timeout config.Duration `toml:"timeout"`
+	Log     telegraf.Logger `toml:"-"`
+
+	mu      sync.Mutex
+	backend backend
+	now     func() time.Time
+}
+
+var _ telegraf.ServiceInput = (*SystemdUnits)(nil)
+
+func (s *SystemdUnits) Description() string {
+	return "Read the state of systemd units"
+}
+
+func (*SystemdUnits) SampleConfig() string {
+	return sampleConfig
+}
+
+func (s *SystemdUnits) Start(telegraf.Accumulator) error {
+	return nil
+}
+
+func (s *SystemdUnits) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.backend.Close()
+}
+
+func (s *SystemdUnits) Gather(acc telegraf.Accumulator) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	patterns := s.Units
+	if len(patterns) == 0 {
+		patterns = defaultUnits
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.Timeout))
+	defer cancel()
+	units, err := s.backend.ListUnits(ctx, patterns)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	found := map[string]bool{}
+	for _, unit := range units {
+		found[unit.Name] = true
+		fields := map[string]interface{}{
+			"active":       boolToInt(unit.ActiveState == "active"),
+			"active_state": stateCode(activeStates, unit.ActiveState),
+			"sub_state":    stateCode(subStates, unit.SubState),
+		}
+		if unit.NRestarts != nil {
+			fields["restarts"] = *unit.NRestarts
+		}
+		if !unit.StateChangeTimestamp.IsZero() {
+			fields["time_since_state_change"] = now.Sub(unit.StateChangeTimestamp).Seconds()
+		}
+		acc.AddGauge(measurement, fields, map[string]string{unitTag: unit.Name}, now)
+	}
+	// systemd only lists loaded units, so a stopped unit that nothing depends on is
+	// not listed. Report the units configured by name as inactive instead of missing.
+	for _, pattern := range patterns {
+		if found[pattern] || strings.ContainsAny(pattern, "*?[") {
+			continue
+		}
+		acc.AddGauge(measurement, map[string]interface{}{
+			"active":       0,
+			"active_state": activeStates["inactive"],
+			"sub_state":    subStates["dead"],
+		}, map[string]string{unitTag: pattern}, now)
+	}
+	return nil
+}
+
+func stateCode(states map[string]int, state string) int {
+	if code, ok := states[state]; ok {
+		return code
+	}
+	return -1
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func newSystemdUnits() *SystemdUnits {
+	return &SystemdUnits{
+		Timeout: config.Duration(defaultTimeout),
+		backend: &dbusBackend{},
+		now:     time.Now,
+	}
+}
+
+func init() {
+	inputs.Add("systemd_units", func() telegraf.Input {
+		return newSystemdUnits()
+	})
+}
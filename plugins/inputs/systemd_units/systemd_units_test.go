@@ -0,0 +1,128 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package systemd_units
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBackend struct {
+	units    []unitState
+	err      error
+	patterns []string
+	closed   bool
+}
+
+func (b *fakeBackend) ListUnits(_ context.Context, patterns []string) ([]unitState, error) {
+	b.patterns = patterns
+	return b.units, b.err
+}
+
+func (b *fakeBackend) Close() {
+	b.closed = true
+}
+
+func newTestSystemdUnits(b backend, units []string) *SystemdUnits {
+	s := newSystemdUnits()
+	s.Units = units
+	s.backend = b
+	s.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return s
+}
+
+func TestGather(t *testing.T) {
+	restarts := uint32(3)
+	b := &fakeBackend{units: []unitState{
+		{
+			Name:                 "nginx.service",
+			ActiveState:          "active",
+			SubState:             "running",
+			NRestarts:            &restarts,
+			StateChangeTimestamp: time.Unix(1700000000-90, 0),
+		},
+		{
+			Name:                 "backup.service",
+			ActiveState:          "failed",
+			SubState:             "failed",
+			StateChangeTimestamp: time.Unix(1700000000-3600, 0),
+		},
+		{
+			Name:        "docker.socket",
+			ActiveState: "active",
+			SubState:    "listening",
+		},
+		{
+			Name:        "custom.service",
+			ActiveState: "active",
+			SubState:    "unknown-state",
+		},
+	}}
+	s := newTestSystemdUnits(b, []string{"*.service", "docker.socket", "redis.service"})
+	acc := &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	assert.Equal(t, []string{"*.service", "docker.socket", "redis.service"}, b.patterns)
+	assert.Len(t, acc.Metrics, 5)
+
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"active":                  1,
+		"active_state":            0,
+		"sub_state":               0,
+		"restarts":                uint32(3),
+		"time_since_state_change": 90.0,
+	}, map[string]string{unitTag: "nginx.service"})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"active":                  0,
+		"active_state":            3,
+		"sub_state":               0x000c,
+		"time_since_state_change": 3600.0,
+	}, map[string]string{unitTag: "backup.service"})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"active":       1,
+		"active_state": 0,
+		"sub_state":    0x0072,
+	}, map[string]string{unitTag: "docker.socket"})
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"active":       1,
+		"active_state": 0,
+		"sub_state":    -1,
+	}, map[string]string{unitTag: "custom.service"})
+	// not loaded by systemd
+	acc.AssertContainsTaggedFields(t, measurement, map[string]interface{}{
+		"active":       0,
+		"active_state": 2,
+		"sub_state":    1,
+	}, map[string]string{unitTag: "redis.service"})
+}
+
+func TestGather_DefaultUnits(t *testing.T) {
+	b := &fakeBackend{}
+	s := newTestSystemdUnits(b, nil)
+	acc := &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	assert.Equal(t, []string{"*.service"}, b.patterns)
+	assert.Empty(t, acc.Metrics)
+}
+
+func TestGather_Error(t *testing.T) {
+	b := &fakeBackend{err: errors.New("unable to connect to systemd")}
+	s := newTestSystemdUnits(b, []string{"nginx.service"})
+	acc := &testutil.Accumulator{}
+	assert.ErrorContains(t, s.Gather(acc), "unable to connect to systemd")
+	assert.Empty(t, acc.Metrics)
+}
+
+func TestStop(t *testing.T) {
+	b := &fakeBackend{}
+	s := newTestSystemdUnits(b, nil)
+	require.NoError(t, s.Start(nil))
+	s.Stop()
+	assert.True(t, b.closed)
+}
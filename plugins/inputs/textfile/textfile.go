@@ -0,0 +1,162 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package textfile
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/textparse"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement = "textfile"
+	filePattern = "*.prom"
+	fileTag     = "file"
+	// valueField makes the name of the metric the name of the series.
+	valueField = "value"
+
+	defaultMaxAge = time.Hour
+)
+
+// Textfile reads the metrics that other processes write to *.prom files in a directory
+// in the Prometheus text format, like the textfile collector of the node exporter.
+type Textfile struct {
+	Directory string          `toml:"directory"`
+	MaxAge    config.Duration `toml:"max_age"`
+	Log       telegraf.Logger `toml:"-"`
+
+	now func() time.Time
+}
+
+func (t *Textfile) Description() string {
+	return "Read the metrics in the Prometheus text format from the *.prom files in a directory"
+}
+
+func (*Textfile) SampleConfig() string {
+	return sampleConfig
+}
+
+func (t *Textfile) Init() error {
+	if t.Directory == "" {
+		return errors.New("directory is required")
+	}
+	return nil
+}
+
+func (t *Textfile) Gather(acc telegraf.Accumulator) error {
+	if _, err := os.Stat(t.Directory); err != nil {
+		return fmt.Errorf("unable to read the directory: %w", err)
+	}
+	paths, err := filepath.Glob(filepath.Join(t.Directory, filePattern))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+	now := t.now()
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		age := now.Sub(info.ModTime())
+		// A file that is not updated anymore, e.g. since the job that writes it failed,
+		// would otherwise report the same values forever.
+		stale := age > time.Duration(t.MaxAge)
+		acc.AddFields(measurement, map[string]interface{}{
+			"age_seconds": age.Seconds(),
+			"stale":       boolToInt(stale),
+		}, map[string]string{fileTag: info.Name()}, now)
+		if stale {
+			t.Log.Debugf("Skipping %s since it was last modified %s ago", path, age.Round(time.Second))
+			continue
+		}
+		if err = t.gatherFile(acc, path, now); err != nil {
+			acc.AddError(fmt.Errorf("unable to parse %s: %w", path, err))
+		}
+	}
+	return nil
+}
+
+// gatherFile adds every sample of the file as a metric named by the series. The samples
+// are parsed before any is added, so a file that is not valid adds no metrics.
+func (t *Textfile) gatherFile(acc telegraf.Accumulator, path string, now time.Time) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	type sample struct {
+		name  string
+		tags  map[string]string
+		value float64
+	}
+	var samples []sample
+	parser := textparse.NewPromParser(content, labels.NewSymbolTable())
+	for {
+		entry, err := parser.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if entry != textparse.EntrySeries {
+			continue
+		}
+		_, _, value := parser.Series()
+		// CloudWatch does not accept NaN or infinite values.
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		var lbls labels.Labels
+		parser.Metric(&lbls)
+		s := sample{tags: map[string]string{}, value: value}
+		lbls.Range(func(l labels.Label) {
+			if l.Name == labels.MetricName {
+				s.name = l.Value
+			} else {
+				s.tags[l.Name] = l.Value
+			}
+		})
+		samples = append(samples, s)
+	}
+	for _, s := range samples {
+		acc.AddGauge(s.name, map[string]interface{}{valueField: s.value}, s.tags, now)
+	}
+	return nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func init() {
+	inputs.Add("textfile", func() telegraf.Input {
+		return &Textfile{
+			MaxAge: config.Duration(defaultMaxAge),
+			now:    time.Now,
+		}
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package textfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestTextfile copies the files to a directory and sets their modification time to
+// the age before now.
+func newTestTextfile(t *testing.T, ages map[string]time.Duration) *Textfile {
+	t.Helper()
+	dir := t.TempDir()
+	for name, age := range ages {
+		content, err := os.ReadFile(filepath.Join("testdata", name))
+		require.NoError(t, err)
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, content, 0600))
+		require.NoError(t, os.Chtimes(path, testNow.Add(-age), testNow.Add(-age)))
+	}
+	return &Textfile{
+		Directory: dir,
+		MaxAge:    config.Duration(defaultMaxAge),
+		Log:       testutil.Logger{},
+		now:       func() time.Time { return testNow },
+	}
+}
+
+func TestGather(t *testing.T) {
+	tf := newTestTextfile(t, map[string]time.Duration{
+		"backup.prom":  time.Minute,
+		"cron.prom":    2 * time.Hour,
+		"ignored.txt":  time.Minute,
+		"invalid.prom": time.Minute,
+	})
+	acc := &testutil.Accumulator{}
+	require.NoError(t, tf.Gather(acc))
+
+	require.Len(t, acc.Errors, 1)
+	assert.Contains(t, acc.Errors[0].Error(), "invalid.prom")
+
+	acc.AssertContainsTaggedFields(t, "backup_last_success_timestamp_seconds",
+		map[string]interface{}{"value": 1.7e+09}, map[string]string{"database": "orders"})
+	acc.AssertContainsTaggedFields(t, "backup_last_success_timestamp_seconds",
+		map[string]interface{}{"value": 1.7000001e+09}, map[string]string{"database": "payments"})
+	acc.AssertContainsTaggedFields(t, "backup_runs_total",
+		map[string]interface{}{"value": float64(42)}, map[string]string{})
+	acc.AssertDoesNotContainMeasurement(t, "backup_duration_seconds")
+	// The file is older than the max age.
+	acc.AssertDoesNotContainMeasurement(t, "cron_jobs_failed")
+	// The samples of a file are not added if it cannot be parsed.
+	acc.AssertDoesNotContainMeasurement(t, "valid_before_error")
+
+	acc.AssertContainsTaggedFields(t, measurement,
+		map[string]interface{}{"age_seconds": float64(60), "stale": 0}, map[string]string{fileTag: "backup.prom"})
+	acc.AssertContainsTaggedFields(t, measurement,
+		map[string]interface{}{"age_seconds": float64(7200), "stale": 1}, map[string]string{fileTag: "cron.prom"})
+	acc.AssertContainsTaggedFields(t, measurement,
+		map[string]interface{}{"age_seconds": float64(60), "stale": 0}, map[string]string{fileTag: "invalid.prom"})
+
+	for _, m := range acc.GetTelegrafMetrics() {
+		tag, _ := m.GetTag(fileTag)
+		assert.NotEqual(t, "ignored.txt", tag)
+		assert.Equal(t, testNow, m.Time())
+		if m.Name() != measurement {
+			assert.Equal(t, telegraf.Gauge, m.Type())
+		}
+	}
+}
+
+func TestGatherMaxAge(t *testing.T) {
+	tf := newTestTextfile(t, map[string]time.Duration{"cron.prom": 2 * time.Hour})
+	tf.MaxAge = config.Duration(3 * time.Hour)
+	acc := &testutil.Accumulator{}
+	require.NoError(t, tf.Gather(acc))
+	assert.Empty(t, acc.Errors)
+	acc.AssertContainsTaggedFields(t, "cron_jobs_failed",
+		map[string]interface{}{"value": float64(0)}, map[string]string{"job": "logrotate"})
+}
+
+func TestGatherMissingDirectory(t *testing.T) {
+	tf := newTestTextfile(t, nil)
+	tf.Directory = filepath.Join(tf.Directory, "missing")
+	assert.Error(t, tf.Gather(&testutil.Accumulator{}))
+	assert.Error(t, (&Textfile{}).Init())
+}
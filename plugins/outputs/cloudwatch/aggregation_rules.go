@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/gobwas/glob"
+)
+
+const (
+	highStorageResolution     int64 = 1
+	standardStorageResolution int64 = 60
+)
+
+type aggregationRule struct {
+	AggregationRule
+	matcher glob.Glob
+}
+
+type aggregationRules []aggregationRule
+
+// newAggregationRules compiles the metric name patterns of the rules.
+func newAggregationRules(rules []AggregationRule) (aggregationRules, error) {
+	compiled := make(aggregationRules, 0, len(rules))
+	for i, rule := range rules {
+		if rule.MetricNamePattern == "" {
+			return nil, fmt.Errorf("'aggregation_rules[%d].metric_name_pattern' must be set", i)
+		}
+		matcher, err := glob.Compile(rule.MetricNamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("'aggregation_rules[%d].metric_name_pattern' is invalid: %w", i, err)
+		}
+		if rule.AggregationInterval < 0 {
+			return nil, fmt.Errorf("'aggregation_rules[%d].aggregation_interval' must not be negative", i)
+		}
+		switch rule.StorageResolution {
+		case 0, highStorageResolution, standardStorageResolution:
+		default:
+			return nil, fmt.Errorf("'aggregation_rules[%d].storage_resolution' must be %d or %d", i, highStorageResolution, standardStorageResolution)
+		}
+		compiled = append(compiled, aggregationRule{AggregationRule: rule, matcher: matcher})
+	}
+	return compiled, nil
+}
+
+// apply the first rule matching the metric name to the datum.
+func (rules aggregationRules) apply(datum *aggregationDatum) {
+	name := aws.StringValue(datum.MetricName)
+	for _, rule := range rules {
+		if !rule.matcher.Match(name) {
+			continue
+		}
+		if rule.AggregationInterval > 0 {
+			datum.aggregationInterval = rule.AggregationInterval
+		}
+		if rule.StorageResolution > 0 {
+			datum.StorageResolution = aws.Int64(rule.StorageResolution)
+		}
+		return
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+)
+
+func TestNewAggregationRules(t *testing.T) {
+	testCases := map[string]struct {
+		rules   []AggregationRule
+		wantErr string
+	}{
+		"Valid": {
+			rules: []AggregationRule{
+				{MetricNamePattern: "latency_*", AggregationInterval: 10 * time.Second, StorageResolution: 1},
+				{MetricNamePattern: "*", AggregationInterval: time.Minute},
+			},
+		},
+		"MissingPattern": {
+			rules:   []AggregationRule{{StorageResolution: 1}},
+			wantErr: "'aggregation_rules[0].metric_name_pattern' must be set",
+		},
+		"InvalidPattern": {
+			rules:   []AggregationRule{{MetricNamePattern: "latency_[a"}},
+			wantErr: "'aggregation_rules[0].metric_name_pattern' is invalid",
+		},
+		"NegativeInterval": {
+			rules:   []AggregationRule{{MetricNamePattern: "*", AggregationInterval: -time.Second}},
+			wantErr: "'aggregation_rules[0].aggregation_interval' must not be negative",
+		},
+		"InvalidStorageResolution": {
+			rules:   []AggregationRule{{MetricNamePattern: "*", StorageResolution: 10}},
+			wantErr: "'aggregation_rules[0].storage_resolution' must be 1 or 60",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := newAggregationRules(testCase.rules)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, got, len(testCase.rules))
+		})
+	}
+}
+
+func TestAggregationRulesApply(t *testing.T) {
+	rules, err := newAggregationRules([]AggregationRule{
+		{MetricNamePattern: "latency_*", AggregationInterval: 10 * time.Second, StorageResolution: 1},
+		{MetricNamePattern: "latency_p99", AggregationInterval: 30 * time.Second},
+		{MetricNamePattern: "*_bytes", StorageResolution: 60},
+		{MetricNamePattern: "cpu_*", AggregationInterval: time.Minute},
+	})
+	require.NoError(t, err)
+	testCases := map[string]struct {
+		interval       time.Duration
+		resolution     int64
+		wantInterval   time.Duration
+		wantResolution int64
+	}{
+		"latency_p99":   {wantInterval: 10 * time.Second, wantResolution: 1},
+		"disk_io_bytes": {interval: 5 * time.Second, resolution: 1, wantInterval: 5 * time.Second, wantResolution: 60},
+		"cpu_usage":     {resolution: 1, wantInterval: time.Minute, wantResolution: 1},
+		"mem_used":      {interval: 30 * time.Second, resolution: 60, wantInterval: 30 * time.Second, wantResolution: 60},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			datum := &aggregationDatum{
+				MetricDatum: cloudwatch.MetricDatum{
+					MetricName:        aws.String(name),
+					StorageResolution: aws.Int64(testCase.resolution),
+				},
+				aggregationInterval: testCase.interval,
+			}
+			rules.apply(datum)
+			assert.Equal(t, testCase.wantInterval, datum.aggregationInterval)
+			assert.Equal(t, testCase.wantResolution, *datum.StorageResolution)
+		})
+	}
+}
@@ -5,6 +5,7 @@ package cloudwatch
 
 import (
 	"context"
+	"errors"
 	"log"
 	"reflect"
 	"sort"
@@ -32,6 +33,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/amazon-cloudwatch-agent/service/admin"
 )
 
 const (
@@ -54,6 +56,7 @@ const (
 )
 
 type CloudWatch struct {
+	id     component.ID
 	config *Config
 	logger *zap.Logger
 	svc    cloudwatchiface.CloudWatchAPI
@@ -63,19 +66,24 @@ type CloudWatch struct {
 	metricChan             chan *aggregationDatum
 	datumBatchChan         chan map[string][]*cloudwatch.MetricDatum
 	metricDatumBatch       *MetricDatumBatch
+	flushChan              chan chan struct{}
 	shutdownChan           chan struct{}
 	retries                int
 	publisher              *publisher.Publisher
 	retryer                *retryer.LogThrottleRetryer
 	droppingOriginMetrics  collections.Set[string]
+	aggregationRules       aggregationRules
 	aggregator             Aggregator
 	aggregatorShutdownChan chan struct{}
 	aggregatorWaitGroup    sync.WaitGroup
 	lastRequestBytes       int
+	lastError              admin.LastError
+	unregister             func()
 }
 
 // Compile time interface check.
 var _ exporter.Metrics = (*CloudWatch)(nil)
+var _ admin.Flusher = (*CloudWatch)(nil)
 
 func (c *CloudWatch) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
@@ -113,9 +121,15 @@ func (c *CloudWatch) Start(_ context.Context, host component.Host) error {
 	}
 	//Format unique roll up list
 	c.config.RollupDimensions = GetUniqueRollupList(c.config.RollupDimensions)
+	aggregationRules, err := newAggregationRules(c.config.AggregationRules)
+	if err != nil {
+		return err
+	}
+	c.aggregationRules = aggregationRules
 	c.svc = svc
 	c.retryer = logThrottleRetryer
 	c.startRoutines()
+	c.unregister = admin.Register(c.id.String(), c)
 	return nil
 }
 
@@ -123,6 +137,7 @@ func (c *CloudWatch) startRoutines() {
 	setNewDistributionFunc(c.config.MaxValuesPerDatum)
 	c.metricChan = make(chan *aggregationDatum, metricChanBufferSize)
 	c.datumBatchChan = make(chan map[string][]*cloudwatch.MetricDatum, datumBatchChanBufferSize)
+	c.flushChan = make(chan chan struct{})
 	c.shutdownChan = make(chan struct{})
 	c.aggregatorShutdownChan = make(chan struct{})
 	c.aggregator = NewAggregator(c.metricChan, c.aggregatorShutdownChan, &c.aggregatorWaitGroup)
@@ -134,6 +149,9 @@ func (c *CloudWatch) startRoutines() {
 
 func (c *CloudWatch) Shutdown(ctx context.Context) error {
 	log.Println("D! Stopping the CloudWatch output plugin")
+	if c.unregister != nil {
+		c.unregister()
+	}
 	for i := 0; i < 5; i++ {
 		if len(c.metricChan) == 0 && len(c.datumBatchChan) == 0 {
 			break
@@ -158,6 +176,7 @@ func (c *CloudWatch) Shutdown(ctx context.Context) error {
 func (c *CloudWatch) ConsumeMetrics(ctx context.Context, metrics pmetric.Metrics) error {
 	datums := ConvertOtelMetrics(metrics)
 	for _, d := range datums {
+		c.aggregationRules.apply(d)
 		c.aggregator.AddMetric(d)
 	}
 	return nil
@@ -192,6 +211,13 @@ func (c *CloudWatch) pushMetricDatum() {
 				c.datumBatchChan <- c.metricDatumBatch.Partition
 				c.metricDatumBatch.clear()
 			}
+		case done := <-c.flushChan:
+			if len(c.metricDatumBatch.Partition) > 0 {
+				c.lastRequestBytes = c.metricDatumBatch.Size
+				c.datumBatchChan <- c.metricDatumBatch.Partition
+				c.metricDatumBatch.clear()
+			}
+			close(done)
 		case <-c.shutdownChan:
 			return
 		}
@@ -326,6 +352,38 @@ func (c *CloudWatch) pushMetricDatumBatch() {
 	}
 }
 
+// Flush queues the batch being filled and publishes the queued batches without waiting for the
+// force flush interval.
+func (c *CloudWatch) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	select {
+	case c.flushChan <- done:
+	case <-c.shutdownChan:
+		return errors.New("cloudwatch output is stopped")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	c.pushMetricDatumBatch()
+	return nil
+}
+
+// Status reports the number of metrics waiting to be batched, the number of batches waiting to
+// be published and the last PutMetricData error.
+func (c *CloudWatch) Status() admin.Status {
+	return admin.Status{
+		Queues: map[string]int{
+			"metrics": len(c.metricChan),
+			"batches": len(c.datumBatchChan),
+		},
+		LastError: c.lastError.Get(),
+	}
+}
+
 // backoffSleep sleeps some amount of time based on number of retries done.
 func (c *CloudWatch) backoffSleep() {
 	d := 1 * time.Minute
@@ -399,6 +457,7 @@ func (c *CloudWatch) WriteToCloudWatch(req interface{}) {
 	}
 	if err != nil {
 		log.Println("E! cloudwatch: WriteToCloudWatch failure, err: ", err)
+		c.lastError.Record(err)
 	}
 }
 
@@ -468,9 +468,32 @@ func TestWriteError(t *testing.T) {
 	}
 	time.Sleep(backoffRetryBase * time.Duration(sum))
 	assert.True(t, svc.AssertNumberOfCalls(t, "PutMetricData", 5))
+	assert.Eventually(t, func() bool { return cw.Status().LastError != nil }, 5*time.Second, 100*time.Millisecond)
 	cw.Shutdown(ctx)
 }
 
+func TestFlush(t *testing.T) {
+	svc := new(mockCloudWatchClient)
+	res := cloudwatch.PutMetricDataOutput{}
+	svc.On("PutMetricData", mock.Anything).Return(&res, nil)
+	cw := newCloudWatchClient(svc, time.Hour)
+	cw.publisher, _ = publisher.NewPublisher(
+		publisher.NewNonBlockingFifoQueue(10),
+		10,
+		2*time.Second,
+		cw.WriteToCloudWatch)
+	ctx := context.Background()
+	cw.ConsumeMetrics(ctx, createTestMetrics(20, 1, 1, ""))
+	require.Eventually(t, func() bool { return cw.Status().Queues["metrics"] == 0 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, cw.Flush(ctx))
+	require.Eventually(t, func() bool { return len(svc.Calls) == 1 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, cw.Status().Queues["batches"])
+	assert.Nil(t, cw.Status().LastError)
+	cw.Shutdown(ctx)
+	assert.Error(t, cw.Flush(ctx))
+}
+
 // TestPublish verifies metric batches do not get pushed immediately when
 // batch-buffer is full.
 func TestPublish(t *testing.T) {
@@ -27,6 +27,9 @@ type Config struct {
 	RollupDimensions         [][]string      `mapstructure:"rollup_dimensions,omitempty"`
 	DropOriginalConfigs      map[string]bool `mapstructure:"drop_original_metrics,omitempty"`
 	Namespace                string          `mapstructure:"namespace"`
+	// AggregationRules override the aggregation interval and storage resolution
+	// set by the receivers. The first rule matching the metric name is applied.
+	AggregationRules []AggregationRule `mapstructure:"aggregation_rules,omitempty"`
 
 	// ResourceToTelemetrySettings is the option for converting resource
 	// attributes to telemetry attributes.
@@ -37,6 +40,15 @@ type Config struct {
 	MiddlewareID *component.ID `mapstructure:"middleware,omitempty"`
 }
 
+// AggregationRule sets the aggregation interval and storage resolution of the metrics
+// with a name matching the glob pattern. Zero values leave the ones set by the
+// receivers unchanged.
+type AggregationRule struct {
+	MetricNamePattern   string        `mapstructure:"metric_name_pattern"`
+	AggregationInterval time.Duration `mapstructure:"aggregation_interval,omitempty"`
+	StorageResolution   int64         `mapstructure:"storage_resolution,omitempty"`
+}
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid.
@@ -50,5 +62,8 @@ func (c *Config) Validate() error {
 	if c.ForceFlushInterval < time.Millisecond {
 		return errors.New("'force_flush_interval' must be at least 1 millisecond")
 	}
+	if _, err := newAggregationRules(c.AggregationRules); err != nil {
+		return err
+	}
 	return nil
 }
@@ -111,3 +111,26 @@ func TestConfigDropOriginConfigs(t *testing.T) {
 	assert.True(t, drop["cpu_usage"])
 	assert.True(t, drop["foo_bar"])
 }
+
+func TestConfigAggregationRules(t *testing.T) {
+	factories, err := otelcoltest.NopFactories()
+	assert.NoError(t, err)
+	factory := NewFactory()
+	factories.Exporters[TypeStr] = factory
+
+	fp := filepath.Join("testdata", "aggregation_rules.yaml")
+	c, err := otelcoltest.LoadConfigAndValidate(fp, factories)
+	assert.NoError(t, err)
+
+	assert.NotNil(t, c)
+	c2, ok := c.Exporters[component.NewID(TypeStr)].(*Config)
+	assert.True(t, ok)
+	assert.Equal(t, []AggregationRule{
+		{MetricNamePattern: "latency_*", AggregationInterval: 10 * time.Second, StorageResolution: 1},
+		{MetricNamePattern: "*", AggregationInterval: time.Minute},
+	}, c2.AggregationRules)
+
+	fp = filepath.Join("testdata", "invalid_aggregation_rules.yaml")
+	_, err = otelcoltest.LoadConfigAndValidate(fp, factories)
+	assert.ErrorContains(t, err, "storage_resolution")
+}
@@ -47,6 +47,7 @@ func createMetricsExporter(
 	config component.Config,
 ) (exporter.Metrics, error) {
 	cw := &CloudWatch{
+		id:     settings.ID,
 		config: config.(*Config),
 		logger: settings.Logger,
 	}
@@ -4,9 +4,12 @@
 package cloudwatchlogs
 
 import (
+	"context"
+	"errors"
 	"math/rand"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -16,6 +19,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/profiler"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/service/admin"
 )
 
 const (
@@ -59,6 +63,9 @@ type pusher struct {
 	needSort            bool
 	stop                <-chan struct{}
 	lastSentTime        time.Time
+	flushCh             chan chan struct{}
+	batchedEvents       atomic.Int64
+	lastError           admin.LastError
 
 	initNonBlockingChOnce sync.Once
 	startNonBlockCh       chan struct{}
@@ -79,6 +86,7 @@ func NewPusher(region string, target Target, service CloudWatchLogsService, flus
 		flushTimer:      time.NewTimer(flushTimeout),
 		stop:            stop,
 		startNonBlockCh: make(chan struct{}),
+		flushCh:         make(chan chan struct{}),
 		wg:              wg,
 	}
 	p.putRetentionPolicy()
@@ -87,6 +95,36 @@ func NewPusher(region string, target Target, service CloudWatchLogsService, flus
 	return p
 }
 
+// Flush sends the batched log events without waiting for the flush timeout.
+func (p *pusher) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	select {
+	case p.flushCh <- done:
+	case <-p.stop:
+		return errors.New("pusher is stopped")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Status reports the number of log events waiting to be batched, the number of batched log
+// events and the last PutLogEvents error.
+func (p *pusher) Status() admin.Status {
+	return admin.Status{
+		Queues: map[string]int{
+			"events":  len(p.eventsCh),
+			"batched": int(p.batchedEvents.Load()),
+		},
+		LastError: p.lastError.Get(),
+	}
+}
+
 func (p *pusher) AddEvent(e logs.LogEvent) {
 	if !hasValidTime(e) {
 		p.Log.Errorf("The log entry in (%v/%v) with timestamp (%v) comparing to the current time (%v) is out of accepted time range. Discard the log entry.", p.Group, p.Stream, e.Time(), time.Now())
@@ -134,6 +172,8 @@ func hasValidTime(e logs.LogEvent) bool {
 
 func (p *pusher) start() {
 	defer p.wg.Done()
+	unregister := admin.Register("cloudwatchlogs:"+p.Group+"/"+p.Stream, p)
+	defer unregister()
 
 	ec := make(chan logs.LogEvent)
 
@@ -178,6 +218,7 @@ func (p *pusher) start() {
 			}
 
 			p.events = append(p.events, ce)
+			p.batchedEvents.Store(int64(len(p.events)))
 			p.doneCallbacks = append(p.doneCallbacks, e.Done)
 			p.bufferredSize += size
 			if p.minT == nil || p.minT.After(et) {
@@ -193,6 +234,11 @@ func (p *pusher) start() {
 			} else {
 				p.resetFlushTimer()
 			}
+		case done := <-p.flushCh:
+			if len(p.events) > 0 {
+				p.send()
+			}
+			close(done)
 		case <-p.stop:
 			if len(p.events) > 0 {
 				p.send()
@@ -207,6 +253,7 @@ func (p *pusher) reset() {
 		p.events[i] = nil
 	}
 	p.events = p.events[:0]
+	p.batchedEvents.Store(0)
 	for i := 0; i < len(p.doneCallbacks); i++ {
 		p.doneCallbacks[i] = nil
 	}
@@ -269,6 +316,8 @@ func (p *pusher) send() {
 			return
 		}
 
+		p.lastError.Record(err)
+
 		awsErr, ok := err.(awserr.Error)
 		if !ok {
 			p.Log.Errorf("Non aws error received when sending logs to %v/%v: %v. CloudWatch agent will not retry and logs will be missing!", p.Group, p.Stream, err)
@@ -5,6 +5,7 @@ package cloudwatchlogs
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -846,6 +847,46 @@ func TestResendWouldStopAfterExhaustedRetries(t *testing.T) {
 	wg.Wait()
 }
 
+func TestPusherFlush(t *testing.T) {
+	var s svcMock
+	sent := make(chan int, 1)
+	s.ple = func(in *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+		sent <- len(in.LogEvents)
+		return &cloudwatchlogs.PutLogEventsOutput{}, nil
+	}
+
+	stop, p := testPreparation(-1, &s, time.Hour, maxRetryTimeout)
+	p.AddEvent(evtMock{"msg", time.Now(), nil})
+	require.Eventually(t, func() bool { return p.Status().Queues["batched"] == 1 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, p.Flush(context.Background()))
+	require.Equal(t, 1, <-sent)
+	require.Equal(t, 0, p.Status().Queues["batched"])
+
+	close(stop)
+	wg.Wait()
+	require.Error(t, p.Flush(context.Background()))
+}
+
+func TestPusherLastError(t *testing.T) {
+	var s svcMock
+	s.ple = func(in *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+		return nil, &cloudwatchlogs.InvalidParameterException{}
+	}
+
+	stop, p := testPreparation(-1, &s, time.Hour, maxRetryTimeout)
+	require.Nil(t, p.Status().LastError)
+	p.AddEvent(evtMock{"msg", time.Now(), nil})
+	require.Eventually(t, func() bool { return p.Status().Queues["batched"] == 1 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Flush(context.Background()))
+	lastError := p.Status().LastError
+	require.NotNil(t, lastError)
+	require.Contains(t, lastError.Message, cloudwatchlogs.ErrCodeInvalidParameterException)
+
+	close(stop)
+	wg.Wait()
+}
+
 func testPreparation(retention int, s *svcMock, flushTimeout time.Duration, retryDuration time.Duration) (chan struct{}, *pusher) {
 	stop := make(chan struct{})
 	mockLogSrcObj := &mockLogSrc{}
@@ -9,10 +9,21 @@ import (
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/processors/k8sdecorator"
 
 	// Enabled cloudwatch-agent input plugins
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/certificates"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/cgroups"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/endpoints"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/exec"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/filesystem_paths"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/kernel_net"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/nfs"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/nvidia_smi"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/procstat_aggregate"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/prometheus"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/psi"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/statsd"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/systemd_units"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/textfile"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/win_perf_counters"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/windows_event_log"
 
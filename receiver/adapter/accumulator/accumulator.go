@@ -17,6 +17,7 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	internalmetric "github.com/aws/amazon-cloudwatch-agent/internal/metric"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util"
 )
 
@@ -188,13 +189,17 @@ func (o *otelAccumulator) modifyMetricAndConvertToOtelValue(m telegraf.Metric) (
 	var errs error
 	for field, value := range mMetric.Fields() {
 		// Convert all int,uint to int64 and float to float64 and bool to int.
-		otelValue, err := util.ToOtelValue(value)
+		// The exemplar of the value is kept for the data point.
+		v, exemplar := unwrapExemplar(value)
+		otelValue, err := util.ToOtelValue(v)
 		if err != nil {
 			errs = multierr.Append(errs, fmt.Errorf("field (%q): %w", field, err))
 		}
 
 		if otelValue == nil {
 			mMetric.RemoveField(field)
+		} else if exemplar != nil {
+			mMetric.AddField(field, internalmetric.ValueWithExemplar{Value: otelValue, Exemplar: *exemplar})
 		} else if value != otelValue {
 			mMetric.AddField(field, otelValue)
 		}
@@ -19,6 +19,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
+	internalmetric "github.com/aws/amazon-cloudwatch-agent/internal/metric"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution/regular"
 )
 
@@ -264,6 +265,20 @@ func Test_ModifyMetricAndConvertMetricValue(t *testing.T) {
 			},
 			wantDroppedFields: []string{"client"},
 		},
+		"WithExemplar": {
+			metric: testutil.MustMetric(
+				"prometheus",
+				map[string]string{},
+				map[string]interface{}{
+					"requests_total": internalmetric.ValueWithExemplar{Value: int32(3), Exemplar: internalmetric.Exemplar{Value: 1}},
+				},
+				time.Now(),
+				telegraf.Untyped,
+			),
+			wantFields: map[string]interface{}{
+				"requests_total": internalmetric.ValueWithExemplar{Value: int64(3), Exemplar: internalmetric.Exemplar{Value: 1}},
+			},
+		},
 	}
 
 	for name, testCase := range testCases {
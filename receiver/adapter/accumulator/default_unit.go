@@ -116,6 +116,96 @@ var defaultUnits = map[string]map[string]string{
 		"sleeping":      "Count",
 		"dead":          "Count",
 	},
+	"cgroups": {
+		"cpu_usage_percent":           "Percent",
+		"cpu_throttled_percent":       "Percent",
+		"memory_current":              "Bytes",
+		"memory_max":                  "Bytes",
+		"memory_used_percent":         "Percent",
+		"memory_swap_current":         "Bytes",
+		"io_read_bytes_per_sec":       "Bytes/Second",
+		"io_write_bytes_per_sec":      "Bytes/Second",
+		"io_read_ops_per_sec":         "Count/Second",
+		"io_write_ops_per_sec":        "Count/Second",
+		"pids_current":                "Count",
+		"cpu_pressure_some_avg10":     "Percent",
+		"cpu_pressure_some_avg60":     "Percent",
+		"cpu_pressure_some_avg300":    "Percent",
+		"cpu_pressure_full_avg10":     "Percent",
+		"cpu_pressure_full_avg60":     "Percent",
+		"cpu_pressure_full_avg300":    "Percent",
+		"memory_pressure_some_avg10":  "Percent",
+		"memory_pressure_some_avg60":  "Percent",
+		"memory_pressure_some_avg300": "Percent",
+		"memory_pressure_full_avg10":  "Percent",
+		"memory_pressure_full_avg60":  "Percent",
+		"memory_pressure_full_avg300": "Percent",
+		"io_pressure_some_avg10":      "Percent",
+		"io_pressure_some_avg60":      "Percent",
+		"io_pressure_some_avg300":     "Percent",
+		"io_pressure_full_avg10":      "Percent",
+		"io_pressure_full_avg60":      "Percent",
+		"io_pressure_full_avg300":     "Percent",
+	},
+	"psi": {
+		"cpu_some_avg10":     "Percent",
+		"cpu_some_avg60":     "Percent",
+		"cpu_some_avg300":    "Percent",
+		"cpu_full_avg10":     "Percent",
+		"cpu_full_avg60":     "Percent",
+		"cpu_full_avg300":    "Percent",
+		"memory_some_avg10":  "Percent",
+		"memory_some_avg60":  "Percent",
+		"memory_some_avg300": "Percent",
+		"memory_full_avg10":  "Percent",
+		"memory_full_avg60":  "Percent",
+		"memory_full_avg300": "Percent",
+		"io_some_avg10":      "Percent",
+		"io_some_avg60":      "Percent",
+		"io_some_avg300":     "Percent",
+		"io_full_avg10":      "Percent",
+		"io_full_avg60":      "Percent",
+		"io_full_avg300":     "Percent",
+		"irq_full_avg10":     "Percent",
+		"irq_full_avg60":     "Percent",
+		"irq_full_avg300":    "Percent",
+		"cpu_some_total":     "Microseconds",
+		"cpu_full_total":     "Microseconds",
+		"memory_some_total":  "Microseconds",
+		"memory_full_total":  "Microseconds",
+		"io_some_total":      "Microseconds",
+		"io_full_total":      "Microseconds",
+		"irq_full_total":     "Microseconds",
+	},
+	"endpoints": {
+		"latency":            "Milliseconds",
+		"tls_handshake_time": "Milliseconds",
+	},
+	"filesystem_paths": {
+		"file_count":      "Count",
+		"total_bytes":     "Bytes",
+		"oldest_file_age": "Seconds",
+	},
+	"kernel_net": {
+		"conntrack_used_percent": "Percent",
+		"file_nr_used_percent":   "Percent",
+	},
+	"nfs": {
+		"ops":         "Count",
+		"retrans":     "Count",
+		"timeouts":    "Count",
+		"rtt":         "Milliseconds",
+		"exec_time":   "Milliseconds",
+		"read_bytes":  "Bytes",
+		"write_bytes": "Bytes",
+	},
+	"textfile": {
+		"age_seconds": "Seconds",
+	},
+	"systemd_units": {
+		"restarts":                "Count",
+		"time_since_state_change": "Seconds",
+	},
 }
 
 func getDefaultUnit(measurement string, fieldKey string) string {
@@ -114,7 +114,8 @@ func populateDataPointsForHistogram(
 	timestamp pcommon.Timestamp,
 ) {
 	for field, value := range fields {
-		d, ok := value.(distribution.Distribution)
+		v, exemplar := unwrapExemplar(value)
+		d, ok := v.(distribution.Distribution)
 		if !ok {
 			continue
 		}
@@ -125,12 +126,19 @@ func populateDataPointsForHistogram(
 		h.SetTimestamp(timestamp)
 		d.ConvertToOtel(h)
 		addTagsToAttributes(h.Attributes(), tags)
+		if exemplar != nil {
+			exemplar.AppendTo(h.Exemplars())
+		}
 	}
 }
 
 func populateNumberDataPoint(datapoint pmetric.NumberDataPoint, value interface{}, tags map[string]string, timestamp pcommon.Timestamp) {
 	datapoint.SetTimestamp(timestamp)
 
+	value, exemplar := unwrapExemplar(value)
+	if exemplar != nil {
+		exemplar.AppendTo(datapoint.Exemplars())
+	}
 	switch v := value.(type) {
 	case int64:
 		datapoint.SetIntValue(v)
@@ -142,3 +150,11 @@ func populateNumberDataPoint(datapoint pmetric.NumberDataPoint, value interface{
 
 	addTagsToAttributes(datapoint.Attributes(), tags)
 }
+
+// unwrapExemplar returns the value of the field and its exemplar, if it has one.
+func unwrapExemplar(value interface{}) (interface{}, *metric.Exemplar) {
+	if v, ok := value.(metric.ValueWithExemplar); ok {
+		return v.Value, &v.Exemplar
+	}
+	return value, nil
+}
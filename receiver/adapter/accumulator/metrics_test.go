@@ -334,3 +334,29 @@ func TestPopulateDataPointsForHistogram(t *testing.T) {
 	assert.Equal(t, dist.Maximum(), dp.Max())
 	assert.Equal(t, dist.Sum(), dp.Sum())
 }
+
+func TestPopulateDataPointsWithExemplar(t *testing.T) {
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	tags := map[string]string{"job": "test"}
+	exemplar := metric.Exemplar{Labels: map[string]string{"trace_id": "trace"}, Value: 1, Timestamp: time.Now()}
+	dist := regular.NewRegularDistribution()
+	assert.NoError(t, dist.AddEntry(1, 1))
+	otelMetrics := pmetric.NewMetrics().ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	populateDataPointsForGauge("prometheus", otelMetrics, map[string]interface{}{
+		"requests_total": metric.ValueWithExemplar{Value: 2.0, Exemplar: exemplar},
+	}, tags, timestamp)
+	populateDataPointsForHistogram("prometheus", otelMetrics, map[string]interface{}{
+		"request_duration": metric.ValueWithExemplar{Value: dist, Exemplar: exemplar},
+	}, tags, timestamp)
+
+	assert.Equal(t, 2, otelMetrics.Len())
+	gauge := otelMetrics.At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, 2.0, gauge.DoubleValue())
+	assert.Equal(t, map[string]interface{}{"job": "test"}, gauge.Attributes().AsRaw())
+	assert.Equal(t, 1, gauge.Exemplars().Len())
+	histogram := otelMetrics.At(1).Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(1), histogram.Count())
+	assert.Equal(t, map[string]interface{}{"job": "test"}, histogram.Attributes().AsRaw())
+	assert.Equal(t, 1, histogram.Exemplars().Len())
+}
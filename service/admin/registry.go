@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Component is a part of the running agent that reports its status to the admin API.
+type Component interface {
+	Status() Status
+}
+
+// Flusher is a component that can send what it buffered without waiting for its next flush.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// Status is what a component reports to the admin API.
+type Status struct {
+	// Queues is the number of items waiting in each queue of the component.
+	Queues map[string]int `json:"queues,omitempty"`
+	// Files are the files tailed by the component.
+	Files []File `json:"files,omitempty"`
+	// LastError is the last error the component got sending telemetry.
+	LastError *Error `json:"last_error,omitempty"`
+}
+
+// File is a tailed log file.
+type File struct {
+	Path      string `json:"path"`
+	LogGroup  string `json:"log_group"`
+	LogStream string `json:"log_stream"`
+	// Offset is the offset up to which the file was published.
+	Offset int64 `json:"offset"`
+}
+
+// Error is an error a component got sending telemetry.
+type Error struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// LastError keeps the last error of a component. The zero value is ready to use.
+type LastError struct {
+	mu   sync.Mutex
+	last *Error
+}
+
+// Record replaces the last error with err.
+func (e *LastError) Record(err error) {
+	if err == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.last = &Error{Time: time.Now(), Message: err.Error()}
+}
+
+// Get returns the last error, or nil if there was none.
+func (e *LastError) Get() *Error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.last
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Component{}
+)
+
+// Register adds the component to the ones served by the admin API under the given name. The
+// returned function removes it.
+func Register(name string, c Component) func() {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = c
+	return func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		// The name may have been taken over by a component registered since.
+		if registry[name] == c {
+			delete(registry, name)
+		}
+	}
+}
+
+// Components returns the registered components by name.
+func Components() map[string]Component {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	components := make(map[string]Component, len(registry))
+	for name, c := range registry {
+		components[name] = c
+	}
+	return components
+}
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockComponent struct {
+	status Status
+}
+
+func (m *mockComponent) Status() Status {
+	return m.status
+}
+
+func TestRegister(t *testing.T) {
+	first := &mockComponent{}
+	second := &mockComponent{}
+
+	unregisterFirst := Register("test", first)
+	assert.Equal(t, first, Components()["test"])
+
+	unregisterSecond := Register("test", second)
+	assert.Equal(t, second, Components()["test"])
+	// The component that took over the name is kept.
+	unregisterFirst()
+	assert.Equal(t, second, Components()["test"])
+
+	unregisterSecond()
+	assert.NotContains(t, Components(), "test")
+}
+
+func TestLastError(t *testing.T) {
+	var lastError LastError
+	assert.Nil(t, lastError.Get())
+
+	lastError.Record(nil)
+	assert.Nil(t, lastError.Get())
+
+	lastError.Record(errors.New("first"))
+	lastError.Record(errors.New("second"))
+	got := lastError.Get()
+	assert.NotNil(t, got)
+	assert.Equal(t, "second", got.Message)
+	assert.False(t, got.Time.IsZero())
+}
@@ -0,0 +1,222 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/version"
+)
+
+const (
+	PathStatus    = "/status"
+	PathConfig    = "/config"
+	PathPipelines = "/pipelines"
+	PathFlush     = "/flush"
+	PathReload    = "/reload"
+)
+
+var flushTimeout = 30 * time.Second
+
+// Agent is the running agent served by the admin API.
+type Agent interface {
+	// Config returns the effective config of the agent.
+	Config() (*Config, error)
+	// Pipelines returns the pipelines of the agent by name.
+	Pipelines() (map[string]Pipeline, error)
+	// Reload reloads the config of the agent.
+	Reload()
+}
+
+// Config is the effective config of the agent.
+type Config struct {
+	// Toml is the translated config of the telegraf plugins.
+	Toml string `json:"toml"`
+	// Otel is the merged config of the collector, which is not running in logs-only mode.
+	Otel map[string]interface{} `json:"otel,omitempty"`
+	// Fragments are the json config files the config was merged from, with the pipeline
+	// components each one contributed and why the skipped ones were.
+	Fragments json.RawMessage `json:"fragments,omitempty"`
+}
+
+// Pipeline is the components the telemetry goes through, in order.
+type Pipeline struct {
+	Receivers  []string `json:"receivers"`
+	Processors []string `json:"processors,omitempty"`
+	Exporters  []string `json:"exporters"`
+}
+
+// StatusResponse is the response of the status endpoint.
+type StatusResponse struct {
+	Version    string            `json:"version"`
+	Components map[string]Status `json:"components"`
+}
+
+// FlushResponse is the response of the flush endpoint, with the error of each flushed
+// component that failed.
+type FlushResponse struct {
+	Flushed []string          `json:"flushed"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server serves the admin API on a Unix socket, which only the user running the agent can
+// connect to. On Windows, Local System and Administrators can also connect.
+type Server struct {
+	path           string
+	agent          Agent
+	jsonMarshaller jsoniter.API
+	httpServer     *http.Server
+}
+
+func NewServer(path string, agent Agent) *Server {
+	s := &Server{
+		path:           path,
+		agent:          agent,
+		jsonMarshaller: jsoniter.ConfigCompatibleWithStandardLibrary,
+	}
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	s.setRouter(router)
+	s.httpServer = &http.Server{Handler: router, ReadHeaderTimeout: 90 * time.Second}
+	return s
+}
+
+func (s *Server) setRouter(router *gin.Engine) {
+	router.Use(gin.Recovery())
+	router.GET(PathStatus, s.statusHandler)
+	router.GET(PathConfig, s.configHandler)
+	router.GET(PathPipelines, s.pipelinesHandler)
+	router.POST(PathFlush, s.flushHandler)
+	router.POST(PathReload, s.reloadHandler)
+}
+
+// Start listens on the socket, replacing the socket left by an agent that did not shut down.
+func (s *Server) Start() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return err
+	}
+	if err = restrictSocket(s.path); err != nil {
+		_ = listener.Close()
+		return err
+	}
+	log.Printf("I! [admin] Serving the admin API on %s", s.path)
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("E! [admin] Failed to serve the admin API: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops serving and removes the socket.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) statusHandler(c *gin.Context) {
+	components := Components()
+	response := StatusResponse{
+		Version:    version.Full(),
+		Components: make(map[string]Status, len(components)),
+	}
+	for name, component := range components {
+		response.Components[name] = component.Status()
+	}
+	s.jsonHandler(c.Writer, http.StatusOK, response)
+}
+
+func (s *Server) configHandler(c *gin.Context) {
+	config, err := s.agent.Config()
+	if err != nil {
+		s.jsonHandler(c.Writer, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+	s.jsonHandler(c.Writer, http.StatusOK, config)
+}
+
+func (s *Server) pipelinesHandler(c *gin.Context) {
+	pipelines, err := s.agent.Pipelines()
+	if err != nil {
+		s.jsonHandler(c.Writer, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+	s.jsonHandler(c.Writer, http.StatusOK, pipelines)
+}
+
+// flushHandler flushes all the components that can be flushed at the same time, so a component
+// that cannot send does not hold back the others.
+func (s *Server) flushHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), flushTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	response := FlushResponse{Flushed: []string{}}
+	for name, component := range Components() {
+		flusher, ok := component.(Flusher)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(name string, flusher Flusher) {
+			defer wg.Done()
+			err := flusher.Flush(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if response.Errors == nil {
+					response.Errors = map[string]string{}
+				}
+				response.Errors[name] = err.Error()
+				return
+			}
+			response.Flushed = append(response.Flushed, name)
+		}(name, flusher)
+	}
+	wg.Wait()
+	sort.Strings(response.Flushed)
+
+	code := http.StatusOK
+	if len(response.Errors) > 0 {
+		code = http.StatusInternalServerError
+	}
+	log.Printf("I! [admin] Flushed %d components, %d failed", len(response.Flushed), len(response.Errors))
+	s.jsonHandler(c.Writer, code, response)
+}
+
+func (s *Server) reloadHandler(c *gin.Context) {
+	log.Printf("I! [admin] Reloading the agent as requested")
+	s.agent.Reload()
+	s.jsonHandler(c.Writer, http.StatusAccepted, struct{}{})
+}
+
+func (s *Server) jsonHandler(w http.ResponseWriter, code int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	encoder := s.jsonMarshaller.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	if err := encoder.Encode(data); err != nil {
+		log.Printf("E! [admin] Failed to encode the response: %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAgent struct {
+	config    *Config
+	pipelines map[string]Pipeline
+	err       error
+	reloaded  int
+}
+
+func (m *mockAgent) Config() (*Config, error) {
+	return m.config, m.err
+}
+
+func (m *mockAgent) Pipelines() (map[string]Pipeline, error) {
+	return m.pipelines, m.err
+}
+
+func (m *mockAgent) Reload() {
+	m.reloaded++
+}
+
+type mockFlusher struct {
+	mockComponent
+	err     error
+	flushed int
+}
+
+func (m *mockFlusher) Flush(context.Context) error {
+	m.flushed++
+	return m.err
+}
+
+func startServer(t *testing.T, agent Agent) *Client {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "admin.sock")
+	server := NewServer(path, agent)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		assert.NoError(t, server.Shutdown(context.Background()))
+		assert.NoFileExists(t, path)
+	})
+	return NewClient(path)
+}
+
+func TestServerStatus(t *testing.T) {
+	exporter := &mockComponent{status: Status{
+		Queues:    map[string]int{"batches": 2},
+		LastError: &Error{Time: time.Unix(0, 0).UTC(), Message: "AccessDenied"},
+	}}
+	tailer := &mockComponent{status: Status{
+		Files: []File{{Path: "/var/log/app.log", LogGroup: "app", LogStream: "i-123", Offset: 42}},
+	}}
+	defer Register("exporter", exporter)()
+	defer Register("tailer", tailer)()
+	client := startServer(t, &mockAgent{})
+
+	body, err := client.Do(context.Background(), http.MethodGet, PathStatus)
+	require.NoError(t, err)
+	var got StatusResponse
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.NotEmpty(t, got.Version)
+	assert.Equal(t, exporter.status, got.Components["exporter"])
+	assert.Equal(t, tailer.status, got.Components["tailer"])
+}
+
+func TestServerConfig(t *testing.T) {
+	agent := &mockAgent{
+		config: &Config{
+			Toml: "[agent]\n",
+			Otel: map[string]interface{}{"exporters": map[string]interface{}{"awscloudwatch": nil}},
+		},
+		pipelines: map[string]Pipeline{
+			"metrics/host": {Receivers: []string{"telegraf_cpu"}, Exporters: []string{"awscloudwatch"}},
+		},
+	}
+	client := startServer(t, agent)
+
+	body, err := client.Do(context.Background(), http.MethodGet, PathConfig)
+	require.NoError(t, err)
+	var config Config
+	require.NoError(t, json.Unmarshal(body, &config))
+	assert.Equal(t, *agent.config, config)
+
+	body, err = client.Do(context.Background(), http.MethodGet, PathPipelines)
+	require.NoError(t, err)
+	var pipelines map[string]Pipeline
+	require.NoError(t, json.Unmarshal(body, &pipelines))
+	assert.Equal(t, agent.pipelines, pipelines)
+
+	agent.err = errors.New("unable to read the toml config")
+	body, err = client.Do(context.Background(), http.MethodGet, PathConfig)
+	assert.Error(t, err)
+	assert.Contains(t, string(body), agent.err.Error())
+}
+
+func TestServerFlush(t *testing.T) {
+	succeeding := &mockFlusher{}
+	failing := &mockFlusher{err: errors.New("stopped")}
+	defer Register("succeeding", succeeding)()
+	defer Register("status-only", &mockComponent{})()
+	client := startServer(t, &mockAgent{})
+
+	body, err := client.Do(context.Background(), http.MethodPost, PathFlush)
+	require.NoError(t, err)
+	var got FlushResponse
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, FlushResponse{Flushed: []string{"succeeding"}}, got)
+	assert.Equal(t, 1, succeeding.flushed)
+
+	defer Register("failing", failing)()
+	body, err = client.Do(context.Background(), http.MethodPost, PathFlush)
+	assert.Error(t, err)
+	got = FlushResponse{}
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, FlushResponse{Flushed: []string{"succeeding"}, Errors: map[string]string{"failing": "stopped"}}, got)
+}
+
+func TestServerReload(t *testing.T) {
+	agent := &mockAgent{}
+	client := startServer(t, agent)
+
+	_, err := client.Do(context.Background(), http.MethodGet, PathReload)
+	assert.Error(t, err)
+	assert.Equal(t, 0, agent.reloaded)
+
+	_, err = client.Do(context.Background(), http.MethodPost, PathReload)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, agent.reloaded)
+}
+
+func TestServerReplacesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "admin.sock")
+	stale := NewServer(path, &mockAgent{})
+	require.NoError(t, stale.Start())
+
+	server := NewServer(path, &mockAgent{})
+	require.NoError(t, server.Start())
+	defer server.Shutdown(context.Background())
+
+	_, err := NewClient(path).Do(context.Background(), http.MethodGet, PathStatus)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,16 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+// +build !windows
+
+package admin
+
+import "os"
+
+const socketMode = 0600
+
+// restrictSocket only allows the user running the agent to connect to the socket.
+func restrictSocket(path string) error {
+	return os.Chmod(path, socketMode)
+}
@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package configprovider
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.uber.org/zap"
+)
+
+// Reloader notifies the collector resolving the configuration that it changed. The collector
+// then shuts down the running service and starts a new one from the re-resolved configuration.
+type Reloader struct {
+	mu         sync.Mutex
+	watcher    confmap.WatcherFunc
+	generation int
+}
+
+// GetReloadableSettings returns the same settings as GetSettings, with providers that let the
+// returned Reloader trigger a reload of the collector using them.
+func GetReloadableSettings(uris []string, logger *zap.Logger) (otelcol.ConfigProviderSettings, *Reloader) {
+	r := &Reloader{}
+	settings := GetSettings(uris, logger)
+	factories := make([]confmap.ProviderFactory, len(settings.ResolverSettings.ProviderFactories))
+	for i, factory := range settings.ResolverSettings.ProviderFactories {
+		factories[i] = r.wrap(factory)
+	}
+	settings.ResolverSettings.ProviderFactories = factories
+	return settings, r
+}
+
+// Reload notifies the collector that last resolved the configuration. Returns false if there
+// is none.
+func (r *Reloader) Reload() bool {
+	r.mu.Lock()
+	watcher := r.watcher
+	r.mu.Unlock()
+	if watcher == nil {
+		return false
+	}
+	go func() {
+		// The resolver closes its watch channel on shutdown, which can race with the notification.
+		defer func() { _ = recover() }()
+		watcher(&confmap.ChangeEvent{})
+	}()
+	return true
+}
+
+func (r *Reloader) wrap(factory confmap.ProviderFactory) confmap.ProviderFactory {
+	return confmap.NewProviderFactory(func(set confmap.ProviderSettings) confmap.Provider {
+		return &reloadableProvider{Provider: factory.Create(set), reloader: r}
+	})
+}
+
+// register stores the watcher of the latest resolve and returns a function that removes it
+// once the retrieved configuration is closed.
+func (r *Reloader) register(watcher confmap.WatcherFunc) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.generation++
+	r.watcher = watcher
+	generation := r.generation
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.generation == generation {
+			r.watcher = nil
+		}
+	}
+}
+
+type reloadableProvider struct {
+	confmap.Provider
+	reloader *Reloader
+}
+
+func (p *reloadableProvider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	ret, err := p.Provider.Retrieve(ctx, uri, nil)
+	if err != nil || watcher == nil {
+		return ret, err
+	}
+	raw, err := ret.AsRaw()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := raw.(map[string]any); !ok {
+		// Only whole configurations are reloaded, not values expanded inline.
+		return ret, nil
+	}
+	unregister := p.reloader.register(watcher)
+	return confmap.NewRetrieved(raw, confmap.WithRetrievedClose(func(ctx context.Context) error {
+		unregister()
+		return ret.Close(ctx)
+	}))
+}
@@ -0,0 +1,47 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package configprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+)
+
+func TestReloader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("receivers:\n  nop:\n"), 0600))
+	settings, reloader := GetReloadableSettings([]string{path}, zap.NewNop())
+	assert.False(t, reloader.Reload())
+
+	resolver, err := confmap.NewResolver(settings.ResolverSettings)
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.True(t, conf.IsSet("receivers::nop"))
+
+	require.NoError(t, os.WriteFile(path, []byte("receivers:\n  otlp:\n"), 0600))
+	assert.True(t, reloader.Reload())
+	select {
+	case err = <-resolver.Watch():
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("resolver was not notified")
+	}
+	conf, err = resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.True(t, conf.IsSet("receivers::otlp"))
+	assert.True(t, reloader.Reload())
+	<-resolver.Watch()
+
+	require.NoError(t, resolver.Shutdown(context.Background()))
+	assert.False(t, reloader.Reload())
+}
@@ -0,0 +1,221 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package lastknowngood
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/constants"
+)
+
+const (
+	dirName = "last-known-good"
+
+	tomlFileName     = "amazon-cloudwatch-agent.toml"
+	yamlFileName     = "amazon-cloudwatch-agent.yaml"
+	envFileName      = "env-config.json"
+	jsonDirName      = "json"
+	rollbackFileName = "rollback.json"
+
+	fileMode = 0644
+	dirMode  = 0755
+)
+
+// ErrNotSaved is returned when rolling back before any translation was saved.
+var ErrNotSaved = errors.New("no last-known-good config has been saved")
+
+// Rollback records when and why the agent fell back to the last-known-good config.
+type Rollback struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
+}
+
+// Store keeps the translated TOML, YAML and env config the agent last started successfully
+// with and copies of the json config they were translated from, in a directory next to the
+// TOML config.
+type Store struct {
+	dir string
+}
+
+func New(tomlConfigPath string) *Store {
+	return &Store{dir: filepath.Join(filepath.Dir(tomlConfigPath), dirName)}
+}
+
+// Dir is the directory of the store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// IsSaved reports whether the translation written to the given paths is the saved one.
+func (s *Store) IsSaved(tomlConfigPath, yamlConfigPath, envConfigPath string) bool {
+	if !s.exists() {
+		return false
+	}
+	for _, pair := range [][2]string{
+		{tomlConfigPath, s.path(tomlFileName)},
+		{yamlConfigPath, s.path(yamlFileName)},
+		{envConfigPath, s.path(envFileName)},
+	} {
+		a, err := readOptional(pair[0])
+		if err != nil {
+			return false
+		}
+		b, err := readOptional(pair[1])
+		if err != nil || !bytes.Equal(a, b) {
+			return false
+		}
+	}
+	return true
+}
+
+// Save replaces the saved config with the translation written to the given paths and the json
+// config files in the sources, which are files or directories. Saving the saved translation
+// again keeps the json config it was saved with and its rollback record.
+func (s *Store) Save(tomlConfigPath, yamlConfigPath, envConfigPath string, sources []string) error {
+	if s.IsSaved(tomlConfigPath, yamlConfigPath, envConfigPath) {
+		return nil
+	}
+	staging := s.dir + constants.FileSuffixTmp
+	if err := os.RemoveAll(staging); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(staging, dirMode); err != nil {
+		return err
+	}
+	if err := copyFile(tomlConfigPath, filepath.Join(staging, tomlFileName)); err != nil {
+		return err
+	}
+	for _, pair := range [][2]string{
+		{yamlConfigPath, filepath.Join(staging, yamlFileName)},
+		{envConfigPath, filepath.Join(staging, envFileName)},
+	} {
+		if err := copyOptional(pair[0], pair[1]); err != nil {
+			return err
+		}
+	}
+	for _, source := range sources {
+		if err := copySource(source, filepath.Join(staging, jsonDirName, filepath.Base(source))); err != nil {
+			return err
+		}
+	}
+	if err := os.RemoveAll(s.dir); err != nil {
+		return err
+	}
+	return os.Rename(staging, s.dir)
+}
+
+// Rollback writes the saved translation to the given paths and records the reason. It returns
+// ErrNotSaved if there is none.
+func (s *Store) Rollback(reason error, tomlConfigPath, yamlConfigPath, envConfigPath string) error {
+	if !s.exists() {
+		return ErrNotSaved
+	}
+	for _, pair := range [][2]string{
+		{s.path(yamlFileName), yamlConfigPath},
+		{s.path(envFileName), envConfigPath},
+	} {
+		if err := copyOptional(pair[0], pair[1]); err != nil {
+			return err
+		}
+	}
+	// the agent is started with the TOML config, so it is written last
+	if err := copyFile(s.path(tomlFileName), tomlConfigPath); err != nil {
+		return err
+	}
+	record, err := json.Marshal(Rollback{Time: time.Now().UTC(), Reason: reason.Error()})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(rollbackFileName), record, fileMode)
+}
+
+// RolledBack returns the rollback record of the saved config, if the agent fell back to it.
+func (s *Store) RolledBack() (*Rollback, bool) {
+	content, err := os.ReadFile(s.path(rollbackFileName))
+	if err != nil {
+		return nil, false
+	}
+	var record Rollback
+	if err = json.Unmarshal(content, &record); err != nil {
+		return nil, false
+	}
+	return &record, true
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+func (s *Store) exists() bool {
+	_, err := os.Stat(s.path(tomlFileName))
+	return err == nil
+}
+
+// readOptional reads the file, which is empty if it does not exist.
+func readOptional(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return content, err
+}
+
+// copyOptional copies the file, or removes the target if it does not exist, like the
+// config-translator does for the YAML config of json config without OTel pipelines.
+func copyOptional(source, target string) error {
+	err := copyFile(source, target)
+	if errors.Is(err, fs.ErrNotExist) {
+		if err = os.Remove(target); errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+	}
+	return err
+}
+
+// copySource copies the file or the files of the directory. Missing sources are skipped.
+func copySource(source, target string) error {
+	return filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		return copyFile(path, filepath.Join(target, rel))
+	})
+}
+
+func copyFile(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err = os.MkdirAll(filepath.Dir(target), dirMode); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fileMode)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,105 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package lastknowngood
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type translationPaths struct {
+	toml, yaml, env string
+}
+
+func newTranslationPaths(dir string) translationPaths {
+	return translationPaths{
+		toml: filepath.Join(dir, "amazon-cloudwatch-agent.toml"),
+		yaml: filepath.Join(dir, "amazon-cloudwatch-agent.yaml"),
+		env:  filepath.Join(dir, "env-config.json"),
+	}
+}
+
+func (p translationPaths) write(t *testing.T, toml, yaml string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(p.toml, []byte(toml), 0644))
+	require.NoError(t, os.WriteFile(p.env, []byte("{}"), 0644))
+	if yaml == "" {
+		require.NoError(t, os.RemoveAll(p.yaml))
+	} else {
+		require.NoError(t, os.WriteFile(p.yaml, []byte(yaml), 0644))
+	}
+}
+
+func (p translationPaths) read(t *testing.T) (string, string) {
+	t.Helper()
+	toml, err := os.ReadFile(p.toml)
+	require.NoError(t, err)
+	yaml, err := os.ReadFile(p.yaml)
+	if !errors.Is(err, os.ErrNotExist) {
+		require.NoError(t, err)
+	}
+	return string(toml), string(yaml)
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	paths := newTranslationPaths(dir)
+	jsonConfigDir := filepath.Join(dir, "amazon-cloudwatch-agent.d")
+	require.NoError(t, os.MkdirAll(jsonConfigDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(jsonConfigDir, "file_config.json"), []byte(`{"agent":{}}`), 0644))
+	store := New(paths.toml)
+	assert.Equal(t, filepath.Join(dir, "last-known-good"), store.Dir())
+
+	paths.write(t, "good", "pipelines")
+	assert.False(t, store.IsSaved(paths.toml, paths.yaml, paths.env))
+	assert.ErrorIs(t, store.Rollback(errors.New("bad"), paths.toml, paths.yaml, paths.env), ErrNotSaved)
+
+	require.NoError(t, store.Save(paths.toml, paths.yaml, paths.env, []string{jsonConfigDir, filepath.Join(dir, "missing.json")}))
+	assert.True(t, store.IsSaved(paths.toml, paths.yaml, paths.env))
+	content, err := os.ReadFile(filepath.Join(store.Dir(), "json", "amazon-cloudwatch-agent.d", "file_config.json"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"agent":{}}`, string(content))
+
+	// a bad config without OTel pipelines is deployed
+	paths.write(t, "bad", "")
+	assert.False(t, store.IsSaved(paths.toml, paths.yaml, paths.env))
+	_, ok := store.RolledBack()
+	assert.False(t, ok)
+
+	require.NoError(t, store.Rollback(errors.New("invalid config"), paths.toml, paths.yaml, paths.env))
+	toml, yaml := paths.read(t)
+	assert.Equal(t, "good", toml)
+	assert.Equal(t, "pipelines", yaml)
+	assert.True(t, store.IsSaved(paths.toml, paths.yaml, paths.env))
+	rollback, ok := store.RolledBack()
+	require.True(t, ok)
+	assert.Equal(t, "invalid config", rollback.Reason)
+	assert.False(t, rollback.Time.IsZero())
+
+	// saving the rolled back config keeps the rollback record
+	require.NoError(t, store.Save(paths.toml, paths.yaml, paths.env, nil))
+	_, ok = store.RolledBack()
+	assert.True(t, ok)
+	_, err = os.Stat(filepath.Join(store.Dir(), "json"))
+	assert.NoError(t, err)
+
+	// a new good config replaces it
+	paths.write(t, "better", "")
+	require.NoError(t, store.Save(paths.toml, paths.yaml, paths.env, nil))
+	_, ok = store.RolledBack()
+	assert.False(t, ok)
+	_, err = os.Stat(filepath.Join(store.Dir(), "json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	paths.write(t, "bad", "pipelines")
+	require.NoError(t, store.Rollback(errors.New("invalid config"), paths.toml, paths.yaml, paths.env))
+	toml, yaml = paths.read(t)
+	assert.Equal(t, "better", toml)
+	assert.Empty(t, yaml, "the YAML config of a translation without OTel pipelines is removed")
+}
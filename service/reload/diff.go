@@ -0,0 +1,154 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package reload
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+)
+
+const (
+	tomlAgentKey     = "agent"
+	tomlInputsKey    = "inputs"
+	tomlRunAsUserKey = "run_as_user"
+	yamlServiceKey   = "service"
+	yamlPipelinesKey = "pipelines"
+)
+
+// Change describes how a translation differs from the running one.
+type Change struct {
+	// Restart is set when the change can only be applied by restarting the agent, i.e. when a
+	// TOML section other than the inputs changed or the OTel pipelines were added or removed.
+	Restart bool
+	// RunAsUser is set when the user the agent runs as changed. The agent is switched to it by
+	// start-amazon-cloudwatch-agent, so it only takes effect when the agent process is started
+	// again, not when the agent is restarted in process.
+	RunAsUser bool
+	// Inputs are the TOML inputs that were added, removed or changed.
+	Inputs []string
+	// Components are the OTel components that were added, removed or changed, e.g.
+	// "receivers/telegraf_cpu" or "service/pipelines/metrics/host".
+	Components []string
+	// Env is set when the env config changed.
+	Env bool
+}
+
+func (c Change) IsEmpty() bool {
+	return !c.Restart && len(c.Inputs) == 0 && len(c.Components) == 0 && !c.Env
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("restart: %t, run_as_user: %t, inputs: %v, components: %v, env: %t", c.Restart, c.RunAsUser, c.Inputs, c.Components, c.Env)
+}
+
+// Diff compares the next translation to the running one.
+func Diff(running, next *cmdutil.Translation) (Change, error) {
+	var change Change
+	runningToml, err := decodeToml(running.Toml)
+	if err != nil {
+		return change, fmt.Errorf("running TOML: %w", err)
+	}
+	nextToml, err := decodeToml(next.Toml)
+	if err != nil {
+		return change, fmt.Errorf("next TOML: %w", err)
+	}
+	for _, key := range changedKeys(runningToml, nextToml) {
+		if key == tomlInputsKey {
+			change.Inputs = changedKeys(asMap(runningToml[key]), asMap(nextToml[key]))
+		} else {
+			change.Restart = true
+		}
+		if key == tomlAgentKey {
+			change.RunAsUser = asMap(runningToml[key])[tomlRunAsUserKey] != asMap(nextToml[key])[tomlRunAsUserKey]
+		}
+	}
+
+	if (running.Yaml == "") != (next.Yaml == "") {
+		change.Restart = true
+	} else {
+		runningYaml, err := decodeYaml(running.Yaml)
+		if err != nil {
+			return change, fmt.Errorf("running YAML: %w", err)
+		}
+		nextYaml, err := decodeYaml(next.Yaml)
+		if err != nil {
+			return change, fmt.Errorf("next YAML: %w", err)
+		}
+		for _, section := range changedKeys(runningYaml, nextYaml) {
+			r, n := asMap(runningYaml[section]), asMap(nextYaml[section])
+			for _, key := range changedKeys(r, n) {
+				if section == yamlServiceKey && key == yamlPipelinesKey {
+					for _, pipeline := range changedKeys(asMap(r[key]), asMap(n[key])) {
+						change.Components = append(change.Components, section+"/"+key+"/"+pipeline)
+					}
+				} else {
+					change.Components = append(change.Components, section+"/"+key)
+				}
+			}
+		}
+	}
+
+	change.Env = !equalEnv(running.Env, next.Env)
+	return change, nil
+}
+
+func decodeToml(content string) (map[string]any, error) {
+	m := make(map[string]any)
+	if _, err := toml.Decode(content, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// decodeYaml decodes the OTel config with the components of its pipelines sorted, so that
+// translations of the same json config compare equal.
+func decodeYaml(content string) (map[string]any, error) {
+	m := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(content), &m); err != nil {
+		return nil, err
+	}
+	pipelines := asMap(asMap(m[yamlServiceKey])[yamlPipelinesKey])
+	for name, pipeline := range pipelines {
+		pipelines[name] = cmdutil.SortPipelineComponents(pipeline)
+	}
+	return m, nil
+}
+
+func equalEnv(a, b []byte) bool {
+	var ma, mb map[string]string
+	if json.Unmarshal(a, &ma) != nil || json.Unmarshal(b, &mb) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(ma, mb)
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// changedKeys returns the sorted keys that are only in one of the maps or have different values.
+func changedKeys(a, b map[string]any) []string {
+	var keys []string
+	for key, va := range a {
+		if vb, ok := b[key]; !ok || !reflect.DeepEqual(va, vb) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,121 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package reload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+)
+
+const (
+	baseToml = `
+[agent]
+  interval = "60s"
+
+[inputs]
+
+  [[inputs.cpu]]
+    fieldpass = ["usage_idle"]
+
+  [[inputs.logfile]]
+    destination = "cloudwatchlogs"
+
+    [[inputs.logfile.file_config]]
+      file_path = "/var/log/app.log"
+      log_group_name = "app"
+
+[outputs]
+
+  [[outputs.cloudwatchlogs]]
+    region = "us-west-2"
+`
+	baseYaml = `
+receivers:
+  telegraf_cpu:
+    collection_interval: 1m0s
+exporters:
+  awscloudwatch:
+    region: us-west-2
+service:
+  pipelines:
+    metrics/host:
+      receivers: [telegraf_cpu, telegraf_mem]
+      processors: [cumulativetodelta, batch]
+      exporters: [awscloudwatch, awsemf]
+  telemetry:
+    logs:
+      level: info
+`
+	baseEnv = `{"CWAGENT_LOG_LEVEL":"INFO"}`
+)
+
+func TestDiff(t *testing.T) {
+	running := &cmdutil.Translation{Toml: baseToml, Yaml: baseYaml, Env: []byte(baseEnv)}
+	testCases := map[string]struct {
+		next *cmdutil.Translation
+		want Change
+	}{
+		"WithSame": {
+			next: &cmdutil.Translation{Toml: baseToml, Yaml: baseYaml, Env: []byte("{\n\t\"CWAGENT_LOG_LEVEL\": \"INFO\"\n}")},
+		},
+		"WithChangedLogFile": {
+			next: &cmdutil.Translation{Toml: replace(baseToml, `log_group_name = "app"`, `log_group_name = "other"`), Yaml: baseYaml, Env: []byte(baseEnv)},
+			want: Change{Inputs: []string{"logfile"}},
+		},
+		"WithAddedInput": {
+			next: &cmdutil.Translation{Toml: baseToml + "\n[[inputs.mem]]\n", Yaml: baseYaml, Env: []byte(baseEnv)},
+			want: Change{Inputs: []string{"mem"}},
+		},
+		"WithChangedAgent": {
+			next: &cmdutil.Translation{Toml: replace(baseToml, `"60s"`, `"10s"`), Yaml: baseYaml, Env: []byte(baseEnv)},
+			want: Change{Restart: true},
+		},
+		"WithChangedRunAsUser": {
+			next: &cmdutil.Translation{Toml: replace(baseToml, `interval = "60s"`, "interval = \"60s\"\n  run_as_user = \"cwagent\""), Yaml: baseYaml, Env: []byte(baseEnv)},
+			want: Change{Restart: true, RunAsUser: true},
+		},
+		"WithChangedOutput": {
+			next: &cmdutil.Translation{Toml: replace(baseToml, `region = "us-west-2"`, `region = "us-east-1"`), Yaml: baseYaml, Env: []byte(baseEnv)},
+			want: Change{Restart: true},
+		},
+		"WithChangedComponents": {
+			next: &cmdutil.Translation{Toml: baseToml, Yaml: replace(replace(baseYaml, "1m0s", "10s"), "level: info", "level: debug"), Env: []byte(baseEnv)},
+			want: Change{Components: []string{"receivers/telegraf_cpu", "service/telemetry"}},
+		},
+		"WithChangedPipeline": {
+			next: &cmdutil.Translation{Toml: baseToml, Yaml: replace(baseYaml, "metrics/host", "metrics/other"), Env: []byte(baseEnv)},
+			want: Change{Components: []string{"service/pipelines/metrics/host", "service/pipelines/metrics/other"}},
+		},
+		"WithPermutedPipelineComponents": {
+			next: &cmdutil.Translation{Toml: baseToml, Yaml: replace(replace(baseYaml, "[telegraf_cpu, telegraf_mem]", "[telegraf_mem, telegraf_cpu]"), "[awscloudwatch, awsemf]", "[awsemf, awscloudwatch]"), Env: []byte(baseEnv)},
+		},
+		"WithReorderedProcessors": {
+			next: &cmdutil.Translation{Toml: baseToml, Yaml: replace(baseYaml, "[cumulativetodelta, batch]", "[batch, cumulativetodelta]"), Env: []byte(baseEnv)},
+			want: Change{Components: []string{"service/pipelines/metrics/host"}},
+		},
+		"WithRemovedPipelines": {
+			next: &cmdutil.Translation{Toml: baseToml, Env: []byte(baseEnv)},
+			want: Change{Restart: true},
+		},
+		"WithChangedEnv": {
+			next: &cmdutil.Translation{Toml: baseToml, Yaml: baseYaml, Env: []byte(`{"CWAGENT_LOG_LEVEL":"DEBUG"}`)},
+			want: Change{Env: true},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Diff(running, testCase.next)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+			assert.Equal(t, testCase.want.IsEmpty(), got.IsEmpty())
+		})
+	}
+
+	_, err := Diff(running, &cmdutil.Translation{Toml: "[agent"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,243 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package reload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/constants"
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+)
+
+var (
+	// pollInterval is how often the json config is polled when it cannot be watched.
+	pollInterval = 30 * time.Second
+	// fallbackPollInterval is how often the watched json config is polled, in case a change
+	// was missed by the watch.
+	fallbackPollInterval = 5 * time.Minute
+	// debounceDelay is how long the events of a change are collected before it is checked, since
+	// writing a file usually raises several events.
+	debounceDelay = time.Second
+)
+
+// Agent is the running agent the changed translation is applied to.
+type Agent interface {
+	// Apply applies a change that does not require a restart. The translation has already
+	// been written when it is called.
+	Apply(Change) error
+	// Restart restarts the agent with the written translation.
+	Restart()
+}
+
+// Reloader watches the json config file and directory. When they change, it translates them in
+// process, writes the translation and applies the difference to the running agent. The running
+// config is kept if the changed json config cannot be translated.
+type Reloader struct {
+	paths       []string
+	translate   func() (*cmdutil.Translation, error)
+	write       func(*cmdutil.Translation) error
+	agent       Agent
+	running     *cmdutil.Translation
+	fingerprint string
+}
+
+func NewReloader(paths []string, running *cmdutil.Translation, translate func() (*cmdutil.Translation, error), write func(*cmdutil.Translation) error, agent Agent) *Reloader {
+	return &Reloader{
+		paths:     paths,
+		translate: translate,
+		write:     write,
+		agent:     agent,
+		running:   running,
+	}
+}
+
+// Run watches for changes until the context is done. The json config is also polled, in case
+// a change was missed or it cannot be watched.
+func (r *Reloader) Run(ctx context.Context) {
+	var err error
+	if r.fingerprint, err = fingerprint(r.paths); err != nil {
+		log.Printf("E! [reload] Unable to read json config: %v", err)
+	}
+	interval := fallbackPollInterval
+	var events <-chan fsnotify.Event
+	var watchErrors <-chan error
+	watcher, err := r.watch()
+	if err != nil {
+		log.Printf("W! [reload] Unable to watch json config, polling it every %v: %v", pollInterval, err)
+		interval = pollInterval
+	} else {
+		defer watcher.Close()
+		events, watchErrors = watcher.Events, watcher.Errors
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var debounce <-chan time.Time
+	for {
+		select {
+		case event := <-events:
+			if !r.isWatched(event.Name) {
+				continue
+			}
+			if event.Op.Has(fsnotify.Create) {
+				// watch the directories created in the config directory
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err = addDirs(watcher, event.Name); err != nil {
+						log.Printf("W! [reload] Unable to watch %s: %v", event.Name, err)
+					}
+				}
+			}
+			if debounce == nil {
+				debounce = time.After(debounceDelay)
+			}
+		case err := <-watchErrors:
+			log.Printf("W! [reload] Error watching json config: %v", err)
+		case <-debounce:
+			debounce = nil
+			r.check()
+		case <-ticker.C:
+			r.check()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// watch returns a watcher of the directories of the paths. The parent directory of a file, or of
+// a missing directory, is watched, so that the path is still watched after it is replaced.
+func (r *Reloader) watch() (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range r.paths {
+		if info, statErr := os.Stat(path); statErr == nil && info.IsDir() {
+			err = addDirs(watcher, path)
+		} else {
+			err = watcher.Add(filepath.Dir(path))
+		}
+		if err != nil {
+			watcher.Close()
+			return nil, err
+		}
+	}
+	return watcher, nil
+}
+
+// isWatched reports whether the name is one of the paths or below one of them.
+func (r *Reloader) isWatched(name string) bool {
+	for _, path := range r.paths {
+		if name == path || strings.HasPrefix(name, path+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// addDirs watches the directory and its subdirectories, whose json config files are also
+// translated.
+func addDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		return watcher.Add(path)
+	})
+}
+
+func (r *Reloader) check() {
+	fp, err := fingerprint(r.paths)
+	if err != nil {
+		log.Printf("E! [reload] Unable to read json config: %v", err)
+		return
+	}
+	if fp == r.fingerprint {
+		return
+	}
+	r.fingerprint = fp
+
+	log.Printf("I! [reload] json config changed, translating it")
+	next, err := r.translate()
+	if err != nil {
+		log.Printf("E! [reload] Unable to translate json config, keeping the running config: %v", err)
+		return
+	}
+	change, err := Diff(r.running, next)
+	if err != nil {
+		log.Printf("E! [reload] Unable to compare translated config, keeping the running config: %v", err)
+		return
+	}
+	if change.IsEmpty() {
+		log.Printf("I! [reload] Translated config did not change")
+		return
+	}
+	if err = r.write(next); err != nil {
+		log.Printf("E! [reload] Unable to write translated config, keeping the running config: %v", err)
+		return
+	}
+	r.running = next
+	log.Printf("I! [reload] Translated config changed, %v", change)
+	if change.RunAsUser {
+		log.Printf("W! [reload] run_as_user changed, the agent keeps running as the same user until its service is restarted")
+	}
+
+	if !change.Restart {
+		if err = r.agent.Apply(change); err == nil {
+			log.Printf("I! [reload] Applied changed config")
+			return
+		}
+		log.Printf("W! [reload] Unable to apply changed config, restarting the agent: %v", err)
+	}
+	r.agent.Restart()
+}
+
+// fingerprint returns a hash of the names and contents of the json config files in the given
+// paths. Missing paths are skipped, and so are the YAML and .tmp files in the directories,
+// which are not translated.
+func fingerprint(paths []string) (string, error) {
+	h := sha256.New()
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || (path != root && (strings.HasSuffix(path, constants.FileSuffixTmp) || strings.HasSuffix(path, constants.FileSuffixYAML))) {
+				return nil
+			}
+			if d.Type()&fs.ModeSymlink != 0 {
+				if info, err := os.Stat(path); err != nil || info.IsDir() {
+					return nil
+				}
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			contentHash := sha256.Sum256(content)
+			h.Write([]byte(path))
+			h.Write(contentHash[:])
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,199 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package reload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+)
+
+type stubAgent struct {
+	applied  []Change
+	applyErr error
+	restarts int
+}
+
+func (a *stubAgent) Apply(change Change) error {
+	a.applied = append(a.applied, change)
+	return a.applyErr
+}
+
+func (a *stubAgent) Restart() {
+	a.restarts++
+}
+
+func replace(s, old, new string) string {
+	return strings.Replace(s, old, new, 1)
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "config.json")
+	jsonDir := filepath.Join(dir, "config.d")
+	require.NoError(t, os.Mkdir(jsonDir, 0700))
+	require.NoError(t, os.WriteFile(jsonPath, []byte("{}"), 0600))
+
+	running := &cmdutil.Translation{Toml: baseToml, Yaml: baseYaml, Env: []byte(baseEnv)}
+	next := running
+	var translateErr error
+	var written []*cmdutil.Translation
+	agent := &stubAgent{}
+	r := NewReloader(
+		[]string{jsonPath, jsonDir},
+		running,
+		func() (*cmdutil.Translation, error) { return next, translateErr },
+		func(translation *cmdutil.Translation) error {
+			written = append(written, translation)
+			return nil
+		},
+		agent,
+	)
+	var err error
+	r.fingerprint, err = fingerprint(r.paths)
+	require.NoError(t, err)
+
+	// nothing changed
+	r.check()
+	assert.Empty(t, written)
+
+	// files that are not translated are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(jsonDir, "otel.yaml"), []byte("receivers:"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(jsonDir, "new.json.tmp"), []byte("{}"), 0600))
+	next = &cmdutil.Translation{Toml: replace(baseToml, `"60s"`, `"10s"`), Yaml: baseYaml, Env: []byte(baseEnv)}
+	r.check()
+	assert.Empty(t, written)
+
+	// the translation did not change
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"agent":{}}`), 0600))
+	next = running
+	r.check()
+	assert.Empty(t, written)
+	assert.Empty(t, agent.applied)
+
+	// translation fails
+	require.NoError(t, os.WriteFile(filepath.Join(jsonDir, "logs.json"), []byte("{"), 0600))
+	translateErr = errors.New("invalid")
+	r.check()
+	assert.Empty(t, written)
+	translateErr = nil
+
+	// input changed
+	require.NoError(t, os.WriteFile(filepath.Join(jsonDir, "logs.json"), []byte("{}"), 0600))
+	next = &cmdutil.Translation{Toml: replace(baseToml, `log_group_name = "app"`, `log_group_name = "other"`), Yaml: baseYaml, Env: []byte(baseEnv)}
+	r.check()
+	assert.Equal(t, []*cmdutil.Translation{next}, written)
+	assert.Equal(t, []Change{{Inputs: []string{"logfile"}}}, agent.applied)
+	assert.Equal(t, 0, agent.restarts)
+	assert.Same(t, next, r.running)
+
+	// changes that cannot be applied restart the agent
+	require.NoError(t, os.Remove(filepath.Join(jsonDir, "logs.json")))
+	next = &cmdutil.Translation{Toml: baseToml, Yaml: baseYaml, Env: []byte(baseEnv)}
+	agent.applyErr = errors.New("not reloadable")
+	r.check()
+	assert.Len(t, agent.applied, 2)
+	assert.Equal(t, 1, agent.restarts)
+
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"agent":{"interval":"10s"}}`), 0600))
+	next = &cmdutil.Translation{Toml: replace(baseToml, `"60s"`, `"10s"`), Yaml: baseYaml, Env: []byte(baseEnv)}
+	r.check()
+	assert.Len(t, agent.applied, 2)
+	assert.Equal(t, 2, agent.restarts)
+	assert.Len(t, written, 3)
+}
+
+func TestReloaderRun(t *testing.T) {
+	defer func(poll, fallback, debounce time.Duration) {
+		pollInterval, fallbackPollInterval, debounceDelay = poll, fallback, debounce
+	}(pollInterval, fallbackPollInterval, debounceDelay)
+	pollInterval, fallbackPollInterval, debounceDelay = time.Hour, time.Hour, 10*time.Millisecond
+
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "config.json")
+	jsonDir := filepath.Join(dir, "config.d")
+	require.NoError(t, os.Mkdir(jsonDir, 0700))
+	require.NoError(t, os.WriteFile(jsonPath, []byte("{}"), 0600))
+
+	running := &cmdutil.Translation{Toml: baseToml, Yaml: baseYaml, Env: []byte(baseEnv)}
+	translated := make(chan struct{}, 10)
+	r := NewReloader(
+		[]string{jsonPath, jsonDir},
+		running,
+		func() (*cmdutil.Translation, error) {
+			translated <- struct{}{}
+			return running, nil
+		},
+		func(*cmdutil.Translation) error { return nil },
+		&stubAgent{},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	waitTranslated := func(name string) {
+		t.Helper()
+		select {
+		case <-translated:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("change to %s was not translated", name)
+		}
+	}
+	// wait for the watch to be set up before changing the files
+	require.Eventually(t, func() bool {
+		require.NoError(t, os.WriteFile(jsonPath, []byte(fmt.Sprintf(`{"agent":{"interval":"%ds"}}`, time.Now().UnixNano())), 0600))
+		select {
+		case <-translated:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+	drain := func() {
+		time.Sleep(100 * time.Millisecond)
+		for len(translated) > 0 {
+			<-translated
+		}
+	}
+	drain()
+
+	// the file is replaced
+	tmpPath := filepath.Join(dir, "config.json.new")
+	require.NoError(t, os.WriteFile(tmpPath, []byte(`{"agent":{}}`), 0600))
+	require.NoError(t, os.Rename(tmpPath, jsonPath))
+	waitTranslated(jsonPath)
+
+	// a file is added in a new subdirectory
+	subDir := filepath.Join(jsonDir, "logs")
+	require.NoError(t, os.Mkdir(subDir, 0700))
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, os.WriteFile(filepath.Join(subDir, "logs.json"), []byte("{}"), 0600))
+	waitTranslated(subDir)
+	drain()
+
+	// other files next to the config file are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "agent.toml"), []byte(baseToml), 0600))
+	select {
+	case <-translated:
+		t.Fatal("unexpected translation")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
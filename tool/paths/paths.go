@@ -10,6 +10,7 @@ const (
 	YAML           = "amazon-cloudwatch-agent.yaml"
 	ENV            = "env-config.json"
 	AGENT_LOG_FILE = "amazon-cloudwatch-agent.log"
+	ADMIN_SOCKET   = "amazon-cloudwatch-agent.sock"
 	JMXJarName     = "opentelemetry-jmx-metrics.jar"
 )
 
@@ -21,6 +22,7 @@ var (
 	CommonConfigPath     string
 	YamlConfigPath       string
 	AgentLogFilePath     string
+	AdminSocketPath      string
 	TranslatorBinaryPath string
 	AgentBinaryPath      string
 	JMXJarPath           string
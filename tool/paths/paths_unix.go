@@ -28,6 +28,7 @@ func init() {
 	CommonConfigPath = filepath.Join(AgentDir, "etc", COMMON_CONFIG)
 	YamlConfigPath = filepath.Join(AgentDir, "etc", YAML)
 	AgentLogFilePath = filepath.Join(AgentDir, "logs", AGENT_LOG_FILE)
+	AdminSocketPath = filepath.Join(AgentDir, "var", ADMIN_SOCKET)
 	TranslatorBinaryPath = filepath.Join(AgentDir, "bin", TranslatorBinaryName)
 	AgentBinaryPath = filepath.Join(AgentDir, "bin", AgentBinaryName)
 	JMXJarPath = filepath.Join(AgentDir, "bin", JMXJarName)
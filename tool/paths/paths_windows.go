@@ -50,6 +50,7 @@ func init() {
 	YamlConfigPath = filepath.Join(AgentConfigDir, YAML)
 	CommonConfigPath = filepath.Join(AgentConfigDir, COMMON_CONFIG)
 	AgentLogFilePath = filepath.Join(AgentConfigDir, AGENT_LOG_FILE)
+	AdminSocketPath = filepath.Join(AgentConfigDir, ADMIN_SOCKET)
 	TranslatorBinaryPath = filepath.Join(AgentRootDir, TranslatorBinaryName)
 	AgentBinaryPath = filepath.Join(AgentRootDir, AgentBinaryName)
 	JMXJarPath = filepath.Join(AgentRootDir, JMXJarName)
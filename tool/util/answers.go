@@ -0,0 +1,151 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Answers answer the wizard questions instead of stdin when the wizard runs non-interactively.
+// They are keyed by the question as it is printed by the wizard. Each answer is used once, so a
+// question asked several times, e.g. "Log file path:" for every monitored log file, is answered
+// with a list. An empty answer chooses the default.
+type Answers struct {
+	answers map[string][]string
+	used    map[string]int
+}
+
+// UnansweredError is the panic value of a question asked non-interactively without an answer.
+type UnansweredError struct {
+	Question string
+	// Count is how many times the question was asked.
+	Count int
+}
+
+func (e *UnansweredError) Error() string {
+	if e.Count > 1 {
+		return fmt.Sprintf("no answer for question %q asked %d times", e.Question, e.Count)
+	}
+	return fmt.Sprintf("no answer for question %q", e.Question)
+}
+
+// InvalidAnswerError is the panic value of an answer that is not one of the valid values.
+type InvalidAnswerError struct {
+	Question    string
+	Answer      string
+	ValidValues []string
+}
+
+func (e *InvalidAnswerError) Error() string {
+	return fmt.Sprintf("answer %q is not valid for question %q, valid values are 1-%d or %s",
+		e.Answer, e.Question, len(e.ValidValues), strings.Join(e.ValidValues, ", "))
+}
+
+var answers *Answers
+
+// SetAnswers makes the wizard questions use the answers instead of stdin. Nil restores stdin.
+func SetAnswers(a *Answers) {
+	answers = a
+}
+
+// ReadAnswers reads a YAML or JSON answers file mapping questions to an answer or a list of
+// answers.
+func ReadAnswers(path string) (*Answers, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	a, err := ParseAnswers(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return a, nil
+}
+
+// ParseAnswers parses YAML or JSON answers. Booleans are yes and no answers.
+func ParseAnswers(content []byte) (*Answers, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	a := &Answers{answers: map[string][]string{}, used: map[string]int{}}
+	for question, value := range raw {
+		var values []interface{}
+		if list, ok := value.([]interface{}); ok {
+			values = list
+		} else {
+			values = []interface{}{value}
+		}
+		for _, value := range values {
+			answer, err := answerString(value)
+			if err != nil {
+				return nil, fmt.Errorf("question %q: %w", question, err)
+			}
+			a.answers[question] = append(a.answers[question], answer)
+		}
+	}
+	return a, nil
+}
+
+func answerString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		if v {
+			return "yes", nil
+		}
+		return "no", nil
+	case int, float64:
+		return fmt.Sprint(v), nil
+	}
+	return "", fmt.Errorf("answer must be a string, number, boolean or a list of them, got %T", value)
+}
+
+// next returns the next answer to the question, or panics with an UnansweredError.
+func (a *Answers) next(question string) string {
+	used := a.used[question]
+	a.used[question] = used + 1
+	if used >= len(a.answers[question]) {
+		panic(&UnansweredError{Question: question, Count: used + 1})
+	}
+	return a.answers[question][used]
+}
+
+// Unused returns the questions with answers that were not used, which usually are misspelled.
+func (a *Answers) Unused() []string {
+	var unused []string
+	for question, list := range a.answers {
+		if a.used[question] < len(list) {
+			unused = append(unused, question)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// option returns the index of the answer in the valid values. The answer is a value, or else
+// the number of a value starting from 1, so that e.g. retention days are not taken as numbers.
+func option(question, answer string, defaultOption int, validValues []string) int {
+	if answer == "" && defaultOption > 0 && defaultOption <= len(validValues) {
+		return defaultOption - 1
+	}
+	for i, value := range validValues {
+		if strings.EqualFold(value, answer) {
+			return i
+		}
+	}
+	if number, err := strconv.Atoi(answer); err == nil && number > 0 && number <= len(validValues) {
+		return number - 1
+	}
+	panic(&InvalidAnswerError{Question: question, Answer: answer, ValidValues: validValues})
+}
@@ -0,0 +1,117 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setAnswers(t *testing.T, content string) *Answers {
+	t.Helper()
+	a, err := ParseAnswers([]byte(content))
+	require.NoError(t, err)
+	SetAnswers(a)
+	t.Cleanup(func() { SetAnswers(nil) })
+	return a
+}
+
+func TestParseAnswers(t *testing.T) {
+	testCases := map[string]struct {
+		content string
+		want    map[string][]string
+		wantErr string
+	}{
+		"WithYaml": {
+			content: `
+"Do you want to monitor any log files?": true
+"Log file path:": [/var/log/a.log, /var/log/b.log]
+"Log Group Retention in days": 7
+"Log stream name:":
+`,
+			want: map[string][]string{
+				"Do you want to monitor any log files?": {"yes"},
+				"Log file path:":                        {"/var/log/a.log", "/var/log/b.log"},
+				"Log Group Retention in days":           {"7"},
+				"Log stream name:":                      {""},
+			},
+		},
+		"WithJson": {
+			content: `{"Do you want to turn on StatsD daemon?": "no", "Which port do you want StatsD daemon to listen to?": [8125]}`,
+			want: map[string][]string{
+				"Do you want to turn on StatsD daemon?":              {"no"},
+				"Which port do you want StatsD daemon to listen to?": {"8125"},
+			},
+		},
+		"WithObjectAnswer": {
+			content: `"Log file path:": {path: /var/log/a.log}`,
+			wantErr: `question "Log file path:": answer must be a string, number, boolean or a list of them`,
+		},
+		"WithInvalidSyntax": {
+			content: `"Log file path:": [`,
+			wantErr: "yaml",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseAnswers([]byte(testCase.content))
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got.answers)
+		})
+	}
+}
+
+func TestReadAnswers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"Log file path:": {}}`), 0600))
+	_, err := ReadAnswers(path)
+	assert.ErrorContains(t, err, path)
+}
+
+func TestAnswers(t *testing.T) {
+	a := setAnswers(t, `
+"Do you want to monitor any log files?": yes
+"Log file path:": [/var/log/a.log, /var/log/b.log]
+"Log group name:": ["", b]
+"Log group class:": [2, STANDARD]
+"Log Group Retention in days": 3
+"Which of the configurations would you like to import?": 2
+"Misspelled question?": yes
+`)
+	assert.True(t, Yes("Do you want to monitor any log files?"))
+	assert.Equal(t, "/var/log/a.log", Ask("Log file path:"))
+	assert.Equal(t, "/var/log/b.log", Ask("Log file path:"))
+	assert.Equal(t, "a.log", AskWithDefault("Log group name:", "a.log"))
+	assert.Equal(t, "b", AskWithDefault("Log group name:", "b.log"))
+	classes := []string{StandardLogGroupClass, InfrequentAccessLogGroupClass}
+	assert.Equal(t, InfrequentAccessLogGroupClass, Choice("Log group class:", 1, classes))
+	assert.Equal(t, StandardLogGroupClass, Choice("Log group class:", 1, classes))
+	// the answer is the value rather than the number of the value
+	assert.Equal(t, "3", Choice("Log Group Retention in days", 1, []string{"-1", "1", "3", "5"}))
+	assert.Equal(t, 1, ChoiceIndex("Which of the configurations would you like to import?", 1, []string{"a", "b"}))
+	EnterToExit()
+	assert.Equal(t, []string{"Misspelled question?"}, a.Unused())
+
+	assert.PanicsWithError(t, `no answer for question "Log file path:" asked 3 times`, func() {
+		Ask("Log file path:")
+	})
+	assert.PanicsWithError(t, `no answer for question "Do you want to monitor any host metrics?"`, func() {
+		Yes("Do you want to monitor any host metrics?")
+	})
+}
+
+func TestAnswersInvalid(t *testing.T) {
+	setAnswers(t, `"Log group class:": GLACIER`)
+	assert.PanicsWithError(t, `answer "GLACIER" is not valid for question "Log group class:", valid values are 1-2 or STANDARD, INFREQUENT_ACCESS`, func() {
+		Choice("Log group class:", 1, []string{StandardLogGroupClass, InfrequentAccessLogGroupClass})
+	})
+}
@@ -257,6 +257,12 @@ func No(question string) bool {
 }
 
 func AskWithDefault(question, defaultValue string) string {
+	if answers != nil {
+		if answer := answers.next(question); answer != "" {
+			return answer
+		}
+		return defaultValue
+	}
 	for {
 		var answer string
 		fmt.Printf("%s\ndefault choice: [%s]\n\r", question, defaultValue)
@@ -276,6 +282,13 @@ func Ask(question string) string {
 
 // defaultOption value starts from 1
 func Choice(question string, defaultOption int, validValues []string) string {
+	if answers != nil {
+		answer := answers.next(question)
+		if validValues == nil {
+			return answer
+		}
+		return validValues[option(question, answer, defaultOption, validValues)]
+	}
 	for {
 		var answer string
 		options := ""
@@ -310,6 +323,9 @@ func Choice(question string, defaultOption int, validValues []string) string {
 
 // ChoiceIndex returns index of choice chosen
 func ChoiceIndex(question string, defaultOption int, validValues []string) int {
+	if answers != nil {
+		return option(question, answers.next(question), defaultOption, validValues)
+	}
 	for {
 		var answer string
 		options := ""
@@ -334,6 +350,9 @@ func ChoiceIndex(question string, defaultOption int, validValues []string) int {
 	}
 }
 func EnterToExit() {
+	if answers != nil {
+		return
+	}
 	fmt.Println("Please press Enter to exit...")
 	stdin.Scanln()
 }
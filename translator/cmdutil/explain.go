@@ -0,0 +1,207 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cmdutil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	collectedKeySuffix = "_collected"
+	yamlServiceKey     = "service"
+	yamlPipelinesKey   = "pipelines"
+)
+
+// explainedSections are the json config sections whose keys are explained. The keys ending in
+// "_collected" are explained per child, e.g. "metrics.metrics_collected.cpu".
+var explainedSections = []string{"metrics", "logs", "traces"}
+
+// tomlPluginSections are the TOML sections whose plugins are listed separately.
+var tomlPluginSections = map[string]bool{"inputs": true, "outputs": true, "processors": true, "aggregators": true}
+
+// Explanation is what a json config key produces in the translation.
+type Explanation struct {
+	// Key is the dotted path of the json config key, e.g. "metrics.metrics_collected.cpu".
+	Key string
+	// Produces are the TOML plugins and OTel components that are only translated because of
+	// the key, e.g. "inputs.cpu" or "receivers/telegraf_cpu".
+	Produces []string
+	// Changes are the TOML plugins and OTel components that are translated differently
+	// because of the key, e.g. "service/pipelines/metrics/host" for a pipeline it adds a
+	// receiver to.
+	Changes []string
+	// Err is set when the json config cannot be translated without the key, in which case
+	// nothing can be attributed to it.
+	Err error
+}
+
+// Explain attributes the translation of the merged json config map to its keys. Each key is
+// removed from the config in turn, and the TOML plugins and OTel components that disappear or
+// change in the translation of the rest are what the key produces.
+func Explain(jsonConfigMap map[string]interface{}) ([]Explanation, error) {
+	full, err := TranslateJsonMap(copyJsonValue(jsonConfigMap).(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	fullComponents, err := translationComponents(full)
+	if err != nil {
+		return nil, err
+	}
+	var explanations []Explanation
+	for _, path := range explainedKeys(jsonConfigMap) {
+		explanation := Explanation{Key: strings.Join(path, ".")}
+		without := copyJsonValue(jsonConfigMap).(map[string]interface{})
+		removeJsonKey(without, path)
+		var components map[string]any
+		t, err := TranslateJsonMap(without)
+		if err == nil {
+			components, err = translationComponents(t)
+		}
+		if err != nil {
+			explanation.Err = err
+		} else {
+			for id, value := range fullComponents {
+				if other, ok := components[id]; !ok {
+					explanation.Produces = append(explanation.Produces, id)
+				} else if !reflect.DeepEqual(value, other) {
+					explanation.Changes = append(explanation.Changes, id)
+				}
+			}
+			sort.Strings(explanation.Produces)
+			sort.Strings(explanation.Changes)
+		}
+		explanations = append(explanations, explanation)
+	}
+	return explanations, nil
+}
+
+// explainedKeys returns the sorted paths of the keys in the explained sections.
+func explainedKeys(jsonConfigMap map[string]interface{}) [][]string {
+	var paths [][]string
+	for _, section := range explainedSections {
+		sectionMap, ok := jsonConfigMap[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range sortedKeys(sectionMap) {
+			collected, ok := sectionMap[key].(map[string]interface{})
+			if !ok || !strings.HasSuffix(key, collectedKeySuffix) {
+				paths = append(paths, []string{section, key})
+				continue
+			}
+			for _, child := range sortedKeys(collected) {
+				paths = append(paths, []string{section, key, child})
+			}
+		}
+	}
+	return paths
+}
+
+// translationComponents flattens the translation into its TOML plugins and sections, and its
+// OTel components and pipelines.
+func translationComponents(t *Translation) (map[string]any, error) {
+	components := make(map[string]any)
+	tomlConfig := make(map[string]any)
+	if _, err := toml.Decode(t.Toml, &tomlConfig); err != nil {
+		return nil, fmt.Errorf("unable to decode TOML: %w", err)
+	}
+	for section, value := range tomlConfig {
+		plugins, ok := value.(map[string]any)
+		if !ok || !tomlPluginSections[section] {
+			components[section] = value
+			continue
+		}
+		for name, plugin := range plugins {
+			components[section+"."+name] = plugin
+		}
+	}
+	yamlConfig := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(t.Yaml), &yamlConfig); err != nil {
+		return nil, fmt.Errorf("unable to decode YAML: %w", err)
+	}
+	for section, value := range yamlConfig {
+		sectionMap, ok := value.(map[string]any)
+		if !ok {
+			components[section] = value
+			continue
+		}
+		for key, component := range sectionMap {
+			pipelines, ok := component.(map[string]any)
+			if section != yamlServiceKey || key != yamlPipelinesKey || !ok {
+				components[section+"/"+key] = component
+				continue
+			}
+			for name, pipeline := range pipelines {
+				components[section+"/"+key+"/"+name] = SortPipelineComponents(pipeline)
+			}
+		}
+	}
+	return components, nil
+}
+
+// SortPipelineComponents sorts the receivers and exporters of a decoded OTel pipeline, whose
+// order can differ between translations of the same json config. The order of the processors
+// matters.
+func SortPipelineComponents(pipeline any) any {
+	pipelineMap, ok := pipeline.(map[string]any)
+	if !ok {
+		return pipeline
+	}
+	for _, key := range []string{"receivers", "exporters"} {
+		ids, ok := pipelineMap[key].([]any)
+		if !ok {
+			continue
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			return fmt.Sprint(ids[i]) < fmt.Sprint(ids[j])
+		})
+	}
+	return pipelineMap
+}
+
+func removeJsonKey(jsonConfigMap map[string]interface{}, path []string) {
+	m := jsonConfigMap
+	for _, key := range path[:len(path)-1] {
+		var ok bool
+		if m, ok = m[key].(map[string]interface{}); !ok {
+			return
+		}
+	}
+	delete(m, path[len(path)-1])
+}
+
+// copyJsonValue deep copies a value decoded from json, since the translators may modify it.
+func copyJsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			m[key] = copyJsonValue(child)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, child := range v {
+			s[i] = copyJsonValue(child)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,82 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cmdutil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	translatorUtil "github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+func TestExplain(t *testing.T) {
+	region, credentialsPath := translatorUtil.DetectRegion, translatorUtil.DetectCredentialsPath
+	translatorUtil.DetectRegion = func(string, map[string]string) (string, string) {
+		return "us-west-2", "ACJ"
+	}
+	translatorUtil.DetectCredentialsPath = func() string {
+		return "fake-path"
+	}
+	t.Cleanup(func() {
+		translatorUtil.DetectRegion, translatorUtil.DetectCredentialsPath = region, credentialsPath
+		context.ResetContext()
+	})
+	context.ResetContext()
+	ctx := context.CurrentContext()
+	ctx.SetOs(config.OS_TYPE_LINUX)
+	ctx.SetMode(config.ModeOnPrem)
+
+	var jsonConfigMap map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "metrics": {
+    "append_dimensions": {"InstanceId": "${aws:InstanceId}"},
+    "metrics_collected": {
+      "cpu": {"measurement": ["usage_idle"]},
+      "mem": {"measurement": ["used"]}
+    }
+  },
+  "logs": {
+    "logs_collected": {
+      "files": {
+        "collect_list": [{"file_path": "/var/log/app.log", "log_group_name": "app"}]
+      }
+    }
+  }
+}`), &jsonConfigMap))
+
+	got, err := Explain(jsonConfigMap)
+	require.NoError(t, err)
+	want := []Explanation{
+		{
+			Key:      "metrics.append_dimensions",
+			Produces: []string{"processors/ec2tagger"},
+			Changes:  []string{"service/pipelines/metrics/host"},
+		},
+		{
+			Key:      "metrics.metrics_collected.cpu",
+			Produces: []string{"inputs.cpu", "receivers/telegraf_cpu"},
+			Changes:  []string{"service/pipelines/metrics/host"},
+		},
+		{
+			Key:      "metrics.metrics_collected.mem",
+			Produces: []string{"inputs.mem", "receivers/telegraf_mem"},
+			Changes:  []string{"service/pipelines/metrics/host"},
+		},
+		{
+			Key:      "logs.logs_collected.files",
+			Produces: []string{"inputs.logfile"},
+		},
+	}
+	assert.Equal(t, want, got)
+	// the input is left unchanged
+	assert.Contains(t, jsonConfigMap["metrics"].(map[string]interface{})["metrics_collected"], "cpu")
+
+	_, err = Explain(map[string]interface{}{"metrics": "invalid"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,185 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cmdutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/commonconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/toenvconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/totomlconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/tocwconfig/toyamlconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline"
+	translatorUtil "github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+var ErrNoJsonConfig = errors.New("no json config files found")
+
+// Translation is the TOML, YAML and env config translated from the json config.
+type Translation struct {
+	Toml string
+	// Yaml is empty when the json config has no OTel pipelines.
+	Yaml string
+	Env  []byte
+	// Fragments are the json config files the translation was merged from. They are not read
+	// back with the rest of the translation.
+	Fragments []*jsonconfig.Fragment
+}
+
+// LoadCommonConfig sets the credentials, proxy and SSL settings of the common-config file
+// on the context and exports the proxy and SSL settings to the environment. An empty path
+// only exports the settings already on the context.
+func LoadCommonConfig(ctx *context.Context, commonConfigPath string) error {
+	if commonConfigPath != "" {
+		f, err := os.Open(commonConfigPath)
+		if err != nil {
+			return fmt.Errorf("failed to open common-config file %s with error: %v", commonConfigPath, err)
+		}
+		defer f.Close()
+		conf, err := commonconfig.Parse(f)
+		if err != nil {
+			return fmt.Errorf("failed to parse common-config file %s with error: %v", commonConfigPath, err)
+		}
+		ctx.SetCredentials(conf.CredentialsMap())
+		ctx.SetProxy(conf.ProxyMap())
+		ctx.SetSSL(conf.SSLMap())
+		translatorUtil.LoadImdsRetries(conf.IMDS)
+	}
+	translatorUtil.SetProxyEnv(ctx.Proxy())
+	translatorUtil.SetSSLEnv(ctx.SSL())
+	return nil
+}
+
+// TranslateJsonConfig runs the same translation as the config-translator using the given
+// context, but in process. The translator state left over from a previous run is reset and
+// invalid input is returned as an error instead of exiting.
+func TranslateJsonConfig(ctx *context.Context) (t *Translation, err error) {
+	resetTranslator()
+	defer recoverTranslation(&err)
+
+	jsonConfigMapMap, skipped, err := getJsonConfigMapMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(jsonConfigMapMap) == 0 && len(skipped) == 0 {
+		return nil, ErrNoJsonConfig
+	}
+	mergedJsonConfigMap, fragments, err := mergeJsonConfigMapMap(ctx, jsonConfigMapMap, skipped)
+	if err != nil {
+		return nil, err
+	}
+	if t, err = translateJsonMap(mergedJsonConfigMap); err != nil {
+		return nil, err
+	}
+	t.Fragments = fragments
+	return t, nil
+}
+
+// TranslateJsonMap translates an already merged json config map in process. Like
+// TranslateJsonConfig, it resets the translator state and returns invalid input as an error.
+func TranslateJsonMap(jsonConfigMap map[string]interface{}) (t *Translation, err error) {
+	resetTranslator()
+	defer recoverTranslation(&err)
+	return translateJsonMap(jsonConfigMap)
+}
+
+func resetTranslator() {
+	translator.ResetMessages()
+	agent.Global_Config = *new(agent.Agent)
+	logs.GlobalLogConfig = logs.Logs{}
+	metrics.GlobalMetricConfig = metrics.Metrics{}
+}
+
+func recoverTranslation(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%v %v", r, translator.ErrorMessages)
+	}
+}
+
+func translateJsonMap(jsonConfigMap map[string]interface{}) (*Translation, error) {
+	tomlConfig, err := TranslateJsonMapToTomlConfig(jsonConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	t := &Translation{
+		Toml: totomlconfig.ToTomlConfig(tomlConfig),
+		Env:  toenvconfig.ToEnvConfig(jsonConfigMap),
+	}
+	yamlConfig, err := TranslateJsonMapToYamlConfig(jsonConfigMap)
+	if err != nil && !errors.Is(err, pipeline.ErrNoPipelines) {
+		return nil, err
+	}
+	if err == nil {
+		if res := toyamlconfig.ToYamlConfig(yamlConfig); strings.TrimSpace(res) != "null" {
+			t.Yaml = res
+		}
+	}
+	return t, nil
+}
+
+// ReadTranslation reads a translation previously written to the given paths. Missing YAML
+// and env config files are left empty.
+func ReadTranslation(tomlConfigPath, yamlConfigPath, envConfigPath string) (*Translation, error) {
+	tomlConfig, err := os.ReadFile(tomlConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	t := &Translation{Toml: string(tomlConfig)}
+	if yamlConfig, err := os.ReadFile(yamlConfigPath); err == nil {
+		t.Yaml = string(yamlConfig)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if envConfig, err := os.ReadFile(envConfigPath); err == nil {
+		t.Env = envConfig
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Write writes the translation to the given paths. The YAML file is removed if the
+// translation has no OTel pipelines.
+func (t *Translation) Write(tomlConfigPath, yamlConfigPath, envConfigPath string) error {
+	if err := os.WriteFile(envConfigPath, t.Env, fileMode); err != nil {
+		return err
+	}
+	if t.Yaml == "" {
+		if err := os.Remove(yamlConfigPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	} else if err := os.WriteFile(yamlConfigPath, []byte(t.Yaml), fileMode); err != nil {
+		return err
+	}
+	return os.WriteFile(tomlConfigPath, []byte(t.Toml), fileMode)
+}
+
+// FragmentsPath is the path of the report of the json config fragments written next to the
+// TOML config.
+func FragmentsPath(tomlConfigPath string) string {
+	return filepath.Join(filepath.Dir(tomlConfigPath), fragmentsFileName)
+}
+
+// WriteFragments writes the report of the json config fragments to the path.
+func WriteFragments(path string, fragments []*jsonconfig.Fragment) error {
+	if fragments == nil {
+		fragments = []*jsonconfig.Fragment{}
+	}
+	content, err := json.MarshalIndent(fragments, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), fileMode)
+}
@@ -0,0 +1,212 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cmdutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	translatorUtil "github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+const (
+	logsJsonConfig = `{
+  "logs": {
+    "logs_collected": {
+      "files": {
+        "collect_list": [{"file_path": "/var/log/app.log", "log_group_name": "app"}]
+      }
+    }
+  }
+}`
+	metricsJsonConfig = `{
+  "metrics": {
+    "metrics_collected": {
+      "cpu": {"measurement": ["usage_idle"]}
+    }
+  }
+}`
+	invalidJsonConfig = `{
+  "metrics": {
+    "metrics_collected": {
+      "cpu": {"measurement": "usage_idle"}
+    }
+  }
+}`
+)
+
+func TestTranslateJsonConfig(t *testing.T) {
+	region, credentialsPath := translatorUtil.DetectRegion, translatorUtil.DetectCredentialsPath
+	translatorUtil.DetectRegion = func(string, map[string]string) (string, string) {
+		return "us-west-2", "ACJ"
+	}
+	translatorUtil.DetectCredentialsPath = func() string {
+		return "fake-path"
+	}
+	t.Cleanup(func() {
+		translatorUtil.DetectRegion, translatorUtil.DetectCredentialsPath = region, credentialsPath
+		context.ResetContext()
+	})
+
+	testCases := map[string]struct {
+		files   map[string]string
+		wantErr bool
+		wantCPU bool
+	}{
+		"WithLogsOnly": {
+			files: map[string]string{"logs.json": logsJsonConfig},
+		},
+		"WithMetrics": {
+			files:   map[string]string{"logs.json": logsJsonConfig, "metrics.json": metricsJsonConfig},
+			wantCPU: true,
+		},
+		"WithSkippedFiles": {
+			files: map[string]string{"logs.json": logsJsonConfig, "metrics.json.tmp": metricsJsonConfig, "otel.yaml": "receivers:"},
+		},
+		"WithInclude": {
+			files: map[string]string{
+				"logs.json":    logsJsonConfig,
+				"metrics.json": `{"$include": ["logs.json"], "metrics": {"metrics_collected": {"cpu": {"measurement": ["${CWA_TEST_MEASUREMENT:-usage_idle}"]}}}}`,
+			},
+			wantCPU: true,
+		},
+		"WithUnsetEnv": {
+			files:   map[string]string{"metrics.json": `{"metrics": {"metrics_collected": {"cpu": {"measurement": ["${env:CWA_TEST_UNSET}"]}}}}`},
+			wantErr: true,
+		},
+		"WithInvalid": {
+			files:   map[string]string{"metrics.json": invalidJsonConfig},
+			wantErr: true,
+		},
+		"WithNoFiles": {
+			wantErr: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for file, content := range testCase.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0600))
+			}
+			context.ResetContext()
+			ctx := context.CurrentContext()
+			ctx.SetOs(config.OS_TYPE_LINUX)
+			ctx.SetMode(config.ModeEC2)
+			ctx.SetInputJsonFilePath(filepath.Join(dir, "missing.json"))
+			ctx.SetInputJsonDirPath(dir)
+			ctx.SetMultiConfig("remove")
+
+			got, err := TranslateJsonConfig(ctx)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, got.Toml, "[inputs.logfile]")
+			assert.Equal(t, 1, strings.Count(got.Toml, `file_path = "/var/log/app.log"`))
+			assert.NotEmpty(t, got.Env)
+			assert.NotEmpty(t, got.Yaml)
+			assert.Equal(t, testCase.wantCPU, strings.Contains(got.Yaml, "telegraf_cpu"))
+
+			// running it again gives the same result
+			again, err := TranslateJsonConfig(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, got, again)
+		})
+	}
+}
+
+func TestTranslateJsonConfigFragments(t *testing.T) {
+	region, credentialsPath := translatorUtil.DetectRegion, translatorUtil.DetectCredentialsPath
+	translatorUtil.DetectRegion = func(string, map[string]string) (string, string) {
+		return "us-west-2", "ACJ"
+	}
+	translatorUtil.DetectCredentialsPath = func() string {
+		return "fake-path"
+	}
+	t.Cleanup(func() {
+		translatorUtil.DetectRegion, translatorUtil.DetectCredentialsPath = region, credentialsPath
+		context.ResetContext()
+	})
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"a-agent.json": `{"agent": {"metrics_collection_interval": 60}}`,
+		"b-agent.json": `{"$fragment": {"owner": "team-b"}, "agent": {"metrics_collection_interval": 10}, "metrics": {"metrics_collected": {"mem": {"measurement": ["used"]}}}}`,
+		"broken.json":  `{"metrics": {"metrics_collected": {"disk": {"measurement": ["${env:CWA_TEST_UNSET}"]}}}}`,
+		"invalid.json": invalidJsonConfig,
+		"logs.json":    `{"$fragment": {"name": "app-logs", "owner": "team-a"},` + strings.TrimPrefix(logsJsonConfig, "{"),
+		"metrics.json": metricsJsonConfig,
+	}
+	for file, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0600))
+	}
+	ctx := context.CurrentContext()
+	ctx.SetOs(config.OS_TYPE_LINUX)
+	ctx.SetMode(config.ModeEC2)
+	ctx.SetInputJsonFilePath(filepath.Join(dir, "missing.json"))
+	ctx.SetInputJsonDirPath(dir)
+	ctx.SetMultiConfig("remove")
+
+	got, err := TranslateJsonConfig(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, got.Toml, "[inputs.logfile]")
+	assert.Contains(t, got.Yaml, "telegraf_cpu")
+	assert.NotContains(t, got.Yaml, "telegraf_mem")
+	assert.NotContains(t, got.Yaml, "telegraf_disk")
+
+	require.Len(t, got.Fragments, len(files))
+	var names, skipped []string
+	for _, f := range got.Fragments {
+		names = append(names, f.Name)
+		if f.Skipped() {
+			skipped = append(skipped, f.Name)
+			assert.Empty(t, f.Components)
+		}
+	}
+	assert.Equal(t, []string{"a-agent", "b-agent", "broken", "invalid", "app-logs", "metrics"}, names)
+	assert.Equal(t, []string{"b-agent", "broken", "invalid"}, skipped)
+	assert.Equal(t, "team-b", got.Fragments[1].Owner)
+	assert.Contains(t, got.Fragments[1].Errors[0], "metrics_collection_interval")
+	assert.Contains(t, got.Fragments[2].Errors[0], "CWA_TEST_UNSET")
+	assert.Equal(t, "team-a", got.Fragments[4].Owner)
+	assert.Equal(t, []string{"logs.logs_collected.files.collect_list[/var/log/app.log]"}, got.Fragments[4].Components)
+	assert.Equal(t, []string{"metrics.metrics_collected.cpu"}, got.Fragments[5].Components)
+
+	path := FragmentsPath(filepath.Join(dir, "amazon-cloudwatch-agent.toml"))
+	require.NoError(t, WriteFragments(path, got.Fragments))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"owner": "team-a"`)
+}
+
+func TestTranslationWriteAndRead(t *testing.T) {
+	dir := t.TempDir()
+	tomlPath := filepath.Join(dir, "amazon-cloudwatch-agent.toml")
+	yamlPath := filepath.Join(dir, "amazon-cloudwatch-agent.yaml")
+	envPath := filepath.Join(dir, "env-config.json")
+
+	want := &Translation{Toml: "[agent]\n", Yaml: "receivers: {}\n", Env: []byte("{}")}
+	require.NoError(t, want.Write(tomlPath, yamlPath, envPath))
+	got, err := ReadTranslation(tomlPath, yamlPath, envPath)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	want.Yaml = ""
+	require.NoError(t, want.Write(tomlPath, yamlPath, envPath))
+	assert.NoFileExists(t, yamlPath)
+	got, err = ReadTranslation(tomlPath, yamlPath, envPath)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = ReadTranslation(filepath.Join(dir, "missing.toml"), yamlPath, envPath)
+	assert.Error(t, err)
+}
@@ -4,10 +4,12 @@
 package cmdutil
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/xeipuuv/gojsonschema"
@@ -34,6 +36,7 @@ const (
 	jsonTemplateName_Windows = "default_windows_config.json"
 	jsonTemplateName_Darwin  = "default_darwin_config.json"
 	defaultTomlConfigName    = "CWAgent.conf"
+	fragmentsFileName        = "config-fragments.json"
 )
 
 // TranslateJsonMapToEnvConfigFile populates env-config.json based on the input json config.
@@ -55,7 +58,10 @@ func getCurBinaryPath() string {
 	return filepath.Dir(ex)
 }
 
-func getJsonConfigMap(jsonConfigFilePath, osType string) (map[string]interface{}, error) {
+// getJsonConfigMap reads the json config file with its references substituted and its included
+// files, relative to the config dir, merged. It also returns the absolute paths of the included
+// files.
+func getJsonConfigMap(jsonConfigFilePath, configDir, osType string) (map[string]interface{}, []string, error) {
 	if jsonConfigFilePath == "" {
 		curPath := getCurBinaryPath()
 		if osType == config.OS_TYPE_WINDOWS {
@@ -69,10 +75,10 @@ func getJsonConfigMap(jsonConfigFilePath, osType string) (map[string]interface{}
 	log.Printf("Reading json config file path: %v ...", jsonConfigFilePath)
 	if _, err := os.Stat(jsonConfigFilePath); err != nil {
 		fmt.Printf("%v does not exist or cannot read. Skipping it.\n", jsonConfigFilePath)
-		return nil, nil
+		return nil, nil, nil
 	}
 
-	return translatorUtil.GetJsonMapFromFile(jsonConfigFilePath)
+	return jsonconfig.ResolveJsonConfigFile(jsonConfigFilePath, configDir)
 }
 
 func GetTomlConfigPath(tomlFilePath string) string {
@@ -105,19 +111,41 @@ func checkSchema(inputJsonMap map[string]interface{}) {
 	}
 }
 
-func GenerateMergedJsonConfigMap(ctx *context.Context) (map[string]interface{}, error) {
+// GenerateMergedJsonConfigMap reads and merges the json config files. It also returns the
+// fragment of each file, with the ones that were skipped.
+func GenerateMergedJsonConfigMap(ctx *context.Context) (map[string]interface{}, []*jsonconfig.Fragment, error) {
+	jsonConfigMapMap, skipped, err := getJsonConfigMapMap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mergeJsonConfigMapMap(ctx, jsonConfigMapMap, skipped)
+}
+
+// getJsonConfigMapMap reads the input json config file and the json config files in the input
+// directory, keyed by path. The files of the directory that cannot be read are returned as
+// skipped fragments.
+func getJsonConfigMapMap(ctx *context.Context) (map[string]map[string]interface{}, []*jsonconfig.Fragment, error) {
 	// we use a map instead of an array here because we need to override the config value
 	// for the append operation when the existing file name and new .tmp file name have diff
 	// only for the ".tmp" suffix, i.e. it is override operation even it says append.
 	var jsonConfigMapMap = make(map[string]map[string]interface{})
+	// the files included by other files are not translated on their own
+	var included []string
+	var skipped []*jsonconfig.Fragment
+	skip := func(path string, err error) {
+		f := jsonconfig.NewFragment(path, nil)
+		f.Errors = []string{err.Error()}
+		skipped = append(skipped, f)
+	}
 
 	if ctx.MultiConfig() == "append" || ctx.MultiConfig() == "remove" {
 		// backwards compatible for the old json config file
 		// this backwards compatible file can be treated as existing files
-		jsonConfigMap, err := getJsonConfigMap(ctx.InputJsonFilePath(), ctx.Os())
+		jsonConfigMap, includedPaths, err := getJsonConfigMap(ctx.InputJsonFilePath(), ctx.InputJsonDirPath(), ctx.Os())
 		if err != nil {
-			return nil, fmt.Errorf("unable to get old json config file with error: %v", err)
+			return nil, nil, fmt.Errorf("unable to get old json config file with error: %v", err)
 		}
+		included = append(included, includedPaths...)
 		if jsonConfigMap != nil {
 			jsonConfigMapMap[ctx.InputJsonFilePath()] = jsonConfigMap
 		}
@@ -161,10 +189,12 @@ func GenerateMergedJsonConfigMap(ctx *context.Context) (map[string]interface{},
 					return nil
 				}
 				if ctx.MultiConfig() == "default" || ctx.MultiConfig() == "append" {
-					jsonConfigMap, err := getJsonConfigMap(path, ctx.Os())
+					jsonConfigMap, includedPaths, err := getJsonConfigMap(path, ctx.InputJsonDirPath(), ctx.Os())
 					if err != nil {
-						return err
+						skip(key, err)
+						return nil
 					}
+					included = append(included, includedPaths...)
 					if jsonConfigMap != nil {
 						jsonConfigMapMap[key] = jsonConfigMap
 					}
@@ -175,10 +205,12 @@ func GenerateMergedJsonConfigMap(ctx *context.Context) (map[string]interface{},
 			} else {
 				// non .tmp / existing files
 				if ctx.MultiConfig() == "append" || ctx.MultiConfig() == "remove" {
-					jsonConfigMap, err := getJsonConfigMap(path, ctx.Os())
+					jsonConfigMap, includedPaths, err := getJsonConfigMap(path, ctx.InputJsonDirPath(), ctx.Os())
 					if err != nil {
-						return err
+						skip(path, err)
+						return nil
 					}
+					included = append(included, includedPaths...)
 					if jsonConfigMap != nil {
 						if _, ok := jsonConfigMapMap[path]; !ok {
 							jsonConfigMapMap[path] = jsonConfigMap
@@ -192,6 +224,14 @@ func GenerateMergedJsonConfigMap(ctx *context.Context) (map[string]interface{},
 	if err != nil {
 		log.Printf("unable to scan config dir %v with error: %v", ctx.InputJsonDirPath(), err)
 	}
+	for _, includedPath := range included {
+		for path := range jsonConfigMapMap {
+			if abs, err := filepath.Abs(path); err == nil && abs == includedPath {
+				log.Printf("I! Skipping %v, it is included by another json config file", path)
+				delete(jsonConfigMapMap, path)
+			}
+		}
+	}
 
 	if len(jsonConfigMapMap) == 0 {
 		// For containerized agent, try to read env variable only when json configuration file is absent
@@ -199,24 +239,69 @@ func GenerateMergedJsonConfigMap(ctx *context.Context) (map[string]interface{},
 			log.Printf("Reading json config from from environment variable %v.", config.CWConfigContent)
 			jm, err := translatorUtil.GetJsonMapFromJsonBytes([]byte(jsonConfigContent))
 			if err != nil {
-				return nil, fmt.Errorf("unable to get json map from environment variable %v with error: %v", config.CWConfigContent, err)
+				return nil, nil, fmt.Errorf("unable to get json map from environment variable %v with error: %v", config.CWConfigContent, err)
+			}
+			if jm, _, err = jsonconfig.ResolveJsonConfig(jm, config.CWConfigContent, ".", ""); err != nil {
+				return nil, nil, err
 			}
 			jsonConfigMapMap[config.CWConfigContent] = jm
 		}
 	}
+	return jsonConfigMapMap, skipped, nil
+}
 
+// mergeJsonConfigMapMap merges the json config files and returns the fragment of each one. When
+// there are several files, a file that is invalid on its own or conflicts with the files before
+// it is skipped instead of failing the merge.
+func mergeJsonConfigMapMap(ctx *context.Context, jsonConfigMapMap map[string]map[string]interface{}, skipped []*jsonconfig.Fragment) (map[string]interface{}, []*jsonconfig.Fragment, error) {
 	defaultConfig, err := translatorUtil.GetDefaultJsonConfigMap(ctx.Os(), ctx.Mode())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if len(jsonConfigMapMap)+len(skipped) > 1 {
+		for path, jsonConfigMap := range jsonConfigMapMap {
+			if errs := fragmentSchemaErrors(jsonConfigMap); len(errs) > 0 {
+				f := jsonconfig.NewFragment(path, jsonConfigMap)
+				f.Errors = errs
+				skipped = append(skipped, f)
+				delete(jsonConfigMapMap, path)
+			}
+		}
+	}
+	if len(jsonConfigMapMap) == 0 && len(skipped) > 0 {
+		jsonconfig.LogFragments(skipped)
+		return nil, skipped, errors.New("all json config files were skipped")
 	}
-	mergedJsonConfigMap, err := jsonconfig.MergeJsonConfigMaps(jsonConfigMapMap, defaultConfig, ctx.MultiConfig())
+	mergedJsonConfigMap, fragments, err := jsonconfig.MergeJsonConfigFragments(jsonConfigMapMap, defaultConfig, ctx.MultiConfig())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	fragments = append(fragments, skipped...)
+	sort.Slice(fragments, func(i, j int) bool {
+		return fragments[i].Path < fragments[j].Path
+	})
+	jsonconfig.LogFragments(fragments)
 
 	// Json Schema Validation by gojsonschema
 	checkSchema(mergedJsonConfigMap)
-	return mergedJsonConfigMap, nil
+	return mergedJsonConfigMap, fragments, nil
+}
+
+// fragmentSchemaErrors validates one of several json config files against the json schema,
+// leaving out what only applies to the merged config.
+func fragmentSchemaErrors(jsonConfigMap map[string]interface{}) []string {
+	result, err := RunSchemaValidation(jsonConfigMap)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var errs []string
+	for _, errorDetail := range result.Errors() {
+		// the allOf errors are reported with the errors of the schemas they combine
+		if errorDetail.Type() != "number_all_of" && !jsonconfig.MergedSchemaErrorTypes[errorDetail.Type()] {
+			errs = append(errs, fmt.Sprintf("Under path : %s | Error : %s", config.GetFormattedPath(errorDetail.Context().String()), errorDetail.Description()))
+		}
+	}
+	return errs
 }
 
 func TranslateJsonMapToTomlConfig(jsonConfigValue interface{}) (interface{}, error) {
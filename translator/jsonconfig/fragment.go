@@ -0,0 +1,166 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package jsonconfig
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	// FragmentKey is the top level key naming a json config file of the config directory, the
+	// fragment, and its owner.
+	FragmentKey = "$fragment"
+
+	fragmentNameKey  = "name"
+	fragmentOwnerKey = "owner"
+)
+
+// MergedSchemaErrorTypes are the schema errors that are only reported for the merged config
+// when there are several files, since a file can leave out what another file has.
+var MergedSchemaErrorTypes = map[string]bool{"required": true, "number_any_of": true, "number_one_of": true}
+
+// Fragment is one of the json config files merged into the config, and what it contributed.
+type Fragment struct {
+	Path  string `json:"path"`
+	Name  string `json:"name"`
+	Owner string `json:"owner,omitempty"`
+	// Components are the json paths of the pipeline components the fragment contributed.
+	Components []string `json:"components,omitempty"`
+	// Errors are why the fragment was skipped.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Skipped reports whether the fragment was left out of the merged config.
+func (f *Fragment) Skipped() bool {
+	return len(f.Errors) > 0
+}
+
+func (f *Fragment) String() string {
+	if f.Owner == "" {
+		return fmt.Sprintf("%s (%s)", f.Name, f.Path)
+	}
+	return fmt.Sprintf("%s of %s (%s)", f.Name, f.Owner, f.Path)
+}
+
+// NewFragment returns the fragment of the json config file read from the path, and removes
+// the $fragment key from the json config. The name defaults to the file name.
+func NewFragment(path string, jsonConfigMap map[string]interface{}) *Fragment {
+	f := &Fragment{Path: path, Name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}
+	if metadata, ok := jsonConfigMap[FragmentKey].(map[string]interface{}); ok {
+		if name, ok := metadata[fragmentNameKey].(string); ok && name != "" {
+			f.Name = name
+		}
+		if owner, ok := metadata[fragmentOwnerKey].(string); ok {
+			f.Owner = owner
+		}
+	}
+	delete(jsonConfigMap, FragmentKey)
+	return f
+}
+
+// MergeJsonConfigFragments merges the json config files like MergeJsonConfigMaps, except that
+// a file which conflicts with the files merged before it, in the order of their paths, is
+// skipped instead of failing the merge. It also returns the fragment of each file.
+func MergeJsonConfigFragments(jsonConfigMapMap map[string]map[string]interface{}, defaultJsonConfigMap map[string]interface{}, multiConfig string) (map[string]interface{}, []*Fragment, error) {
+	if len(jsonConfigMapMap) == 0 {
+		resultMap, err := MergeJsonConfigMaps(jsonConfigMapMap, defaultJsonConfigMap, multiConfig)
+		return resultMap, nil, err
+	}
+
+	paths := make([]string, 0, len(jsonConfigMapMap))
+	for path := range jsonConfigMapMap {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	resultMap := map[string]interface{}{}
+	fragments := make([]*Fragment, 0, len(paths))
+	for _, path := range paths {
+		jsonConfigMap := jsonConfigMapMap[path]
+		f := NewFragment(path, jsonConfigMap)
+		fragments = append(fragments, f)
+		// the merge modifies the result, which is kept as it was if the fragment conflicts
+		components := fragmentComponents(jsonConfigMap)
+		merged := copyJsonValue(resultMap).(map[string]interface{})
+		errorCount := len(translator.ErrorMessages)
+		Merge(jsonConfigMap, merged)
+		if len(translator.ErrorMessages) > errorCount {
+			f.Errors = append([]string(nil), translator.ErrorMessages[errorCount:]...)
+			translator.ErrorMessages = translator.ErrorMessages[:errorCount]
+			continue
+		}
+		f.Components = components
+		resultMap = merged
+	}
+	return resultMap, fragments, nil
+}
+
+// LogFragments logs the fragments of the config directory, and why the skipped ones were.
+func LogFragments(fragments []*Fragment) {
+	for _, f := range fragments {
+		if f.Skipped() {
+			log.Printf("E! Skipping json config fragment %v: %s", f, strings.Join(f.Errors, "; "))
+		} else {
+			log.Printf("I! Merged json config fragment %v with %d components", f, len(f.Components))
+		}
+	}
+}
+
+// fragmentComponents returns the json paths of the plugins collecting metrics, logs and traces
+// in the json config, with the log files and Windows events they collect.
+func fragmentComponents(jsonConfigMap map[string]interface{}) []string {
+	var components []string
+	for _, section := range [][]string{
+		{"metrics", "metrics_collected"},
+		{"logs", "metrics_collected"},
+		{"traces", "traces_collected"},
+	} {
+		for key := range subMap(jsonConfigMap, section...) {
+			components = append(components, strings.Join(append(section, key), "."))
+		}
+	}
+	for _, collection := range []struct{ key, idKey string }{
+		{key: "files", idKey: "file_path"},
+		{key: "windows_events", idKey: "event_name"},
+	} {
+		list, _ := subMap(jsonConfigMap, "logs", "logs_collected", collection.key)["collect_list"].([]interface{})
+		for _, item := range list {
+			entry, _ := item.(map[string]interface{})
+			components = append(components, fmt.Sprintf("logs.logs_collected.%s.collect_list[%v]", collection.key, entry[collection.idKey]))
+		}
+	}
+	sort.Strings(components)
+	return components
+}
+
+func subMap(m map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, key := range keys {
+		m, _ = m[key].(map[string]interface{})
+	}
+	return m
+}
+
+func copyJsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			m[key] = copyJsonValue(child)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, child := range v {
+			list[i] = copyJsonValue(child)
+		}
+		return list
+	}
+	return value
+}
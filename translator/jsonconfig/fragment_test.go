@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package jsonconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+func TestNewFragment(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  Fragment
+	}{
+		"WithoutMetadata": {
+			input: `{"agent": {}}`,
+			want:  Fragment{Path: "/etc/conf.d/app.json", Name: "app"},
+		},
+		"WithMetadata": {
+			input: `{"$fragment": {"name": "nginx", "owner": "web-team"}, "agent": {}}`,
+			want:  Fragment{Path: "/etc/conf.d/app.json", Name: "nginx", Owner: "web-team"},
+		},
+		"WithEmptyName": {
+			input: `{"$fragment": {"name": "", "owner": "web-team"}}`,
+			want:  Fragment{Path: "/etc/conf.d/app.json", Name: "app", Owner: "web-team"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			jsonConfigMap, err := util.GetJsonMapFromJsonBytes([]byte(testCase.input))
+			require.NoError(t, err)
+			assert.Equal(t, &testCase.want, NewFragment("/etc/conf.d/app.json", jsonConfigMap))
+			assert.NotContains(t, jsonConfigMap, FragmentKey)
+		})
+	}
+}
+
+func TestMergeJsonConfigFragments(t *testing.T) {
+	translator.ResetMessages()
+	t.Cleanup(translator.ResetMessages)
+	inputs := map[string]string{
+		"a.json": `{
+  "$fragment": {"owner": "platform"},
+  "agent": {"region": "us-east-1"},
+  "metrics": {"metrics_collected": {"cpu": {"measurement": ["usage_idle"]}}}
+}`,
+		"b.json": `{
+  "agent": {"region": "us-west-2"},
+  "logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/b.log"}]}}}
+}`,
+		"c.json": `{
+  "$fragment": {"name": "app", "owner": "app-team"},
+  "logs": {
+    "logs_collected": {
+      "files": {"collect_list": [{"file_path": "/var/log/app.log"}, {"file_path": "/var/log/app.err"}]},
+      "windows_events": {"collect_list": [{"event_name": "System"}]}
+    },
+    "metrics_collected": {"emf": {}}
+  },
+  "traces": {"traces_collected": {"xray": {}}}
+}`,
+	}
+	jsonConfigMapMap := map[string]map[string]interface{}{}
+	for path, input := range inputs {
+		jsonConfigMap, err := util.GetJsonMapFromJsonBytes([]byte(input))
+		require.NoError(t, err)
+		jsonConfigMapMap[path] = jsonConfigMap
+	}
+
+	got, fragments, err := MergeJsonConfigFragments(jsonConfigMapMap, nil, "remove")
+	require.NoError(t, err)
+	assert.Empty(t, translator.ErrorMessages)
+	want, err := util.GetJsonMapFromJsonBytes([]byte(`{
+  "agent": {"region": "us-east-1"},
+  "metrics": {"metrics_collected": {"cpu": {"measurement": ["usage_idle"]}}},
+  "logs": {
+    "logs_collected": {
+      "files": {"collect_list": [{"file_path": "/var/log/app.log"}, {"file_path": "/var/log/app.err"}]},
+      "windows_events": {"collect_list": [{"event_name": "System"}]}
+    },
+    "metrics_collected": {"emf": {}}
+  },
+  "traces": {"traces_collected": {"xray": {}}}
+}`))
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	require.Len(t, fragments, 3)
+	assert.Equal(t, &Fragment{Path: "a.json", Name: "a", Owner: "platform", Components: []string{"metrics.metrics_collected.cpu"}}, fragments[0])
+	assert.True(t, fragments[1].Skipped())
+	assert.Empty(t, fragments[1].Components)
+	assert.Equal(t, []string{"Under path : /agent/region | Error : Different values are specified for region"}, fragments[1].Errors)
+	assert.Equal(t, &Fragment{Path: "c.json", Name: "app", Owner: "app-team", Components: []string{
+		"logs.logs_collected.files.collect_list[/var/log/app.err]",
+		"logs.logs_collected.files.collect_list[/var/log/app.log]",
+		"logs.logs_collected.windows_events.collect_list[System]",
+		"logs.metrics_collected.emf",
+		"traces.traces_collected.xray",
+	}}, fragments[2])
+}
@@ -0,0 +1,329 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package jsonconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+const (
+	// IncludeKey is the top level key listing the json config files merged into a file.
+	IncludeKey = "$include"
+
+	envReferencePrefix  = "env:"
+	fileReferencePrefix = "file:"
+	defaultSeparator    = ":-"
+	escapedReference    = "$$"
+)
+
+var (
+	// referenceRegex matches ${...} references and their $${...} escapes.
+	referenceRegex = regexp.MustCompile(`\$?\$\{([^{}]*)\}`)
+	envNameRegex   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// ResolveJsonConfigFile reads a json config file, substitutes the references in its string
+// values and merges the files of its $include directive into it. Relative $include paths, also
+// those of the included files, are relative to the config dir, or to the including file if the
+// config dir is empty. It also returns the absolute paths of the included files. The errors
+// name the file they come from.
+//
+// The references are:
+//   - ${env:VAR} is the value of the environment variable, which must be set.
+//   - ${VAR:-default} and ${env:VAR:-default} are the value of the environment variable, or
+//     the default if it is unset or empty.
+//   - ${file:path} is the content of the file without trailing newlines. Relative paths are
+//     relative to the json config file.
+//   - $${...} is a literal ${...}.
+//
+// Other references like ${aws:InstanceId} are left to the translators. A string that only
+// has one reference whose value is a JSON number or boolean is replaced by that value where the
+// schema expects a number or boolean, and stays a string otherwise.
+func ResolveJsonConfigFile(path, configDir string) (map[string]interface{}, []string, error) {
+	r := &resolver{configDir: configDir}
+	jsonConfigMap, err := r.resolveFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return jsonConfigMap, r.included, nil
+}
+
+// ResolveJsonConfig is ResolveJsonConfigFile for json config read from the source instead of
+// a file. Relative ${file:...} paths are relative to the dir, and relative $include paths to
+// the config dir, or to the dir if the config dir is empty.
+func ResolveJsonConfig(jsonConfigMap map[string]interface{}, source, dir, configDir string) (map[string]interface{}, []string, error) {
+	r := &resolver{configDir: configDir}
+	jsonConfigMap, err := r.resolve(jsonConfigMap, source, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return jsonConfigMap, r.included, nil
+}
+
+type resolver struct {
+	// configDir is the dir relative $include paths are relative to. They are relative to the
+	// including file if it is empty.
+	configDir string
+	// resolving are the absolute paths of the files being resolved, to detect include cycles.
+	resolving []string
+	included  []string
+}
+
+func (r *resolver) resolveFile(path string) (map[string]interface{}, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for i, resolving := range r.resolving {
+		if resolving == abs {
+			return nil, fmt.Errorf("%s: %s cycle: %s", path, IncludeKey, strings.Join(append(r.resolving[i:], abs), " -> "))
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	jsonConfigMap, err := util.GetJsonMapFromJsonBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	r.resolving = append(r.resolving, abs)
+	defer func() { r.resolving = r.resolving[:len(r.resolving)-1] }()
+	return r.resolve(jsonConfigMap, path, filepath.Dir(path))
+}
+
+func (r *resolver) resolve(jsonConfigMap map[string]interface{}, source, dir string) (map[string]interface{}, error) {
+	includes, err := includePaths(jsonConfigMap[IncludeKey])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	delete(jsonConfigMap, IncludeKey)
+	typed := map[string]bool{}
+	for key, value := range jsonConfigMap {
+		if jsonConfigMap[key], err = substitute(value, dir, "/"+key, typed); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+	}
+	convertTypedValues(jsonConfigMap, typed)
+	if len(includes) == 0 {
+		return jsonConfigMap, nil
+	}
+
+	result := map[string]interface{}{}
+	errorCount := len(translator.ErrorMessages)
+	includeDir := r.configDir
+	if includeDir == "" {
+		includeDir = dir
+	}
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(includeDir, include)
+		}
+		included, err := r.resolveFile(include)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		abs, _ := filepath.Abs(include)
+		r.included = append(r.included, abs)
+		Merge(included, result)
+	}
+	Merge(jsonConfigMap, result)
+	if fragment, ok := jsonConfigMap[FragmentKey]; ok {
+		result[FragmentKey] = fragment
+	}
+	if len(translator.ErrorMessages) > errorCount {
+		conflicts := append([]string(nil), translator.ErrorMessages[errorCount:]...)
+		translator.ErrorMessages = translator.ErrorMessages[:errorCount]
+		return nil, fmt.Errorf("%s: conflicts with the %s files: %s", source, IncludeKey, strings.Join(conflicts, "; "))
+	}
+	return result, nil
+}
+
+func includePaths(value interface{}) ([]string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list of file paths", IncludeKey)
+	}
+	paths := make([]string, 0, len(list))
+	for _, item := range list {
+		path, ok := item.(string)
+		if !ok || path == "" {
+			return nil, fmt.Errorf("%s must be a list of file paths", IncludeKey)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// substitute replaces the references in the string values below the value at the path. The
+// paths of the values that are only one reference whose value is a JSON number or boolean are
+// added to typed.
+func substitute(value interface{}, dir, path string, typed map[string]bool) (interface{}, error) {
+	var err error
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if v[key], err = substitute(child, dir, path+"/"+key, typed); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			if v[i], err = substitute(child, dir, fmt.Sprintf("%s/%d", path, i), typed); err != nil {
+				return nil, err
+			}
+		}
+	case string:
+		var isTyped bool
+		if value, isTyped, err = substituteString(v, dir); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if isTyped {
+			typed[path] = true
+		}
+	}
+	return value, nil
+}
+
+// substituteString returns the string with its references substituted, and whether it is only
+// one reference whose value is a JSON number or boolean.
+func substituteString(s, dir string) (string, bool, error) {
+	matches := referenceRegex.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, false, nil
+	}
+	var sb strings.Builder
+	last := 0
+	var only *string
+	for _, match := range matches {
+		sb.WriteString(s[last:match[0]])
+		last = match[1]
+		reference := s[match[0]:match[1]]
+		if strings.HasPrefix(reference, escapedReference) {
+			sb.WriteString(reference[1:])
+			continue
+		}
+		value, ok, err := lookupReference(s[match[2]:match[3]], dir)
+		if err != nil {
+			return "", false, err
+		}
+		if !ok {
+			sb.WriteString(reference)
+			continue
+		}
+		sb.WriteString(value)
+		if len(matches) == 1 && match[0] == 0 && match[1] == len(s) {
+			only = &value
+		}
+	}
+	sb.WriteString(s[last:])
+	return sb.String(), only != nil && typedValue(*only) != nil, nil
+}
+
+// typedValue returns the JSON number or boolean of the value, or nil if it is neither.
+func typedValue(value string) interface{} {
+	var typed interface{}
+	if json.Unmarshal([]byte(value), &typed) == nil {
+		switch typed.(type) {
+		case float64, bool:
+			return typed
+		}
+	}
+	return nil
+}
+
+// convertTypedValues replaces the substituted strings at the typed paths with their JSON number
+// or boolean where the schema expects one, so that e.g. a log_group_name stays a string.
+func convertTypedValues(jsonConfigMap map[string]interface{}, typed map[string]bool) {
+	if len(typed) == 0 {
+		return
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(config.GetJsonSchema()), gojsonschema.NewGoLoader(jsonConfigMap))
+	if err != nil {
+		return
+	}
+	for _, e := range result.Errors() {
+		path := strings.TrimPrefix(e.Context().String("/"), gojsonschema.STRING_CONTEXT_ROOT)
+		if e.Type() != "invalid_type" || !typed[path] {
+			continue
+		}
+		setValue(jsonConfigMap, strings.Split(strings.TrimPrefix(path, "/"), "/"), func(value interface{}) interface{} {
+			if s, ok := value.(string); ok {
+				if v := typedValue(s); v != nil {
+					return v
+				}
+			}
+			return value
+		})
+	}
+}
+
+// setValue replaces the value at the keys of the json config with the result of fn.
+func setValue(value interface{}, keys []string, fn func(interface{}) interface{}) {
+	for i, key := range keys {
+		last := i == len(keys)-1
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if last {
+				v[key] = fn(v[key])
+			}
+			value = v[key]
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return
+			}
+			if last {
+				v[index] = fn(v[index])
+			}
+			value = v[index]
+		default:
+			return
+		}
+	}
+}
+
+// lookupReference returns the value of the reference, or false if it is not one this resolves.
+func lookupReference(reference, dir string) (string, bool, error) {
+	if strings.HasPrefix(reference, fileReferencePrefix) {
+		path := strings.TrimPrefix(reference, fileReferencePrefix)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, err
+		}
+		return strings.TrimRight(string(content), "\r\n"), true, nil
+	}
+	name, isEnv := strings.CutPrefix(reference, envReferencePrefix)
+	name, defaultValue, hasDefault := strings.Cut(name, defaultSeparator)
+	if !envNameRegex.MatchString(name) || (!isEnv && !hasDefault) {
+		return "", false, nil
+	}
+	value, ok := os.LookupEnv(name)
+	if hasDefault && value == "" {
+		return defaultValue, true, nil
+	}
+	if !ok {
+		return "", false, errors.New("environment variable " + name + " is not set")
+	}
+	return value, true, nil
+}
@@ -0,0 +1,219 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package jsonconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestResolveJsonConfigFile(t *testing.T) {
+	t.Setenv("CWA_TEST_REGION", "us-west-2")
+	t.Setenv("CWA_TEST_INTERVAL", "30")
+	t.Setenv("CWA_TEST_EMPTY", "")
+	t.Setenv("CWA_TEST_TRUE", "true")
+	t.Setenv("CWA_TEST_ACCOUNT_ID", "123456789012345678901")
+
+	testCases := map[string]struct {
+		files        map[string]string
+		want         map[string]interface{}
+		wantIncluded []string
+		wantErr      string
+	}{
+		"WithReferences": {
+			files: map[string]string{
+				"config.json": `{
+  "agent": {
+    "region": "${env:CWA_TEST_REGION}",
+    "metrics_collection_interval": "${env:CWA_TEST_INTERVAL}",
+    "credentials": {"role_arn": "${file:secrets/role}"}
+  },
+  "logs": {
+    "log_stream_name": "${CWA_TEST_UNSET:-default}-${CWA_TEST_EMPTY:-empty}-${env:CWA_TEST_EMPTY}",
+    "logs_collected": {
+      "files": {
+        "collect_list": [
+          {"file_path": "/var/log/$${env:CWA_TEST_REGION}.log", "log_group_name": "${aws:InstanceId}-${env:CWA_TEST_INTERVAL}"}
+        ]
+      }
+    }
+  }
+}`,
+				"secrets/role": "arn:aws:iam::123456789012:role/agent\n",
+			},
+			want: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"region":                      "us-west-2",
+					"metrics_collection_interval": float64(30),
+					"credentials":                 map[string]interface{}{"role_arn": "arn:aws:iam::123456789012:role/agent"},
+				},
+				"logs": map[string]interface{}{
+					"log_stream_name": "default-empty-",
+					"logs_collected": map[string]interface{}{
+						"files": map[string]interface{}{
+							"collect_list": []interface{}{
+								map[string]interface{}{"file_path": "/var/log/${env:CWA_TEST_REGION}.log", "log_group_name": "${aws:InstanceId}-30"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"WithTypedReferences": {
+			files: map[string]string{
+				"config.json": `{
+  "agent": {"debug": "${env:CWA_TEST_TRUE}", "metrics_collection_interval": "${CWA_TEST_UNSET:-60}"},
+  "logs": {
+    "log_stream_name": "${env:CWA_TEST_TRUE}",
+    "logs_collected": {
+      "files": {
+        "collect_list": [
+          {"file_path": "/var/log/app.log", "log_group_name": "${env:CWA_TEST_ACCOUNT_ID}", "log_stream_name": "${env:CWA_TEST_INTERVAL}"}
+        ]
+      }
+    }
+  }
+}`,
+			},
+			want: map[string]interface{}{
+				"agent": map[string]interface{}{"debug": true, "metrics_collection_interval": float64(60)},
+				"logs": map[string]interface{}{
+					"log_stream_name": "true",
+					"logs_collected": map[string]interface{}{
+						"files": map[string]interface{}{
+							"collect_list": []interface{}{
+								map[string]interface{}{"file_path": "/var/log/app.log", "log_group_name": "123456789012345678901", "log_stream_name": "30"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"WithUnsetEnv": {
+			files:   map[string]string{"config.json": `{"agent": {"region": "${env:CWA_TEST_UNSET}"}}`},
+			wantErr: "config.json: /agent/region: environment variable CWA_TEST_UNSET is not set",
+		},
+		"WithMissingFile": {
+			files:   map[string]string{"config.json": `{"agent": {"region": "${file:missing}"}}`},
+			wantErr: "config.json: /agent/region: open ",
+		},
+		"WithInclude": {
+			files: map[string]string{
+				"config.json": `{
+  "$include": ["common/agent.json", "common/logs.json"],
+  "logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/app.log"}]}}}
+}`,
+				"common/agent.json": `{"agent": {"region": "${env:CWA_TEST_REGION}"}}`,
+				"common/logs.json":  `{"$include": ["common/agent.json"], "logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/common.log"}]}}}}`,
+			},
+			want: map[string]interface{}{
+				"agent": map[string]interface{}{"region": "us-west-2"},
+				"logs": map[string]interface{}{
+					"logs_collected": map[string]interface{}{
+						"files": map[string]interface{}{
+							"collect_list": []interface{}{
+								map[string]interface{}{"file_path": "/var/log/common.log"},
+								map[string]interface{}{"file_path": "/var/log/app.log"},
+							},
+						},
+					},
+				},
+			},
+			wantIncluded: []string{"common/agent.json", "common/agent.json", "common/logs.json"},
+		},
+		"WithIncludeAndFragment": {
+			files: map[string]string{
+				"config.json": `{"$fragment": {"name": "${env:CWA_TEST_REGION}"}, "$include": ["agent.json"]}`,
+				"agent.json":  `{"$fragment": {"name": "agent"}, "agent": {"region": "us-east-1"}}`,
+			},
+			want: map[string]interface{}{
+				"$fragment": map[string]interface{}{"name": "us-west-2"},
+				"agent":     map[string]interface{}{"region": "us-east-1"},
+			},
+			wantIncluded: []string{"agent.json"},
+		},
+		"WithIncludeConflict": {
+			files: map[string]string{
+				"config.json": `{"$include": ["common.json"], "agent": {"region": "us-east-1"}}`,
+				"common.json": `{"agent": {"region": "us-west-2"}}`,
+			},
+			wantErr: "config.json: conflicts with the $include files: Under path : /agent/region | Error : Different values are specified for region",
+		},
+		"WithIncludeCycle": {
+			files: map[string]string{
+				"config.json": `{"$include": ["common.json"]}`,
+				"common.json": `{"$include": ["config.json"]}`,
+			},
+			wantErr: "config.json: common.json: config.json: $include cycle: ",
+		},
+		"WithIncludeError": {
+			files: map[string]string{
+				"config.json": `{"$include": ["common.json"]}`,
+				"common.json": `{"agent": {"region": "${env:CWA_TEST_UNSET}"}}`,
+			},
+			wantErr: "config.json: common.json: /agent/region: environment variable CWA_TEST_UNSET is not set",
+		},
+		"WithInvalidInclude": {
+			files:   map[string]string{"config.json": `{"$include": "common.json"}`},
+			wantErr: "config.json: $include must be a list of file paths",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			dir := t.TempDir()
+			for file, content := range testCase.files {
+				path := filepath.Join(dir, file)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			}
+			got, included, err := ResolveJsonConfigFile(filepath.Join(dir, "config.json"), dir)
+			if testCase.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, strings.ReplaceAll(err.Error(), dir+string(filepath.Separator), ""), testCase.wantErr)
+				assert.Empty(t, translator.ErrorMessages)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+			var wantIncluded []string
+			for _, file := range testCase.wantIncluded {
+				wantIncluded = append(wantIncluded, filepath.Join(dir, file))
+			}
+			assert.ElementsMatch(t, wantIncluded, included)
+		})
+	}
+}
+
+func TestResolveJsonConfigFile_IncludeDir(t *testing.T) {
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		"common.json":     `{"agent": {"region": "us-east-1"}}`,
+		"app/common.json": `{"agent": {"region": "us-west-2"}}`,
+		"app/config.json": `{"$include": ["common.json"]}`,
+	} {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	// relative to the config dir
+	got, included, err := ResolveJsonConfigFile(filepath.Join(dir, "app", "config.json"), dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"agent": map[string]interface{}{"region": "us-east-1"}}, got)
+	assert.Equal(t, []string{filepath.Join(dir, "common.json")}, included)
+
+	// relative to the including file without a config dir
+	got, included, err = ResolveJsonConfigFile(filepath.Join(dir, "app", "config.json"), "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"agent": map[string]interface{}{"region": "us-west-2"}}, got)
+	assert.Equal(t, []string{filepath.Join(dir, "app", "common.json")}, included)
+}
@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otelimport
+
+const (
+	typeAWSCloudWatch     = "awscloudwatch"
+	typeAWSCloudWatchLogs = "awscloudwatchlogs"
+	typeAWSEMF            = "awsemf"
+	typeAWSXRay           = "awsxray"
+
+	// agentEMFNamespace and agentEMFLogGroupName are what the agent uses for OTLP metrics sent as
+	// embedded metric format logs.
+	agentEMFNamespace    = "CWAgent"
+	agentEMFLogGroupName = "/aws/cwagent"
+)
+
+// destination is where an exporter sends the telemetry in the agent JSON config.
+type destination struct {
+	// exporter is the type of the exporter.
+	exporter string
+	// config is the agent JSON config of the exporter.
+	config map[string]interface{}
+	// logFile is the collect_list config of the log files sent by the exporter.
+	logFile map[string]interface{}
+}
+
+type exporterConverter func(s *settings) *destination
+
+// exporterConverters are the exporters with an agent JSON equivalent by pipeline type.
+var exporterConverters = map[string]map[string]exporterConverter{
+	pipelineMetrics: {
+		typeAWSCloudWatch: convertAWSCloudWatch,
+		typeAWSEMF:        convertAWSEMF,
+	},
+	pipelineLogs: {
+		typeAWSCloudWatchLogs: convertAWSCloudWatchLogs,
+	},
+	pipelineTraces: {
+		typeAWSXRay: convertAWSXRay,
+	},
+}
+
+func convertAWSCloudWatch(s *settings) *destination {
+	d := &destination{exporter: typeAWSCloudWatch, config: map[string]interface{}{}}
+	setRegion(s, d.config)
+	if namespace, ok := s.string("namespace"); ok {
+		set(d.config, namespace, "metrics", "namespace")
+	}
+	if endpoint, ok := s.string("endpoint_override"); ok {
+		set(d.config, endpoint, "metrics", "endpoint_override")
+	}
+	if interval, ok := s.seconds("force_flush_interval"); ok {
+		set(d.config, interval, "metrics", "force_flush_interval")
+	}
+	if roleARN, ok := s.string("role_arn"); ok {
+		set(d.config, roleARN, "metrics", "credentials", "role_arn")
+	}
+	return d
+}
+
+func convertAWSEMF(s *settings) *destination {
+	d := &destination{exporter: typeAWSEMF, config: map[string]interface{}{}}
+	setRegion(s, d.config)
+	s.expect("namespace", agentEMFNamespace)
+	s.expect("log_group_name", agentEMFLogGroupName)
+	s.expect("dimension_rollup_option", "NoDimensionRollup")
+	resourceToTelemetry := s.sub("resource_to_telemetry_conversion")
+	resourceToTelemetry.expect("enabled", true)
+	s.merge(resourceToTelemetry)
+	if endpoint, ok := s.string("endpoint"); ok {
+		set(d.config, endpoint, "logs", "endpoint_override")
+	}
+	if roleARN, ok := s.string("role_arn"); ok {
+		set(d.config, roleARN, "logs", "credentials", "role_arn")
+	}
+	return d
+}
+
+func convertAWSCloudWatchLogs(s *settings) *destination {
+	d := &destination{exporter: typeAWSCloudWatchLogs, config: map[string]interface{}{}, logFile: map[string]interface{}{}}
+	setRegion(s, d.config)
+	if logGroupName, ok := s.string("log_group_name"); ok {
+		d.logFile["log_group_name"] = logGroupName
+	}
+	if logStreamName, ok := s.string("log_stream_name"); ok {
+		d.logFile["log_stream_name"] = logStreamName
+	}
+	if retention, ok := s.int("log_retention"); ok && retention > 0 {
+		d.logFile["retention_in_days"] = retention
+	}
+	if rawLog, _ := s.bool("raw_log"); !rawLog {
+		s.note("raw_log", "is not mapped, the agent sends the raw log lines")
+	}
+	if endpoint, ok := s.string("endpoint"); ok {
+		set(d.config, endpoint, "logs", "endpoint_override")
+	}
+	if roleARN, ok := s.string("role_arn"); ok {
+		set(d.config, roleARN, "logs", "credentials", "role_arn")
+	}
+	return d
+}
+
+func convertAWSXRay(s *settings) *destination {
+	d := &destination{exporter: typeAWSXRay, config: map[string]interface{}{}}
+	if region, ok := s.string("region"); ok {
+		set(d.config, region, "traces", "region_override")
+	}
+	if endpoint, ok := s.string("endpoint"); ok {
+		set(d.config, endpoint, "traces", "endpoint_override")
+	}
+	if proxy, ok := s.string("proxy_address"); ok {
+		set(d.config, proxy, "traces", "proxy_override")
+	}
+	if localMode, ok := s.bool("local_mode"); ok {
+		set(d.config, localMode, "traces", "local_mode")
+	}
+	if resourceARN, ok := s.string("resource_arn"); ok {
+		set(d.config, resourceARN, "traces", "resource_arn")
+	}
+	if roleARN, ok := s.string("role_arn"); ok {
+		set(d.config, roleARN, "traces", "credentials", "role_arn")
+	}
+	return d
+}
+
+// setRegion maps the region of an exporter to the agent region, which all the CloudWatch
+// exporters of the agent use.
+func setRegion(s *settings, config map[string]interface{}) {
+	if region, ok := s.string("region"); ok {
+		set(config, region, "agent", "region")
+	}
+}
@@ -0,0 +1,488 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package otelimport converts an OTel collector config into an agent JSON config. The pipelines
+// that the agent JSON config cannot express remain in an OTel config fragment, which the agent
+// merges with the translated config when it is passed with -otelconfig.
+package otelimport
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	keyDelimiter = "::"
+
+	keyConnectors = "connectors"
+	keyExporters  = "exporters"
+	keyExtensions = "extensions"
+	keyPipelines  = "pipelines"
+	keyProcessors = "processors"
+	keyReceivers  = "receivers"
+	keyService    = "service"
+	keyTelemetry  = "telemetry"
+
+	pipelineLogs    = "logs"
+	pipelineMetrics = "metrics"
+	pipelineTraces  = "traces"
+
+	typeBatch = "batch"
+
+	// importedName names the components and pipelines of the OTel config fragment, so that they do
+	// not conflict with the ones translated from the agent JSON config.
+	importedName = "imported"
+)
+
+var errNoPipelines = errors.New("the OTel config has no service pipelines")
+
+// Issue is a part of the OTel config without an agent JSON equivalent, or that is mapped to a
+// setting that is not exactly the same.
+type Issue struct {
+	// Path is the key of the component or setting, e.g. receivers::filelog::operators.
+	Path    string
+	Message string
+}
+
+func (i Issue) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// Result of converting an OTel collector config.
+type Result struct {
+	// Config is the agent JSON config, or nil if nothing was converted.
+	Config map[string]interface{}
+	// OtelConfig is the OTel config fragment with the pipelines, or the parts of them, that were
+	// not converted, or nil if everything was converted.
+	OtelConfig map[string]interface{}
+	Issues     []Issue
+}
+
+type pipeline struct {
+	receivers, processors, exporters []string
+}
+
+type converter struct {
+	otelConfig map[string]interface{}
+	config     map[string]interface{}
+	remaining  map[string]interface{}
+	issues     []Issue
+	// receiverPipelines counts the pipelines of each receiver.
+	receiverPipelines map[string]int
+	// mappedReceivers and keptReceivers are the receivers that were mapped, and that were kept
+	// whole in the OTel config fragment.
+	mappedReceivers, keptReceivers map[string]bool
+}
+
+// Convert converts the service pipelines of the OTel collector config. A pipeline is converted if
+// its exporter and processors have agent equivalents. Its receivers without agent equivalents, and
+// the other pipelines, remain in the OTel config fragment with their components renamed.
+func Convert(otelConfig map[string]interface{}) (*Result, error) {
+	service, _ := otelConfig[keyService].(map[string]interface{})
+	pipelinesConfig, _ := service[keyPipelines].(map[string]interface{})
+	if len(pipelinesConfig) == 0 {
+		return nil, errNoPipelines
+	}
+	c := &converter{
+		otelConfig:        otelConfig,
+		config:            map[string]interface{}{},
+		remaining:         map[string]interface{}{},
+		receiverPipelines: map[string]int{},
+		mappedReceivers:   map[string]bool{},
+		keptReceivers:     map[string]bool{},
+	}
+	ids := make([]string, 0, len(pipelinesConfig))
+	pipelines := make(map[string]pipeline, len(pipelinesConfig))
+	for id, value := range pipelinesConfig {
+		p, err := c.parsePipeline(id, value)
+		if err != nil {
+			return nil, err
+		}
+		for _, receiver := range p.receivers {
+			c.receiverPipelines[receiver]++
+		}
+		ids = append(ids, id)
+		pipelines[id] = p
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		c.convertPipeline(id, pipelines[id])
+	}
+	c.reportDuplicateReceivers()
+	if err := c.keepExtensions(service[keyExtensions]); err != nil {
+		return nil, err
+	}
+	if _, ok := service[keyTelemetry]; ok {
+		c.report(Issue{Path: keyService + keyDelimiter + keyTelemetry, Message: "has no agent equivalent, the agent configures its own telemetry"})
+	}
+
+	result := &Result{Issues: c.issues}
+	if len(c.config) > 0 {
+		for _, keys := range [][]string{
+			{"metrics", "metrics_collected", "otlp"},
+			{"logs", "metrics_collected", "otlp"},
+			{"traces", "traces_collected", "otlp"},
+		} {
+			unwrapSingleItem(c.config, keys...)
+		}
+		result.Config = c.config
+	}
+	if len(c.remaining) > 0 {
+		result.OtelConfig = c.remaining
+	}
+	return result, nil
+}
+
+func (c *converter) parsePipeline(id string, value interface{}) (pipeline, error) {
+	config, ok := value.(map[string]interface{})
+	if !ok {
+		return pipeline{}, fmt.Errorf("pipeline %s must be a map", id)
+	}
+	var p pipeline
+	for _, field := range []struct {
+		key string
+		ids *[]string
+	}{
+		{key: keyReceivers, ids: &p.receivers},
+		{key: keyProcessors, ids: &p.processors},
+		{key: keyExporters, ids: &p.exporters},
+	} {
+		list, _ := config[field.key].([]interface{})
+		for _, item := range list {
+			componentID, ok := item.(string)
+			if !ok {
+				return pipeline{}, fmt.Errorf("pipeline %s %s must be a list of strings", id, field.key)
+			}
+			if _, err := c.section(field.key, componentID); err != nil {
+				return pipeline{}, fmt.Errorf("pipeline %s: %w", id, err)
+			}
+			*field.ids = append(*field.ids, componentID)
+		}
+	}
+	return p, nil
+}
+
+// section returns the section that configures a component of a pipeline, since the receivers and
+// exporters of a pipeline can also be connectors.
+func (c *converter) section(key, id string) (string, error) {
+	sections := []string{key}
+	if key != keyProcessors {
+		sections = append(sections, keyConnectors)
+	}
+	for _, section := range sections {
+		components, _ := c.otelConfig[section].(map[string]interface{})
+		if _, ok := components[id]; ok {
+			return section, nil
+		}
+	}
+	return "", fmt.Errorf("%s %s is not configured", strings.TrimSuffix(key, "s"), id)
+}
+
+func (c *converter) component(section, id string) interface{} {
+	components, _ := c.otelConfig[section].(map[string]interface{})
+	return components[id]
+}
+
+func (c *converter) report(issues ...Issue) {
+	for _, issue := range issues {
+		if !containsIssue(c.issues, issue) {
+			c.issues = append(c.issues, issue)
+		}
+	}
+}
+
+func containsIssue(issues []Issue, issue Issue) bool {
+	for _, existing := range issues {
+		if existing == issue {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *converter) convertPipeline(id string, p pipeline) {
+	pipelinePath := strings.Join([]string{keyService, keyPipelines, id}, keyDelimiter)
+	if len(p.exporters) != 1 {
+		c.report(Issue{Path: pipelinePath, Message: "has no agent equivalent, the agent pipelines have one exporter"})
+		c.keepPipeline(id, p, nil)
+		return
+	}
+	exporterID := p.exporters[0]
+	exporterSection, _ := c.section(keyExporters, exporterID)
+	exporterPath := exporterSection + keyDelimiter + exporterID
+	convertExporter := exporterConverters[componentType(id)][componentType(exporterID)]
+	if exporterSection != keyExporters || convertExporter == nil {
+		c.report(Issue{Path: exporterPath, Message: fmt.Sprintf("has no agent equivalent in %s pipelines", componentType(id))})
+		c.keepPipeline(id, p, nil)
+		return
+	}
+	exporter := newSettings(exporterPath, c.component(keyExporters, exporterID))
+	d := convertExporter(exporter)
+	if issues := exporter.unmapped(); len(issues) > 0 {
+		c.report(issues...)
+		c.keepPipeline(id, p, nil)
+		return
+	}
+	notes := exporter.notes
+	for _, processorID := range p.processors {
+		processorPath := keyProcessors + keyDelimiter + processorID
+		if componentType(processorID) != typeBatch {
+			c.report(Issue{Path: processorPath, Message: "has no agent equivalent"})
+			c.keepPipeline(id, p, nil)
+			return
+		}
+		if config, _ := c.component(keyProcessors, processorID).(map[string]interface{}); len(config) > 0 {
+			notes = append(notes, Issue{Path: processorPath, Message: "is not mapped, the agent batches the telemetry itself"})
+		}
+	}
+
+	config := map[string]interface{}{}
+	residuals := map[string]interface{}{}
+	var mapped, kept []string
+	for _, receiverID := range p.receivers {
+		receiverSection, _ := c.section(keyReceivers, receiverID)
+		receiverPath := receiverSection + keyDelimiter + receiverID
+		convertReceiver := receiverConverters[componentType(receiverID)]
+		if receiverSection != keyReceivers || convertReceiver == nil {
+			c.report(Issue{Path: receiverPath, Message: "has no agent equivalent"})
+			kept = append(kept, receiverID)
+			continue
+		}
+		receiver := newSettings(receiverPath, c.component(keyReceivers, receiverID))
+		receiverConfig, residual, residualIssues := convertReceiver(receiver, d)
+		if issues := receiver.unmapped(); len(issues) > 0 || receiverConfig == nil {
+			c.report(issues...)
+			c.report(residualIssues...)
+			kept = append(kept, receiverID)
+			continue
+		}
+		if residual != nil && c.receiverPipelines[receiverID] > 1 {
+			c.report(Issue{Path: receiverPath, Message: "is not mapped, it is in several pipelines and only part of it has agent equivalents"})
+			c.report(residualIssues...)
+			kept = append(kept, receiverID)
+			continue
+		}
+		if err := merge(config, receiverConfig); err != nil {
+			c.report(Issue{Path: receiverPath, Message: fmt.Sprintf("is not mapped, it conflicts with the other receivers of the pipeline: %v", err)})
+			kept = append(kept, receiverID)
+			continue
+		}
+		if residual != nil {
+			c.report(residualIssues...)
+			residuals[receiverID] = residual
+			kept = append(kept, receiverID)
+		} else {
+			mapped = append(mapped, receiverID)
+		}
+		notes = append(notes, receiver.notes...)
+	}
+	if len(config) > 0 {
+		err := merge(config, d.config)
+		if err == nil {
+			err = merge(c.config, config)
+		}
+		if err != nil {
+			c.report(Issue{Path: pipelinePath, Message: fmt.Sprintf("is not mapped, it conflicts with the other pipelines: %v", err)})
+			c.keepPipeline(id, p, nil)
+			return
+		}
+		c.report(notes...)
+		for _, receiverID := range mapped {
+			c.mappedReceivers[receiverID] = true
+		}
+	}
+	if len(kept) > 0 {
+		c.keepPipeline(id, pipeline{receivers: kept, processors: p.processors, exporters: p.exporters}, residuals)
+	}
+}
+
+// keepPipeline adds the pipeline and its components to the OTel config fragment. The residuals
+// replace the config of the receivers that were mapped in part.
+func (c *converter) keepPipeline(id string, p pipeline, residuals map[string]interface{}) {
+	pipelineConfig := map[string]interface{}{}
+	for _, field := range []struct {
+		key string
+		ids []string
+	}{
+		{key: keyReceivers, ids: p.receivers},
+		{key: keyProcessors, ids: p.processors},
+		{key: keyExporters, ids: p.exporters},
+	} {
+		if len(field.ids) == 0 {
+			continue
+		}
+		importedIDs := make([]interface{}, 0, len(field.ids))
+		for _, componentID := range field.ids {
+			section, _ := c.section(field.key, componentID)
+			config, ok := residuals[componentID]
+			if !ok {
+				config = c.component(section, componentID)
+				if section == keyReceivers {
+					c.keptReceivers[componentID] = true
+				}
+			}
+			set(c.remaining, config, section, importedID(componentID))
+			importedIDs = append(importedIDs, importedID(componentID))
+		}
+		pipelineConfig[field.key] = importedIDs
+	}
+	set(c.remaining, pipelineConfig, keyService, keyPipelines, importedID(id))
+}
+
+// reportDuplicateReceivers reports the receivers that were mapped in some pipelines, and kept in the
+// OTel config fragment for others, since the agent then runs them twice.
+func (c *converter) reportDuplicateReceivers() {
+	var receiverIDs []string
+	for receiverID := range c.mappedReceivers {
+		if c.keptReceivers[receiverID] {
+			receiverIDs = append(receiverIDs, receiverID)
+		}
+	}
+	sort.Strings(receiverIDs)
+	for _, receiverID := range receiverIDs {
+		c.report(Issue{
+			Path:    keyReceivers + keyDelimiter + receiverID,
+			Message: "is both mapped and in the OTel config fragment, so the agent runs it twice",
+		})
+	}
+}
+
+// keepExtensions adds the extensions of the service to the OTel config fragment.
+func (c *converter) keepExtensions(value interface{}) error {
+	extensions, _ := value.([]interface{})
+	if len(extensions) == 0 {
+		return nil
+	}
+	importedIDs := make([]interface{}, 0, len(extensions))
+	for _, item := range extensions {
+		extensionID, ok := item.(string)
+		if !ok {
+			return fmt.Errorf("service %s must be a list of strings", keyExtensions)
+		}
+		components, _ := c.otelConfig[keyExtensions].(map[string]interface{})
+		config, ok := components[extensionID]
+		if !ok {
+			return fmt.Errorf("extension %s is not configured", extensionID)
+		}
+		c.report(Issue{Path: keyExtensions + keyDelimiter + extensionID, Message: "has no agent equivalent"})
+		set(c.remaining, config, keyExtensions, importedID(extensionID))
+		importedIDs = append(importedIDs, importedID(extensionID))
+	}
+	set(c.remaining, importedIDs, keyService, keyExtensions)
+	return nil
+}
+
+// componentType returns the type of a component or pipeline ID, e.g. otlp for otlp/app.
+func componentType(id string) string {
+	componentType, _, _ := strings.Cut(id, "/")
+	return componentType
+}
+
+// importedID renames a component or pipeline of the OTel config fragment, e.g. otlp/app to
+// otlp/imported_app.
+func importedID(id string) string {
+	componentType, name, ok := strings.Cut(id, "/")
+	if !ok {
+		return componentType + "/" + importedName
+	}
+	return componentType + "/" + importedName + "_" + name
+}
+
+func list[T any](items ...T) []interface{} {
+	l := make([]interface{}, len(items))
+	for i, item := range items {
+		l[i] = item
+	}
+	return l
+}
+
+// set sets the value at the keys in the config, creating the maps on the way.
+func set(config map[string]interface{}, value interface{}, keys ...string) {
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := config[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			config[key] = next
+		}
+		config = next
+	}
+	config[keys[len(keys)-1]] = value
+}
+
+// unwrapSingleItem replaces a list with a single item at the keys with the item.
+func unwrapSingleItem(config map[string]interface{}, keys ...string) {
+	for _, key := range keys[:len(keys)-1] {
+		config, _ = config[key].(map[string]interface{})
+	}
+	last := keys[len(keys)-1]
+	if items, ok := config[last].([]interface{}); ok && len(items) == 1 {
+		config[last] = items[0]
+	}
+}
+
+// merge merges the src config into the dst config. Lists are appended to, without duplicates.
+// It fails without changing dst if a value is different in both.
+func merge(dst, src map[string]interface{}) error {
+	if err := mergeMaps(dst, src, nil, false); err != nil {
+		return err
+	}
+	return mergeMaps(dst, src, nil, true)
+}
+
+func mergeMaps(dst, src map[string]interface{}, path []string, apply bool) error {
+	keys := make([]string, 0, len(src))
+	for key := range src {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		srcValue := src[key]
+		keyPath := append(path[:len(path):len(path)], key)
+		dstValue, ok := dst[key]
+		if !ok {
+			if apply {
+				dst[key] = srcValue
+			}
+			continue
+		}
+		switch dstValue := dstValue.(type) {
+		case map[string]interface{}:
+			if srcMap, ok := srcValue.(map[string]interface{}); ok {
+				if err := mergeMaps(dstValue, srcMap, keyPath, apply); err != nil {
+					return err
+				}
+				continue
+			}
+		case []interface{}:
+			if srcList, ok := srcValue.([]interface{}); ok {
+				if apply {
+					dst[key] = appendUnique(dstValue, srcList)
+				}
+				continue
+			}
+		}
+		if !reflect.DeepEqual(dstValue, srcValue) {
+			return fmt.Errorf("%s is both %v and %v", strings.Join(keyPath, "."), dstValue, srcValue)
+		}
+	}
+	return nil
+}
+
+func appendUnique(dst, src []interface{}) []interface{} {
+	for _, item := range src {
+		found := false
+		for _, existing := range dst {
+			if reflect.DeepEqual(existing, item) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, item)
+		}
+	}
+	return dst
+}
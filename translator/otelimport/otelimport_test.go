@@ -0,0 +1,452 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otelimport
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/validate"
+)
+
+func readYAML(t *testing.T, content []byte) map[string]interface{} {
+	t.Helper()
+	var config map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(content, &config))
+	return config
+}
+
+func TestConvert(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "otel.yaml"))
+	require.NoError(t, err)
+	result, err := Convert(readYAML(t, content))
+	require.NoError(t, err)
+
+	wantConfig, err := os.ReadFile(filepath.Join("testdata", "agent.json"))
+	require.NoError(t, err)
+	gotConfig, err := json.Marshal(result.Config)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(wantConfig), string(gotConfig))
+	configPath := filepath.Join(t.TempDir(), "agent.json")
+	require.NoError(t, os.WriteFile(configPath, gotConfig, 0600))
+	diagnostics, err := validate.Validate([]string{configPath}, "")
+	require.NoError(t, err)
+	assert.False(t, validate.HasErrors(diagnostics), "%v", diagnostics)
+
+	wantOtelConfig, err := os.ReadFile(filepath.Join("testdata", "otel_imported.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, readYAML(t, wantOtelConfig), result.OtelConfig)
+
+	assert.Equal(t, []Issue{
+		{Path: "receivers::filelog/json::operators", Message: "has no agent equivalent"},
+		{Path: "receivers::hostmetrics::scrapers::filesystem", Message: "has settings without agent equivalent"},
+		{Path: "receivers::hostmetrics::scrapers::load", Message: "has no agent equivalent"},
+		{Path: "receivers::prometheus", Message: "has no agent equivalent"},
+		{Path: "processors::resourcedetection", Message: "has no agent equivalent"},
+		{Path: "exporters::debug", Message: "has no agent equivalent in traces pipelines"},
+		{Path: "receivers::otlp", Message: "is both mapped and in the OTel config fragment, so the agent runs it twice"},
+		{Path: "extensions::health_check", Message: "has no agent equivalent"},
+		{Path: "service::telemetry", Message: "has no agent equivalent, the agent configures its own telemetry"},
+	}, result.Issues)
+}
+
+func TestConvertPipelines(t *testing.T) {
+	testCases := map[string]struct {
+		otelConfig     string
+		wantConfig     string
+		wantOtelConfig string
+		wantIssues     []Issue
+	}{
+		"WithOTLPTraces": {
+			otelConfig: `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 127.0.0.1:5317
+        tls:
+          cert_file: /etc/tls/cert.pem
+          key_file: /etc/tls/key.pem
+exporters:
+  awsxray:
+    region: eu-west-1
+    local_mode: true
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [awsxray]
+`,
+			wantConfig: `{"traces": {"region_override": "eu-west-1", "local_mode": true, "traces_collected": {"otlp": {
+				"grpc_endpoint": "127.0.0.1:5317", "tls": {"cert_file": "/etc/tls/cert.pem", "key_file": "/etc/tls/key.pem"}}}}}`,
+			wantIssues: []Issue{
+				{Path: "receivers::otlp::protocols::http", Message: "is not configured, but the agent also receives OTLP over http on 127.0.0.1:4318"},
+			},
+		},
+		"WithOTLPDifferentTLS": {
+			otelConfig: `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /etc/tls/cert.pem
+      http:
+exporters:
+  awscloudwatch:
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp]
+      exporters: [awscloudwatch]
+`,
+			wantOtelConfig: `
+receivers:
+  otlp/imported:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /etc/tls/cert.pem
+      http:
+exporters:
+  awscloudwatch/imported:
+service:
+  pipelines:
+    metrics/imported:
+      receivers: [otlp/imported]
+      exporters: [awscloudwatch/imported]
+`,
+			wantIssues: []Issue{
+				{Path: "receivers::otlp::protocols::http::tls", Message: "has no agent equivalent, the agent uses the same TLS settings for gRPC and HTTP"},
+			},
+		},
+		"WithFilelogStructuredLogs": {
+			otelConfig: `
+receivers:
+  filelog:
+    include: [/var/log/a.log, /var/log/b.log]
+    start_at: end
+    encoding: utf-16le
+exporters:
+  awscloudwatchlogs:
+    log_group_name: app
+service:
+  pipelines:
+    logs:
+      receivers: [filelog]
+      exporters: [awscloudwatchlogs]
+`,
+			wantConfig: `{"logs": {"logs_collected": {"files": {"collect_list": [
+				{"file_path": "/var/log/a.log", "log_group_name": "app", "encoding": "utf-16le"},
+				{"file_path": "/var/log/b.log", "log_group_name": "app", "encoding": "utf-16le"}]}}}}`,
+			wantIssues: []Issue{
+				{Path: "exporters::awscloudwatchlogs::raw_log", Message: "is not mapped, the agent sends the raw log lines"},
+				{Path: "receivers::filelog::start_at", Message: "is not mapped, the agent reads new files from the beginning"},
+			},
+		},
+		"WithFilelogToAWSEMF": {
+			otelConfig: `
+receivers:
+  filelog:
+    include: [/var/log/a.log]
+exporters:
+  awsemf:
+service:
+  pipelines:
+    metrics:
+      receivers: [filelog]
+      exporters: [awsemf]
+`,
+			wantOtelConfig: `
+receivers:
+  filelog/imported:
+    include: [/var/log/a.log]
+exporters:
+  awsemf/imported:
+service:
+  pipelines:
+    metrics/imported:
+      receivers: [filelog/imported]
+      exporters: [awsemf/imported]
+`,
+			wantIssues: []Issue{
+				{Path: "receivers::filelog", Message: "has no agent equivalent in pipelines exporting to awsemf"},
+			},
+		},
+		"WithAWSEMFNamespace": {
+			otelConfig: `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+exporters:
+  awsemf:
+    namespace: MyApp
+    resource_to_telemetry_conversion:
+      enabled: false
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp]
+      exporters: [awsemf]
+`,
+			wantOtelConfig: `
+receivers:
+  otlp/imported:
+    protocols:
+      grpc:
+      http:
+exporters:
+  awsemf/imported:
+    namespace: MyApp
+    resource_to_telemetry_conversion:
+      enabled: false
+service:
+  pipelines:
+    metrics/imported:
+      receivers: [otlp/imported]
+      exporters: [awsemf/imported]
+`,
+			wantIssues: []Issue{
+				{Path: "exporters::awsemf::namespace", Message: "has no agent equivalent, the agent uses CWAgent"},
+				{Path: "exporters::awsemf::resource_to_telemetry_conversion::enabled", Message: "has no agent equivalent, the agent uses true"},
+			},
+		},
+		"WithExporterSetting": {
+			otelConfig: `
+receivers:
+  hostmetrics:
+    scrapers:
+      cpu:
+exporters:
+  awscloudwatch:
+    max_datums_per_call: 500
+    force_flush_interval: 500ms
+service:
+  pipelines:
+    metrics:
+      receivers: [hostmetrics]
+      exporters: [awscloudwatch]
+`,
+			wantOtelConfig: `
+receivers:
+  hostmetrics/imported:
+    scrapers:
+      cpu:
+exporters:
+  awscloudwatch/imported:
+    max_datums_per_call: 500
+    force_flush_interval: 500ms
+service:
+  pipelines:
+    metrics/imported:
+      receivers: [hostmetrics/imported]
+      exporters: [awscloudwatch/imported]
+`,
+			wantIssues: []Issue{
+				{Path: "exporters::awscloudwatch::force_flush_interval", Message: "has no agent equivalent, the agent intervals are whole seconds"},
+				{Path: "exporters::awscloudwatch::max_datums_per_call", Message: "has no agent equivalent"},
+			},
+		},
+		"WithConflictingPipelines": {
+			otelConfig: `
+receivers:
+  hostmetrics/cpu:
+    scrapers:
+      cpu:
+  hostmetrics/memory:
+    scrapers:
+      memory:
+processors:
+  batch:
+    timeout: 10s
+exporters:
+  awscloudwatch/a:
+    namespace: A
+  awscloudwatch/b:
+    namespace: B
+service:
+  pipelines:
+    metrics/a:
+      receivers: [hostmetrics/cpu]
+      processors: [batch]
+      exporters: [awscloudwatch/a]
+    metrics/b:
+      receivers: [hostmetrics/memory]
+      exporters: [awscloudwatch/b]
+`,
+			wantConfig: `{"metrics": {"namespace": "A", "metrics_collected": {"cpu": {"resources": ["*"], "totalcpu": false,
+				"measurement": ["time_user", "time_system", "time_idle", "time_nice", "time_iowait", "time_irq", "time_softirq", "time_steal"]}}}}`,
+			wantOtelConfig: `
+receivers:
+  hostmetrics/imported_memory:
+    scrapers:
+      memory:
+exporters:
+  awscloudwatch/imported_b:
+    namespace: B
+service:
+  pipelines:
+    metrics/imported_b:
+      receivers: [hostmetrics/imported_memory]
+      exporters: [awscloudwatch/imported_b]
+`,
+			wantIssues: []Issue{
+				{Path: "processors::batch", Message: "is not mapped, the agent batches the telemetry itself"},
+				{Path: "service::pipelines::metrics/b", Message: "is not mapped, it conflicts with the other pipelines: metrics.namespace is both A and B"},
+			},
+		},
+		"WithSharedHostMetrics": {
+			otelConfig: `
+receivers:
+  hostmetrics:
+    scrapers:
+      memory:
+      load:
+exporters:
+  awscloudwatch:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [hostmetrics]
+      exporters: [awscloudwatch]
+    metrics/debug:
+      receivers: [hostmetrics]
+      exporters: [debug]
+`,
+			wantOtelConfig: `
+receivers:
+  hostmetrics/imported:
+    scrapers:
+      memory:
+      load:
+exporters:
+  awscloudwatch/imported:
+  debug/imported:
+service:
+  pipelines:
+    metrics/imported:
+      receivers: [hostmetrics/imported]
+      exporters: [awscloudwatch/imported]
+    metrics/imported_debug:
+      receivers: [hostmetrics/imported]
+      exporters: [debug/imported]
+`,
+			wantIssues: []Issue{
+				{Path: "receivers::hostmetrics", Message: "is not mapped, it is in several pipelines and only part of it has agent equivalents"},
+				{Path: "receivers::hostmetrics::scrapers::load", Message: "has no agent equivalent"},
+				{Path: "exporters::debug", Message: "has no agent equivalent in metrics pipelines"},
+			},
+		},
+		"WithConnector": {
+			otelConfig: `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+connectors:
+  spanmetrics:
+exporters:
+  awsxray:
+  awscloudwatch:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [awsxray, spanmetrics]
+    metrics:
+      receivers: [spanmetrics]
+      exporters: [awscloudwatch]
+`,
+			wantOtelConfig: `
+receivers:
+  otlp/imported:
+    protocols:
+      grpc:
+      http:
+connectors:
+  spanmetrics/imported:
+exporters:
+  awsxray/imported:
+  awscloudwatch/imported:
+service:
+  pipelines:
+    traces/imported:
+      receivers: [otlp/imported]
+      exporters: [awsxray/imported, spanmetrics/imported]
+    metrics/imported:
+      receivers: [spanmetrics/imported]
+      exporters: [awscloudwatch/imported]
+`,
+			wantIssues: []Issue{
+				{Path: "connectors::spanmetrics", Message: "has no agent equivalent"},
+				{Path: "service::pipelines::traces", Message: "has no agent equivalent, the agent pipelines have one exporter"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := Convert(readYAML(t, []byte(testCase.otelConfig)))
+			require.NoError(t, err)
+			if testCase.wantConfig == "" {
+				assert.Nil(t, result.Config)
+			} else {
+				gotConfig, err := json.Marshal(result.Config)
+				require.NoError(t, err)
+				assert.JSONEq(t, testCase.wantConfig, string(gotConfig))
+			}
+			if testCase.wantOtelConfig == "" {
+				assert.Nil(t, result.OtelConfig)
+			} else {
+				assert.Equal(t, readYAML(t, []byte(testCase.wantOtelConfig)), result.OtelConfig)
+			}
+			assert.Equal(t, testCase.wantIssues, result.Issues)
+		})
+	}
+}
+
+func TestConvertInvalid(t *testing.T) {
+	testCases := map[string]struct {
+		otelConfig string
+		wantErr    string
+	}{
+		"WithoutPipelines": {
+			otelConfig: `receivers: {otlp: }`,
+			wantErr:    "the OTel config has no service pipelines",
+		},
+		"WithUnconfiguredReceiver": {
+			otelConfig: `{service: {pipelines: {metrics: {receivers: [otlp], exporters: [awscloudwatch]}}}, exporters: {awscloudwatch: }}`,
+			wantErr:    "pipeline metrics: receiver otlp is not configured",
+		},
+		"WithInvalidPipeline": {
+			otelConfig: `{service: {pipelines: {metrics: [otlp]}}}`,
+			wantErr:    "pipeline metrics must be a map",
+		},
+		"WithUnconfiguredExtension": {
+			otelConfig: `{service: {extensions: [health_check], pipelines: {traces: {receivers: [otlp], exporters: [awsxray]}}}, receivers: {otlp: }, exporters: {awsxray: }}`,
+			wantErr:    "extension health_check is not configured",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Convert(readYAML(t, []byte(testCase.otelConfig)))
+			assert.EqualError(t, err, testCase.wantErr)
+		})
+	}
+}
+
+func TestImportedID(t *testing.T) {
+	assert.Equal(t, "otlp/imported", importedID("otlp"))
+	assert.Equal(t, "otlp/imported_app", importedID("otlp/app"))
+	assert.Equal(t, "otlp/imported_app/a", importedID("otlp/app/a"))
+}
@@ -0,0 +1,245 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otelimport
+
+import (
+	"reflect"
+	"sort"
+)
+
+const (
+	typeFilelog     = "filelog"
+	typeHostMetrics = "hostmetrics"
+	typeOTLP        = "otlp"
+
+	// agentOTLPGrpcEndpoint and agentOTLPHttpEndpoint are where the agent receives OTLP unless
+	// configured otherwise.
+	agentOTLPGrpcEndpoint = "127.0.0.1:4317"
+	agentOTLPHttpEndpoint = "127.0.0.1:4318"
+)
+
+// receiverConverter returns the agent JSON config of a receiver that sends to the destination,
+// or nil if nothing could be mapped. A receiver that could only be mapped in part also returns
+// the OTel config of the rest and why it was not mapped.
+type receiverConverter func(s *settings, d *destination) (config, remaining map[string]interface{}, remainingIssues []Issue)
+
+var receiverConverters = map[string]receiverConverter{
+	typeFilelog:     convertFilelog,
+	typeHostMetrics: convertHostMetrics,
+	typeOTLP:        convertOTLP,
+}
+
+// hostMetricsPlugins returns the agent plugins with the measurements closest to the default
+// metrics of a hostmetrics scraper.
+func hostMetricsPlugins(scraper string) map[string]map[string]interface{} {
+	switch scraper {
+	case "cpu":
+		return map[string]map[string]interface{}{
+			"cpu": {
+				"resources":   list("*"),
+				"totalcpu":    false,
+				"measurement": list("time_user", "time_system", "time_idle", "time_nice", "time_iowait", "time_irq", "time_softirq", "time_steal"),
+			},
+		}
+	case "disk":
+		return map[string]map[string]interface{}{
+			"diskio": {
+				"resources":   list("*"),
+				"measurement": list("read_bytes", "write_bytes", "reads", "writes", "read_time", "write_time", "io_time", "iops_in_progress"),
+			},
+		}
+	case "filesystem":
+		return map[string]map[string]interface{}{
+			"disk": {
+				"resources":   list("*"),
+				"measurement": list("used", "free", "inodes_used", "inodes_free"),
+			},
+		}
+	case "memory":
+		return map[string]map[string]interface{}{
+			"mem": {
+				"measurement": list("used", "free", "buffered", "cached"),
+			},
+		}
+	case "network":
+		return map[string]map[string]interface{}{
+			"net": {
+				"resources":   list("*"),
+				"measurement": list("bytes_sent", "bytes_recv", "packets_sent", "packets_recv", "err_in", "err_out", "drop_in", "drop_out"),
+			},
+			"netstat": {
+				"measurement": list("tcp_close", "tcp_close_wait", "tcp_closing", "tcp_established", "tcp_fin_wait1", "tcp_fin_wait2",
+					"tcp_last_ack", "tcp_listen", "tcp_none", "tcp_syn_sent", "tcp_syn_recv", "tcp_time_wait", "udp_socket"),
+			},
+		}
+	case "paging":
+		return map[string]map[string]interface{}{
+			"swap": {
+				"measurement": list("used", "free"),
+			},
+		}
+	case "processes":
+		return map[string]map[string]interface{}{
+			"processes": {
+				"measurement": list("running", "sleeping", "blocked", "zombies", "stopped", "paging", "dead", "idle", "total_threads"),
+			},
+		}
+	}
+	return nil
+}
+
+// convertHostMetrics maps the scrapers without settings to the metrics collected by the agent.
+// The other scrapers remain in the hostmetrics receiver.
+func convertHostMetrics(s *settings, d *destination) (map[string]interface{}, map[string]interface{}, []Issue) {
+	if d.exporter != typeAWSCloudWatch {
+		s.unsupported("has no agent equivalent in pipelines exporting to %s", d.exporter)
+		return nil, nil, nil
+	}
+	interval, hasInterval := s.seconds("collection_interval")
+	value, _ := s.get("scrapers")
+	scrapers, _ := value.(map[string]interface{})
+	names := make([]string, 0, len(scrapers))
+	for name := range scrapers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	collected := map[string]interface{}{}
+	remainingScrapers := map[string]interface{}{}
+	var remainingIssues []Issue
+	for _, name := range names {
+		path := s.keyPath("scrapers") + keyDelimiter + name
+		plugins := hostMetricsPlugins(name)
+		if plugins == nil {
+			remainingScrapers[name] = scrapers[name]
+			remainingIssues = append(remainingIssues, Issue{Path: path, Message: "has no agent equivalent"})
+			continue
+		}
+		if config, _ := scrapers[name].(map[string]interface{}); len(config) > 0 {
+			remainingScrapers[name] = scrapers[name]
+			remainingIssues = append(remainingIssues, Issue{Path: path, Message: "has settings without agent equivalent"})
+			continue
+		}
+		for plugin, config := range plugins {
+			if hasInterval {
+				config["metrics_collection_interval"] = interval
+			}
+			collected[plugin] = config
+		}
+	}
+	if len(collected) == 0 {
+		return nil, nil, remainingIssues
+	}
+	config := map[string]interface{}{}
+	set(config, collected, "metrics", "metrics_collected")
+	if len(remainingScrapers) == 0 {
+		return config, nil, nil
+	}
+	remaining := map[string]interface{}{}
+	for key, value := range s.values {
+		remaining[key] = value
+	}
+	remaining["scrapers"] = remainingScrapers
+	return config, remaining, remainingIssues
+}
+
+// convertFilelog maps the included files to the log files collected by the agent.
+func convertFilelog(s *settings, d *destination) (map[string]interface{}, map[string]interface{}, []Issue) {
+	if d.exporter != typeAWSCloudWatchLogs {
+		s.unsupported("has no agent equivalent in pipelines exporting to %s", d.exporter)
+		return nil, nil, nil
+	}
+	logFile := map[string]interface{}{}
+	for key, value := range d.logFile {
+		logFile[key] = value
+	}
+	multiline := s.sub("multiline")
+	if pattern, ok := multiline.string("line_start_pattern"); ok {
+		logFile["multi_line_start_pattern"] = pattern
+	}
+	s.merge(multiline)
+	if encoding, ok := s.string("encoding"); ok {
+		if encoding == "nop" {
+			s.invalid("encoding", "has no agent equivalent")
+		} else {
+			logFile["encoding"] = encoding
+		}
+	}
+	if startAt, _ := s.string("start_at"); startAt == "end" {
+		s.note("start_at", "is not mapped, the agent reads new files from the beginning")
+	}
+	// The file attributes are not sent by the agent.
+	s.ignore("include_file_name", "include_file_path")
+
+	include, ok := s.strings("include")
+	if !ok {
+		if !s.has("include") {
+			s.invalid("include", "is required")
+		}
+		return nil, nil, nil
+	}
+	collectList := make([]interface{}, 0, len(include))
+	for _, path := range include {
+		entry := map[string]interface{}{"file_path": path}
+		for key, value := range logFile {
+			entry[key] = value
+		}
+		collectList = append(collectList, entry)
+	}
+	config := map[string]interface{}{}
+	set(config, collectList, "logs", "logs_collected", "files", "collect_list")
+	return config, nil, nil
+}
+
+// convertOTLP maps the gRPC and HTTP endpoints to the agent OTLP receiver of the destination.
+func convertOTLP(s *settings, d *destination) (map[string]interface{}, map[string]interface{}, []Issue) {
+	var keys []string
+	switch d.exporter {
+	case typeAWSCloudWatch:
+		keys = []string{"metrics", "metrics_collected", "otlp"}
+	case typeAWSEMF:
+		keys = []string{"logs", "metrics_collected", "otlp"}
+	case typeAWSXRay:
+		keys = []string{"traces", "traces_collected", "otlp"}
+	default:
+		s.unsupported("has no agent equivalent in pipelines exporting to %s", d.exporter)
+		return nil, nil, nil
+	}
+	otlp := map[string]interface{}{}
+	protocols := s.sub("protocols")
+	var tls map[string]interface{}
+	for _, protocol := range []struct{ name, defaultEndpoint string }{
+		{name: "grpc", defaultEndpoint: agentOTLPGrpcEndpoint},
+		{name: "http", defaultEndpoint: agentOTLPHttpEndpoint},
+	} {
+		if !protocols.has(protocol.name) {
+			protocols.note(protocol.name, "is not configured, but the agent also receives OTLP over %s on %s", protocol.name, protocol.defaultEndpoint)
+			continue
+		}
+		settings := protocols.sub(protocol.name)
+		if endpoint, ok := settings.string("endpoint"); ok {
+			otlp[protocol.name+"_endpoint"] = endpoint
+		}
+		protocolTLS := map[string]interface{}{}
+		tlsSettings := settings.sub("tls")
+		for _, key := range []string{"cert_file", "key_file"} {
+			if file, ok := tlsSettings.string(key); ok {
+				protocolTLS[key] = file
+			}
+		}
+		settings.merge(tlsSettings)
+		if tls != nil && !reflect.DeepEqual(tls, protocolTLS) {
+			settings.invalid("tls", "has no agent equivalent, the agent uses the same TLS settings for gRPC and HTTP")
+		}
+		tls = protocolTLS
+		protocols.merge(settings)
+	}
+	s.merge(protocols)
+	if len(tls) > 0 {
+		otlp["tls"] = tls
+	}
+	config := map[string]interface{}{}
+	set(config, list(otlp), keys...)
+	return config, nil, nil
+}
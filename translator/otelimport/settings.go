@@ -0,0 +1,180 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otelimport
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// settings reads the settings of an OTel component and tracks the ones that were read, so that
+// the settings without an agent JSON equivalent can be reported.
+type settings struct {
+	path   string
+	values map[string]interface{}
+	read   map[string]bool
+	issues []Issue
+	// notes are about settings that are mapped, but not exactly.
+	notes []Issue
+}
+
+func newSettings(path string, value interface{}) *settings {
+	values, _ := value.(map[string]interface{})
+	return &settings{path: path, values: values, read: map[string]bool{}}
+}
+
+func (s *settings) keyPath(key string) string {
+	return s.path + keyDelimiter + key
+}
+
+func (s *settings) has(key string) bool {
+	_, ok := s.values[key]
+	return ok
+}
+
+func (s *settings) get(key string) (interface{}, bool) {
+	value, ok := s.values[key]
+	if ok {
+		s.read[key] = true
+	}
+	return value, ok
+}
+
+func (s *settings) invalid(key string, format string, args ...interface{}) {
+	s.issues = append(s.issues, Issue{Path: s.keyPath(key), Message: fmt.Sprintf(format, args...)})
+}
+
+// unsupported reports the whole component instead of its settings.
+func (s *settings) unsupported(format string, args ...interface{}) {
+	for key := range s.values {
+		s.read[key] = true
+	}
+	s.issues = append(s.issues, Issue{Path: s.path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (s *settings) note(key string, format string, args ...interface{}) {
+	s.notes = append(s.notes, Issue{Path: s.keyPath(key), Message: fmt.Sprintf(format, args...)})
+}
+
+// ignore reads the settings that do not matter to the agent.
+func (s *settings) ignore(keys ...string) {
+	for _, key := range keys {
+		s.get(key)
+	}
+}
+
+func (s *settings) string(key string) (string, bool) {
+	value, ok := s.get(key)
+	if !ok || value == nil {
+		return "", false
+	}
+	str, ok := value.(string)
+	if !ok {
+		s.invalid(key, "must be a string")
+	}
+	return str, ok
+}
+
+func (s *settings) bool(key string) (bool, bool) {
+	value, ok := s.get(key)
+	if !ok || value == nil {
+		return false, false
+	}
+	b, ok := value.(bool)
+	if !ok {
+		s.invalid(key, "must be a boolean")
+	}
+	return b, ok
+}
+
+func (s *settings) int(key string) (int, bool) {
+	value, ok := s.get(key)
+	if !ok || value == nil {
+		return 0, false
+	}
+	i, ok := value.(int)
+	if !ok {
+		s.invalid(key, "must be an integer")
+	}
+	return i, ok
+}
+
+func (s *settings) strings(key string) ([]string, bool) {
+	value, ok := s.get(key)
+	if !ok || value == nil {
+		return nil, false
+	}
+	list, _ := value.([]interface{})
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		str, ok := item.(string)
+		if !ok {
+			break
+		}
+		strs = append(strs, str)
+	}
+	if list == nil || len(strs) != len(list) {
+		s.invalid(key, "must be a list of strings")
+		return nil, false
+	}
+	return strs, true
+}
+
+// seconds reads a duration, e.g. 1m, as the whole number of seconds of the agent JSON intervals.
+func (s *settings) seconds(key string) (int, bool) {
+	value, ok := s.get(key)
+	if !ok || value == nil {
+		return 0, false
+	}
+	str, _ := value.(string)
+	duration, err := time.ParseDuration(str)
+	if err != nil {
+		s.invalid(key, "must be a duration")
+		return 0, false
+	}
+	if duration <= 0 || duration%time.Second != 0 {
+		s.invalid(key, "has no agent equivalent, the agent intervals are whole seconds")
+		return 0, false
+	}
+	return int(duration / time.Second), true
+}
+
+// expect reads a setting that the agent does not configure, which is only mapped if it has the
+// value the agent uses.
+func (s *settings) expect(key string, want interface{}) {
+	value, ok := s.get(key)
+	if ok && value != nil && !reflect.DeepEqual(value, want) {
+		s.invalid(key, "has no agent equivalent, the agent uses %v", want)
+	}
+}
+
+// sub returns the settings of a nested map. Its issues are reported with the issues of s.
+func (s *settings) sub(key string) *settings {
+	value, _ := s.get(key)
+	return newSettings(s.keyPath(key), value)
+}
+
+// merge adds the issues and notes of the nested settings to s.
+func (s *settings) merge(sub *settings) {
+	s.issues = append(s.issues, sub.unmapped()...)
+	s.notes = append(s.notes, sub.notes...)
+}
+
+// unmapped returns the issues of the settings, including the settings that were not read.
+func (s *settings) unmapped() []Issue {
+	issues := s.issues
+	var keys []string
+	for key := range s.values {
+		if !s.read[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		issues = append(issues, Issue{Path: s.keyPath(key), Message: "has no agent equivalent"})
+	}
+	return issues
+}
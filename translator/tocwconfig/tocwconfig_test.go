@@ -619,6 +619,78 @@ func TestDeltaNetConfigLinux(t *testing.T) {
 	checkTranslation(t, "delta_net_config_linux", "darwin", nil, "")
 }
 
+func TestDerivedMetricsConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "derived_metrics_config_linux", "linux", nil, "")
+}
+
+func TestAggregationRulesConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "aggregation_rules_config_linux", "linux", nil, "")
+}
+
+func TestPSICgroupsConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "psi_cgroups_config_linux", "linux", nil, "")
+}
+
+func TestSystemdUnitsConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "systemd_units_config_linux", "linux", nil, "")
+}
+
+func TestCertificatesConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "certificates_config_linux", "linux", nil, "")
+}
+
+func TestEndpointsConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "endpoints_config_linux", "linux", nil, "")
+}
+
+func TestFilesystemPathsConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "filesystem_paths_config_linux", "linux", nil, "")
+}
+
+func TestKernelNetConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "kernel_net_config_linux", "linux", nil, "")
+}
+
+func TestNFSConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "nfs_config_linux", "linux", nil, "")
+}
+
+func TestProcstatAggregateConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "procstat_aggregate_config_linux", "linux", nil, "")
+}
+
+func TestExecConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "exec_config_linux", "linux", nil, "")
+}
+
+func TestTextfileConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "textfile_config_linux", "linux", nil, "")
+}
+
 func TestECSNodeMetricConfig(t *testing.T) {
 	resetContext(t)
 	context.CurrentContext().SetRunInContainer(true)
@@ -22,6 +22,28 @@ var Registered_Metrics_Linux = map[string][]string{
 		"rlimit_realtime_priority_hard", "rlimit_realtime_priority_soft", "rlimit_signals_pending_hard", "rlimit_signals_pending_soft", "signals_pending", "voluntary_context_switches", "write_bytes", "write_count", "pid_count"},
 	"nvidia_smi": {"utilization_gpu", "temperature_gpu", "power_draw", "utilization_memory", "fan_speed", "memory_total", "memory_used", "memory_free", "temperature_gpu", "pcie_link_gen_current", "pcie_link_width_current",
 		"encoder_stats_session_count", "encoder_stats_average_fps", "encoder_stats_average_latency", "clocks_current_graphics", "clocks_current_sm", "clocks_current_memory", "clocks_current_video"},
+	"psi": {"cpu_some_avg10", "cpu_some_avg60", "cpu_some_avg300", "cpu_full_avg10", "cpu_full_avg60", "cpu_full_avg300",
+		"memory_some_avg10", "memory_some_avg60", "memory_some_avg300", "memory_full_avg10", "memory_full_avg60", "memory_full_avg300",
+		"io_some_avg10", "io_some_avg60", "io_some_avg300", "io_full_avg10", "io_full_avg60", "io_full_avg300",
+		"irq_full_avg10", "irq_full_avg60", "irq_full_avg300",
+		"cpu_some_total", "cpu_full_total", "memory_some_total", "memory_full_total", "io_some_total", "io_full_total", "irq_full_total"},
+	"cgroups": {"cpu_usage_percent", "cpu_throttled_percent", "memory_current", "memory_max", "memory_used_percent", "memory_swap_current",
+		"io_read_bytes_per_sec", "io_write_bytes_per_sec", "io_read_ops_per_sec", "io_write_ops_per_sec", "pids_current",
+		"cpu_pressure_some_avg10", "cpu_pressure_some_avg60", "cpu_pressure_some_avg300", "cpu_pressure_full_avg10", "cpu_pressure_full_avg60", "cpu_pressure_full_avg300",
+		"memory_pressure_some_avg10", "memory_pressure_some_avg60", "memory_pressure_some_avg300", "memory_pressure_full_avg10", "memory_pressure_full_avg60", "memory_pressure_full_avg300",
+		"io_pressure_some_avg10", "io_pressure_some_avg60", "io_pressure_some_avg300", "io_pressure_full_avg10", "io_pressure_full_avg60", "io_pressure_full_avg300"},
+	"systemd_units":    {"active", "active_state", "sub_state", "restarts", "time_since_state_change"},
+	"certificates":     {"days_until_expiry"},
+	"endpoints":        {"success", "status_code", "latency", "tls_handshake_time", "body_match"},
+	"filesystem_paths": {"file_count", "total_bytes", "oldest_file_age", "newest_file_mtime"},
+	"kernel_net": {"conntrack_count", "conntrack_max", "conntrack_used_percent", "softnet_processed", "softnet_dropped", "softnet_time_squeeze",
+		"tcp_active_opens", "tcp_passive_opens", "tcp_attempt_fails", "tcp_estab_resets", "tcp_curr_estab", "tcp_in_segs", "tcp_out_segs", "tcp_retrans_segs", "tcp_in_errs", "tcp_out_rsts",
+		"udp_in_datagrams", "udp_out_datagrams", "udp_no_ports", "udp_in_errors", "udp_rcvbuf_errors", "udp_sndbuf_errors",
+		"tcp_listen_overflows", "tcp_listen_drops", "tcp_syncookies_sent", "tcp_timeouts", "tcp_syn_retrans", "tcp_fast_retrans", "tcp_lost_retransmit", "tcp_backlog_drop",
+		"tcp_abort_on_timeout", "tcp_abort_on_memory", "tcp_rcvq_drop", "tcp_zero_window_drop", "tcp_reqq_full_drop", "tcp_reqq_full_do_cookies",
+		"sockets_used", "tcp_inuse", "tcp_orphan", "tcp_tw", "tcp_alloc", "tcp_mem_pages", "udp_inuse", "udp_mem_pages",
+		"file_nr_allocated", "file_nr_max", "file_nr_used_percent"},
+	"nfs": {"ops", "retrans", "timeouts", "rtt", "exec_time", "read_bytes", "write_bytes"},
 }
 
 // This served as the allowlisted metric name, which is registered under the plugin name
@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package certificates
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificates(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"WithoutMeasurement": {
+			input: `{"certificates":{"files":["/etc/ssl/certs/server.pem"]}}`,
+			want:  nil,
+		},
+		"WithFiles": {
+			input: `{"certificates":{"files":["/etc/ssl/certs/server.pem","/etc/pki/tls/certs/*.crt"],"measurement":["certificates_days_until_expiry","invalid"]}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"days_until_expiry"},
+				"files":     []interface{}{"/etc/ssl/certs/server.pem", "/etc/pki/tls/certs/*.crt"},
+			}},
+		},
+		"WithEndpoints": {
+			input: `{"certificates":{"endpoints":["localhost:443"],"server_name":"example.com","measurement":["days_until_expiry"],"metrics_collection_interval":3600,"append_dimensions":{"env":"prod"}}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass":   []string{"days_until_expiry"},
+				"endpoints":   []interface{}{"localhost:443"},
+				"server_name": "example.com",
+				"interval":    "3600s",
+				"tags":        map[string]interface{}{"env": "prod"},
+			}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			_, got := new(Certificates).ApplyRule(input)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package certificates
+
+const (
+	SectionKey_Endpoints  = "endpoints"
+	SectionKey_ServerName = "server_name"
+)
+
+type endpoints struct {
+}
+
+// ApplyRule passes the host:port addresses of the local TLS listeners to the input.
+func (e *endpoints) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_Endpoints]; ok {
+		returnKey, returnVal = SectionKey_Endpoints, val
+	}
+	return
+}
+
+type serverName struct {
+}
+
+// ApplyRule passes the SNI server name sent to the TLS listeners. The input uses the
+// host of each endpoint if it is not set.
+func (s *serverName) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_ServerName]; ok {
+		returnKey, returnVal = SectionKey_ServerName, val
+	}
+	return
+}
+
+func init() {
+	RegisterRule(SectionKey_Endpoints, new(endpoints))
+	RegisterRule(SectionKey_ServerName, new(serverName))
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package certificates
+
+const SectionKey_Files = "files"
+
+type files struct {
+}
+
+// ApplyRule passes the globs of the certificate files and directories to the input.
+func (f *files) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_Files]; ok {
+		returnKey, returnVal = SectionKey_Files, val
+	}
+	return
+}
+
+func init() {
+	f := new(files)
+	RegisterRule(SectionKey_Files, f)
+}
@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cgroups
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCgroups(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"WithoutMeasurement": {
+			input: `{"cgroups":{"resources":["*.service"]}}`,
+			want:  nil,
+		},
+		"WithMeasurement": {
+			input: `{"cgroups":{"measurement":["cgroups_cpu_usage_percent","memory_current","io_pressure_some_avg10"]}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"cpu_usage_percent", "memory_current", "io_pressure_some_avg10"},
+			}},
+		},
+		"WithResources": {
+			input: `{"cgroups":{"resources":["nginx.service","*.slice"],"measurement":["memory_current"],"metrics_collection_interval":120}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"memory_current"},
+				"units":     []interface{}{"nginx.service", "*.slice"},
+				"interval":  "120s",
+			}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			_, got := new(Cgroups).ApplyRule(input)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
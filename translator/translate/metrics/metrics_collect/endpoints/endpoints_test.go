@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package endpoints
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoints(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"WithoutMeasurement": {
+			input: `{"endpoints":{"urls":["http://localhost/health"]}}`,
+			want:  nil,
+		},
+		"WithURLs": {
+			input: `{"endpoints":{"urls":["http://localhost/health"],"measurement":["endpoints_success","latency","invalid"]}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"success", "latency"},
+				"urls":      []interface{}{"http://localhost/health"},
+			}},
+		},
+		"WithHTTPOptions": {
+			input: `{"endpoints":{
+				"urls":["https://localhost:8443/health"],
+				"tcp_addresses":["localhost:5432"],
+				"method":"HEAD",
+				"headers":{"Host":"example.com"},
+				"expected_status_codes":[200,204],
+				"body_regex":"ok",
+				"follow_redirects":true,
+				"insecure_skip_verify":true,
+				"timeout":5,
+				"measurement":["success","status_code"],
+				"metrics_collection_interval":120
+			}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass":             []string{"success", "status_code"},
+				"urls":                  []interface{}{"https://localhost:8443/health"},
+				"tcp_addresses":         []interface{}{"localhost:5432"},
+				"method":                "HEAD",
+				"headers":               map[string]interface{}{"Host": "example.com"},
+				"expected_status_codes": []int{200, 204},
+				"body_regex":            "ok",
+				"follow_redirects":      true,
+				"insecure_skip_verify":  true,
+				"timeout":               "5s",
+				"interval":              "120s",
+			}},
+		},
+		"WithFailureLog": {
+			input: `{"endpoints":{"tcp_addresses":["localhost:5432"],"failure_log_group_name":"failures","failure_log_stream_name":"probes","measurement":["success"]}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass":               []string{"success"},
+				"tcp_addresses":           []interface{}{"localhost:5432"},
+				"failure_log_group_name":  "failures",
+				"failure_log_stream_name": "probes",
+			}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			_, got := new(Endpoints).ApplyRule(input)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
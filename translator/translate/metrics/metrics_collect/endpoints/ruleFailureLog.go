@@ -0,0 +1,16 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package endpoints
+
+const (
+	SectionKey_FailureLogGroupName  = "failure_log_group_name"
+	SectionKey_FailureLogStreamName = "failure_log_stream_name"
+)
+
+// The failure events are published by the cloudwatchlogs output of the logs section. The
+// translation fails if the logs section is not configured.
+func init() {
+	RegisterRule(SectionKey_FailureLogGroupName, optionalKey(SectionKey_FailureLogGroupName))
+	RegisterRule(SectionKey_FailureLogStreamName, optionalKey(SectionKey_FailureLogStreamName))
+}
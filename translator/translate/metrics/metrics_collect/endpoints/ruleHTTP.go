@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package endpoints
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	SectionKey_Method              = "method"
+	SectionKey_Headers             = "headers"
+	SectionKey_BodyRegex           = "body_regex"
+	SectionKey_FollowRedirects     = "follow_redirects"
+	SectionKey_InsecureSkipVerify  = "insecure_skip_verify"
+	SectionKey_ExpectedStatusCodes = "expected_status_codes"
+)
+
+type expectedStatusCodes struct {
+}
+
+// ApplyRule converts the status codes to integers since JSON numbers are unmarshalled
+// as floats.
+func (e *expectedStatusCodes) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	val, ok := m[SectionKey_ExpectedStatusCodes]
+	if !ok {
+		return
+	}
+	arr, ok := val.([]interface{})
+	if !ok {
+		translator.AddErrorMessages(GetCurPath()+SectionKey_ExpectedStatusCodes, fmt.Sprintf("%v is not an array of status codes", val))
+		return
+	}
+	codes := make([]int, 0, len(arr))
+	for _, v := range arr {
+		code, ok := v.(float64)
+		if !ok {
+			translator.AddErrorMessages(GetCurPath()+SectionKey_ExpectedStatusCodes, fmt.Sprintf("%v is not a valid status code", v))
+			return
+		}
+		codes = append(codes, int(code))
+	}
+	return SectionKey_ExpectedStatusCodes, codes
+}
+
+func init() {
+	RegisterRule(SectionKey_Method, optionalKey(SectionKey_Method))
+	RegisterRule(SectionKey_Headers, optionalKey(SectionKey_Headers))
+	RegisterRule(SectionKey_BodyRegex, optionalKey(SectionKey_BodyRegex))
+	RegisterRule(SectionKey_FollowRedirects, optionalKey(SectionKey_FollowRedirects))
+	RegisterRule(SectionKey_InsecureSkipVerify, optionalKey(SectionKey_InsecureSkipVerify))
+	RegisterRule(SectionKey_ExpectedStatusCodes, new(expectedStatusCodes))
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package endpoints
+
+const (
+	SectionKey_URLs         = "urls"
+	SectionKey_TCPAddresses = "tcp_addresses"
+)
+
+// optionalKey passes the value of the key to the input as is if it is configured.
+type optionalKey string
+
+func (k optionalKey) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[string(k)]; ok {
+		returnKey, returnVal = string(k), val
+	}
+	return
+}
+
+func init() {
+	RegisterRule(SectionKey_URLs, optionalKey(SectionKey_URLs))
+	RegisterRule(SectionKey_TCPAddresses, optionalKey(SectionKey_TCPAddresses))
+}
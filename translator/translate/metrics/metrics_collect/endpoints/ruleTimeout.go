@@ -0,0 +1,27 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package endpoints
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const SectionKey_Timeout = "timeout"
+
+type timeout struct {
+}
+
+// ApplyRule converts the timeout of each probe in seconds to a duration. The input
+// uses a 10 second timeout if it is not configured.
+func (t *timeout) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[SectionKey_Timeout]; !ok {
+		return
+	}
+	return translator.DefaultTimeIntervalCase(SectionKey_Timeout, nil, input)
+}
+
+func init() {
+	RegisterRule(SectionKey_Timeout, new(timeout))
+}
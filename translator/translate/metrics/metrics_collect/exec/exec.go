@@ -0,0 +1,59 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"exec": {
+//		"commands": ["/opt/scripts/queue_depth.sh --queue orders"],
+//		"data_format": "json",
+//		"tag_keys": ["queue"],
+//		"timeout": 5,
+//		"run_as_user": "cwagent",
+//		"append_dimensions": {
+//			"team": "payments"
+//		},
+//		"metrics_collection_interval": 60
+//	}
+
+const SectionKey = "exec"
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type Exec struct {
+}
+
+func (e *Exec) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	resArr := []interface{}{}
+	result := map[string]interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		//Check if there are some config entry with rules applied
+		result = translator.ProcessRuleToApply(m[SectionKey], ChildRule, result)
+		//The metrics are named by the commands, so there is no measurement to process
+		util.ProcessAppendDimensionsAndInterval(m[SectionKey].(map[string]interface{}), SectionKey, result)
+		resArr = append(resArr, result)
+		returnKey = SectionKey
+		returnVal = resArr
+	}
+	return
+}
+
+func init() {
+	e := new(Exec)
+	parent.RegisterLinuxRule(SectionKey, e)
+}
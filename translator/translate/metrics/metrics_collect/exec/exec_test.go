@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExec(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"WithoutSection": {
+			input: `{"cpu":{}}`,
+			want:  "",
+		},
+		"WithDefaults": {
+			input: `{"exec":{"commands":["/opt/scripts/queue_depth.sh --queue orders"]}}`,
+			want: []interface{}{map[string]interface{}{
+				"commands":    []string{"/opt/scripts/queue_depth.sh --queue orders"},
+				"data_format": "influx",
+			}},
+		},
+		"WithAllOptions": {
+			input: `{"exec":{"commands":["/opt/scripts/a.sh","/opt/scripts/b.sh"],"data_format":"json","tag_keys":["queue"],"timeout":10,"run_as_user":"cwagent","append_dimensions":{"team":"payments"},"metrics_collection_interval":120}}`,
+			want: []interface{}{map[string]interface{}{
+				"commands":    []string{"/opt/scripts/a.sh", "/opt/scripts/b.sh"},
+				"data_format": "json",
+				"tag_keys":    []interface{}{"queue"},
+				"timeout":     "10s",
+				"run_as_user": "cwagent",
+				"tags":        map[string]interface{}{"team": "payments"},
+				"interval":    "120s",
+			}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			_, got := new(Exec).ApplyRule(input)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
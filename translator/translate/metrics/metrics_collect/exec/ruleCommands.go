@@ -0,0 +1,21 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const SectionKey_Commands = "commands"
+
+type Commands struct {
+}
+
+func (c *Commands) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	return translator.DefaultStringArrayCase(SectionKey_Commands, []interface{}{}, input)
+}
+
+func init() {
+	RegisterRule(SectionKey_Commands, new(Commands))
+}
@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	SectionKey_DataFormat = "data_format"
+	defaultDataFormat     = "influx"
+)
+
+type DataFormat struct {
+}
+
+func (d *DataFormat) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	return translator.DefaultCase(SectionKey_DataFormat, defaultDataFormat, input)
+}
+
+func init() {
+	RegisterRule(SectionKey_DataFormat, new(DataFormat))
+}
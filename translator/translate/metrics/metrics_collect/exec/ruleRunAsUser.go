@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+const SectionKey_RunAsUser = "run_as_user"
+
+type RunAsUser struct {
+}
+
+// ApplyRule sets the user that runs the commands. The commands run as the user of the
+// agent if it is not configured.
+func (r *RunAsUser) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_RunAsUser]; ok {
+		returnKey = SectionKey_RunAsUser
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	RegisterRule(SectionKey_RunAsUser, new(RunAsUser))
+}
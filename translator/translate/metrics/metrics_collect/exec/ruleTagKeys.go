@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package exec
+
+const SectionKey_TagKeys = "tag_keys"
+
+type TagKeys struct {
+}
+
+// ApplyRule sets the keys of the JSON output that are added as tags instead of fields.
+func (t *TagKeys) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_TagKeys]; ok {
+		returnKey = SectionKey_TagKeys
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	RegisterRule(SectionKey_TagKeys, new(TagKeys))
+}
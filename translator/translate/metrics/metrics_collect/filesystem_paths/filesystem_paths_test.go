@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package filesystem_paths
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemPaths(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"WithoutMeasurement": {
+			input: `{"filesystem_paths":{"resources":["/var/spool/postfix/deferred"]}}`,
+			want:  nil,
+		},
+		"WithResources": {
+			input: `{"filesystem_paths":{"resources":["/var/spool/postfix/deferred","/var/log/app/**.log"],"measurement":["filesystem_paths_file_count","oldest_file_age","invalid"]}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"file_count", "oldest_file_age"},
+				"paths":     []interface{}{"/var/spool/postfix/deferred", "/var/log/app/**.log"},
+			}},
+		},
+		"WithExclude": {
+			input: `{"filesystem_paths":{"resources":["/var/log/app"],"exclude":["*.gz"],"measurement":["total_bytes"],"metrics_collection_interval":300,"append_dimensions":{"env":"prod"}}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"total_bytes"},
+				"paths":     []interface{}{"/var/log/app"},
+				"exclude":   []interface{}{"*.gz"},
+				"interval":  "300s",
+				"tags":      map[string]interface{}{"env": "prod"},
+			}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			_, got := new(FilesystemPaths).ApplyRule(input)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
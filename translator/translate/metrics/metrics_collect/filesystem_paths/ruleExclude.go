@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package filesystem_paths
+
+type exclude struct {
+}
+
+const SectionKey_Exclude = "exclude"
+
+// ApplyRule passes the globs of the files to skip to the input.
+func (e *exclude) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_Exclude]; ok {
+		returnKey, returnVal = SectionKey_Exclude, val
+	}
+	return
+}
+
+func init() {
+	e := new(exclude)
+	RegisterRule(SectionKey_Exclude, e)
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package filesystem_paths
+
+import "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+
+type paths struct {
+}
+
+const SectionKey_Paths = "paths"
+
+// ApplyRule maps the resources to the globs of the files to report on.
+func (p *paths) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[util.Resource_Key]; !ok {
+		return
+	}
+	return SectionKey_Paths, m[util.Resource_Key]
+}
+
+func init() {
+	p := new(paths)
+	RegisterRule(util.Resource_Key, p)
+}
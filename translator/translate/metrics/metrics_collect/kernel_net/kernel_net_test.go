@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kernel_net
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKernelNet(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"WithoutMeasurement": {
+			input: `{"kernel_net":{}}`,
+			want:  nil,
+		},
+		"WithMeasurement": {
+			input: `{"kernel_net":{"measurement":["kernel_net_conntrack_count","tcp_retrans_segs","invalid"]}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"conntrack_count", "tcp_retrans_segs"},
+			}},
+		},
+		"WithInterval": {
+			input: `{"kernel_net":{"measurement":["softnet_dropped"],"metrics_collection_interval":120,"append_dimensions":{"env":"prod"}}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"softnet_dropped"},
+				"interval":  "120s",
+				"tags":      map[string]interface{}{"env": "prod"},
+			}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			_, got := new(KernelNet).ApplyRule(input)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nfs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNFS(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"WithoutMeasurement": {
+			input: `{"nfs":{"resources":["/mnt/efs"]}}`,
+			want:  nil,
+		},
+		"WithAllMountPoints": {
+			input: `{"nfs":{"resources":["*"],"measurement":["rtt","nfs_retrans"],"drop_device":false}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"rtt", "retrans"},
+			}},
+		},
+		"WithFilters": {
+			input: `{"nfs":{"resources":["/mnt/efs","/mnt/build"],"ignore_file_system_types":["nfs"],"operations":["READ","WRITE"],"measurement":["exec_time","read_bytes"],"drop_device":true,"metrics_collection_interval":120}}`,
+			want: []interface{}{map[string]interface{}{
+				"mount_points": []interface{}{"/mnt/efs", "/mnt/build"},
+				"ignore_fs":    []interface{}{"nfs"},
+				"operations":   []interface{}{"READ", "WRITE"},
+				"tagexclude":   []string{"device"},
+				"fieldpass":    []string{"exec_time", "read_bytes"},
+				"interval":     "120s",
+			}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			_, got := new(NFS).ApplyRule(input)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
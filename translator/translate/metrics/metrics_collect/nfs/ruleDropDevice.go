@@ -0,0 +1,27 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nfs
+
+const (
+	tagExcludeKey = "tagexclude"
+	dropDeviceKey = "drop_device"
+)
+
+// DropDevice drops the device dimension, i.e. the server and export, so that the
+// metrics of a mount point do not change when it is mounted from another server.
+type DropDevice struct {
+}
+
+func (d *DropDevice) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[dropDeviceKey].(bool); ok && val {
+		returnKey = tagExcludeKey
+		returnVal = []string{"device"}
+	}
+	return
+}
+
+func init() {
+	RegisterRule(dropDeviceKey, new(DropDevice))
+}
@@ -0,0 +1,14 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nfs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/disk"
+)
+
+// The mount points are filtered the same way as the disk input.
+func init() {
+	RegisterRule("mount_points", new(disk.MountPoints))
+	RegisterRule("ignore_fs", new(disk.IgnoreFs))
+}
@@ -0,0 +1,22 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nfs
+
+const SectionKey_Operations = "operations"
+
+type Operations struct {
+}
+
+func (o *Operations) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_Operations]; ok {
+		returnKey = SectionKey_Operations
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	RegisterRule(SectionKey_Operations, new(Operations))
+}
@@ -41,6 +41,9 @@ func (p *Procstat) ApplyRule(input interface{}) (returnKey string, returnVal int
 	resArray := []interface{}{}
 	configArray := im[SectionKey].([]interface{})
 	for _, processConfig := range configArray {
+		if IsAggregate(processConfig.(map[string]interface{})) {
+			continue
+		}
 		result := map[string]interface{}{}
 		// common config
 		if !util.ProcessLinuxCommonConfig(processConfig, SectionKey, GetCurPath(), result) {
@@ -68,6 +71,9 @@ func (p *Procstat) ApplyRule(input interface{}) (returnKey string, returnVal int
 		}
 		resArray = append(resArray, result)
 	}
+	if len(resArray) == 0 {
+		return
+	}
 
 	returnKey = SectionKey
 	returnVal = resArray
@@ -0,0 +1,98 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package procstat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/hash"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+// AggregateSectionKey is the input of the procstat entries with aggregate_by, e.g.
+//
+//	"procstat": [
+//		{
+//			"aggregate_by": "cgroup",
+//			"cgroup_depth": 1,
+//			"top_n": 5,
+//			"top_by": "memory_rss",
+//			"measurement": ["cpu_usage", "memory_rss"]
+//		}
+//	]
+//
+// The entries report the total usage of the processes per user, cgroup or command
+// instead of per process, so they are translated to another input.
+const AggregateSectionKey = "procstat_aggregate"
+
+const AggregateByKey = "aggregate_by"
+
+// aggregateKeys are passed to the procstat_aggregate input as is.
+var aggregateKeys = []string{AggregateByKey, "cgroup_depth", "top_n", "top_by", ExeKey, PatternKey}
+
+type ProcstatAggregate struct {
+}
+
+func (p *ProcstatAggregate) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	configArray, ok := im[SectionKey].([]interface{})
+	if !ok {
+		return
+	}
+	resArray := []interface{}{}
+	for _, processConfig := range configArray {
+		m := processConfig.(map[string]interface{})
+		if !IsAggregate(m) {
+			continue
+		}
+		result := map[string]interface{}{}
+		// the aggregated metrics have the same names as the procstat metrics
+		if !util.ProcessLinuxCommonConfig(processConfig, SectionKey, GetCurPath(), result) {
+			continue
+		}
+		for _, key := range aggregateKeys {
+			if val, ok := m[key]; ok {
+				// the JSON numbers are floats but the input expects integers
+				if f, ok := val.(float64); ok {
+					val = int(f)
+				}
+				result[key] = val
+			}
+		}
+		result[util.Alias_Key] = hash.HashName(AggregateName(m))
+		resArray = append(resArray, result)
+	}
+	if len(resArray) > 0 {
+		returnKey = AggregateSectionKey
+		returnVal = resArray
+	}
+	return
+}
+
+// IsAggregate returns true if the procstat entry aggregates the processes.
+func IsAggregate(processConfig map[string]interface{}) bool {
+	_, ok := processConfig[AggregateByKey]
+	return ok
+}
+
+// AggregateName is the name of the receiver of the aggregated procstat entry, e.g.
+// "aggregate_by:command top_n:5 exe:java".
+func AggregateName(processConfig map[string]interface{}) string {
+	var parts []string
+	for _, key := range aggregateKeys {
+		if val, ok := processConfig[key]; ok {
+			parts = append(parts, fmt.Sprintf("%s:%v", key, val))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func init() {
+	p := new(ProcstatAggregate)
+	parent.RegisterLinuxRule(AggregateSectionKey, p)
+	parent.RegisterDarwinRule(AggregateSectionKey, p)
+	parent.RegisterWindowsRule(AggregateSectionKey, p)
+}
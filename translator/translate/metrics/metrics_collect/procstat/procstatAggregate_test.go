@@ -0,0 +1,90 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package procstat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/hash"
+)
+
+func TestAggregateConfig(t *testing.T) {
+	input := []byte(`{"procstat": [
+	{
+	    "measurement": ["cpu_usage"],
+	    "exe": "amazon-cloudwatch"
+	},
+	{
+	    "measurement": ["cpu_usage", "memory_rss", "pid_count"],
+	    "aggregate_by": "cgroup",
+	    "cgroup_depth": 1
+	},
+	{
+	    "measurement": ["cpu_usage"],
+	    "aggregate_by": "command",
+	    "top_n": 5,
+	    "top_by": "cpu_usage",
+	    "pattern": "java",
+	    "metrics_collection_interval": 120
+	}
+      ]}`)
+	var in interface{}
+	require.NoError(t, json.Unmarshal(input, &in))
+
+	key, got := new(ProcstatAggregate).ApplyRule(in)
+	assert.Equal(t, AggregateSectionKey, key)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"aggregate_by": "cgroup",
+			"cgroup_depth": 1,
+			"alias":        hash.HashName("aggregate_by:cgroup cgroup_depth:1"),
+			"fieldpass":    []string{"cpu_usage", "memory_rss", "pid_count"},
+		},
+		map[string]interface{}{
+			"aggregate_by": "command",
+			"top_n":        5,
+			"top_by":       "cpu_usage",
+			"pattern":      "java",
+			"alias":        hash.HashName("aggregate_by:command top_n:5 top_by:cpu_usage pattern:java"),
+			"fieldpass":    []string{"cpu_usage"},
+			"interval":     "120s",
+		},
+	}, got)
+
+	// the aggregated entries are not monitored by the procstat input
+	checkResult(t, input, []interface{}{map[string]interface{}{
+		"exe":        "amazon-cloudwatch",
+		"alias":      hash.HashName("amazon-cloudwatch"),
+		"pid_finder": "native",
+		"fieldpass":  []string{"cpu_usage"},
+		"tagexclude": []string{"user", "result"},
+	}})
+}
+
+func TestAggregateOnlyConfig(t *testing.T) {
+	input := []byte(`{"procstat": [
+	{
+	    "measurement": ["memory_rss"],
+	    "aggregate_by": "user"
+	}
+      ]}`)
+	checkResult(t, input, "")
+
+	var in interface{}
+	require.NoError(t, json.Unmarshal(input, &in))
+	key, got := new(ProcstatAggregate).ApplyRule(in)
+	assert.Equal(t, AggregateSectionKey, key)
+	assert.Len(t, got, 1)
+}
+
+func TestWithoutAggregateConfig(t *testing.T) {
+	var in interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"procstat": [{"measurement": ["cpu_usage"], "exe": "sshd"}]}`), &in))
+	key, _ := new(ProcstatAggregate).ApplyRule(in)
+	assert.Empty(t, key)
+}
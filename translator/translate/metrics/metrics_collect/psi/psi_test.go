@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package psi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPSI(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"WithoutMeasurement": {
+			input: `{"psi":{"metrics_collection_interval":30}}`,
+			want:  nil,
+		},
+		"WithMeasurement": {
+			input: `{"psi":{"measurement":["psi_cpu_some_avg10","memory_full_avg60","invalid"]}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"cpu_some_avg10", "memory_full_avg60"},
+			}},
+		},
+		"WithAllResources": {
+			input: `{"psi":{"resources":["*"],"measurement":["io_some_avg10"]}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"io_some_avg10"},
+			}},
+		},
+		"WithResources": {
+			input: `{"psi":{"resources":["cpu","io"],"measurement":["io_some_avg10"],"metrics_collection_interval":120,"append_dimensions":{"env":"prod"}}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"io_some_avg10"},
+				"resources": []interface{}{"cpu", "io"},
+				"interval":  "120s",
+				"tags":      map[string]interface{}{"env": "prod"},
+			}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			_, got := new(PSI).ApplyRule(input)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package psi
+
+import "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+
+type resources struct {
+}
+
+// ApplyRule limits the pressure files read to the configured resources. All of the
+// resources available on the host are read if none or "*" are configured.
+func (r *resources) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[util.Resource_Key]; !ok || util.ContainAsterisk(input, util.Resource_Key) {
+		return
+	}
+	return util.Resource_Key, m[util.Resource_Key]
+}
+
+func init() {
+	r := new(resources)
+	RegisterRule(util.Resource_Key, r)
+}
@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package statsd
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+type Events struct {
+}
+
+const (
+	SectionKey_Events = "events"
+
+	eventsDestination = "cloudwatchlogs"
+)
+
+// ApplyRule translates the log group that DogStatsD events and service checks are
+// published to by the cloudwatchlogs output of the logs section, e.g.
+//
+//	"events": {
+//	    "log_group_name": "statsd-events",
+//	    "log_stream_name": "{instance_id}",
+//	    "retention_in_days": 7
+//	}
+func (obj *Events) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	events, ok := im[SectionKey_Events].(map[string]interface{})
+	if !ok {
+		return
+	}
+	result := map[string]interface{}{
+		"destination": eventsDestination,
+	}
+	for _, key := range []string{"log_group_name", "log_stream_name"} {
+		if _, val := translator.DefaultCase(key, "", events); val != "" {
+			result[key] = util.ResolvePlaceholder(val.(string), logs.GlobalLogConfig.MetadataInfo)
+		}
+	}
+	if _, val := translator.DefaultRetentionInDaysCase("retention_in_days", float64(-1), events); val != -1 {
+		result["retention_in_days"] = val
+	}
+	if _, val := translator.DefaultLogGroupClassCase("log_group_class", "", events); val != "" {
+		result["log_group_class"] = val
+	}
+	return SectionKey_Events, result
+}
+
+func init() {
+	obj := new(Events)
+	RegisterRule(SectionKey_Events, obj)
+}
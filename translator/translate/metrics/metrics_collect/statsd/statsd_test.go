@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
 )
 
 func TestStatsD_HappyCase(t *testing.T) {
@@ -100,3 +103,63 @@ func TestStatsD_MetricSeparator(t *testing.T) {
 
 	assert.Equal(t, expect, actual)
 }
+
+func TestStatsD_Events(t *testing.T) {
+	obj := new(StatsD)
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"statsd": {
+					"service_address": "unixgram:///var/run/cwagent/statsd.sock",
+					"events": {
+						"log_group_name": "statsd-events",
+						"log_stream_name": "my-host",
+						"retention_in_days": 7
+					}
+					}}`), &input)
+	assert.NoError(t, err)
+
+	_, actual := obj.ApplyRule(input)
+
+	expect := []interface{}{
+		map[string]interface{}{
+			"service_address":     "unixgram:///var/run/cwagent/statsd.sock",
+			"interval":            "10s",
+			"parse_data_dog_tags": true,
+			"tags":                map[string]interface{}{"aws:AggregationInterval": "60s"},
+			"events": map[string]interface{}{
+				"destination":       "cloudwatchlogs",
+				"log_group_name":    "statsd-events",
+				"log_stream_name":   "my-host",
+				"retention_in_days": 7,
+			},
+		},
+	}
+
+	assert.Equal(t, expect, actual)
+}
+
+func TestStatsD_EventsPlaceholders(t *testing.T) {
+	logs.GlobalLogConfig.MetadataInfo = util.GetMetadataInfo(func() *util.Metadata {
+		return &util.Metadata{InstanceID: "i-0123456789", Hostname: "my-host"}
+	})
+	t.Cleanup(func() {
+		logs.GlobalLogConfig.MetadataInfo = nil
+	})
+	obj := new(StatsD)
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"statsd": {
+					"events": {
+						"log_group_name": "statsd-events-{hostname}",
+						"log_stream_name": "{instance_id}"
+					}
+					}}`), &input)
+	assert.NoError(t, err)
+
+	_, actual := obj.ApplyRule(input)
+
+	events := actual.([]interface{})[0].(map[string]interface{})["events"]
+	assert.Equal(t, map[string]interface{}{
+		"destination":     "cloudwatchlogs",
+		"log_group_name":  "statsd-events-my-host",
+		"log_stream_name": "i-0123456789",
+	}, events)
+}
@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package systemd_units
+
+import "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+
+type units struct {
+}
+
+const SectionKey_Units = "units"
+
+// ApplyRule maps the resources to the glob patterns of the systemd units to collect.
+// The input reports all services if no resources are configured.
+func (u *units) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if _, ok := m[util.Resource_Key]; !ok {
+		return
+	}
+	return SectionKey_Units, m[util.Resource_Key]
+}
+
+func init() {
+	u := new(units)
+	RegisterRule(util.Resource_Key, u)
+}
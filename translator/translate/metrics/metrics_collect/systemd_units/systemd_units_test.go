@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package systemd_units
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemdUnits(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"WithoutMeasurement": {
+			input: `{"systemd_units":{"resources":["nginx.service"]}}`,
+			want:  nil,
+		},
+		"WithMeasurement": {
+			input: `{"systemd_units":{"measurement":["systemd_units_active","restarts","invalid"]}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"active", "restarts"},
+			}},
+		},
+		"WithResources": {
+			input: `{"systemd_units":{"resources":["nginx.service","docker.*"],"measurement":["active","time_since_state_change"],"append_dimensions":{"env":"prod"}}}`,
+			want: []interface{}{map[string]interface{}{
+				"fieldpass": []string{"active", "time_since_state_change"},
+				"units":     []interface{}{"nginx.service", "docker.*"},
+				"tags":      map[string]interface{}{"env": "prod"},
+			}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			_, got := new(SystemdUnits).ApplyRule(input)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
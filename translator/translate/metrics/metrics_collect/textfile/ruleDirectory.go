@@ -0,0 +1,21 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package textfile
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const SectionKey_Directory = "directory"
+
+type Directory struct {
+}
+
+func (d *Directory) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	return translator.DefaultCase(SectionKey_Directory, "", input)
+}
+
+func init() {
+	RegisterRule(SectionKey_Directory, new(Directory))
+}
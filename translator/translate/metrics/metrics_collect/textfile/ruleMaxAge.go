@@ -0,0 +1,27 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package textfile
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const SectionKey_MaxAge = "max_age"
+
+type MaxAge struct {
+}
+
+// ApplyRule converts the max age of the files in seconds to a duration. The input skips
+// the files that were not modified in the last hour if it is not configured.
+func (m *MaxAge) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	if _, ok := im[SectionKey_MaxAge]; !ok {
+		return
+	}
+	return translator.DefaultTimeIntervalCase(SectionKey_MaxAge, nil, input)
+}
+
+func init() {
+	RegisterRule(SectionKey_MaxAge, new(MaxAge))
+}
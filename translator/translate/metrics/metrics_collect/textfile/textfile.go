@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package textfile
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+//	"textfile": {
+//		"directory": "/var/lib/node_exporter/textfile_collector",
+//		"max_age": 3600,
+//		"append_dimensions": {
+//			"team": "backups"
+//		},
+//		"metrics_collection_interval": 60
+//	}
+
+const SectionKey = "textfile"
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = r
+}
+
+type Textfile struct {
+}
+
+func (t *Textfile) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	resArr := []interface{}{}
+	result := map[string]interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		//Check if there are some config entry with rules applied
+		result = translator.ProcessRuleToApply(m[SectionKey], ChildRule, result)
+		//The metrics are named by the files, so there is no measurement to process
+		util.ProcessAppendDimensionsAndInterval(m[SectionKey].(map[string]interface{}), SectionKey, result)
+		resArr = append(resArr, result)
+		returnKey = SectionKey
+		returnVal = resArr
+	}
+	return
+}
+
+func init() {
+	t := new(Textfile)
+	parent.RegisterLinuxRule(SectionKey, t)
+}
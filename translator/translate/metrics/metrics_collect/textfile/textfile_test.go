@@ -0,0 +1,47 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package textfile
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextfile(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  interface{}
+	}{
+		"WithoutSection": {
+			input: `{"cpu":{}}`,
+			want:  "",
+		},
+		"WithDefaults": {
+			input: `{"textfile":{"directory":"/var/lib/node_exporter/textfile_collector"}}`,
+			want: []interface{}{map[string]interface{}{
+				"directory": "/var/lib/node_exporter/textfile_collector",
+			}},
+		},
+		"WithAllOptions": {
+			input: `{"textfile":{"directory":"/var/lib/textfile","max_age":86400,"append_dimensions":{"team":"backups"},"metrics_collection_interval":300}}`,
+			want: []interface{}{map[string]interface{}{
+				"directory": "/var/lib/textfile",
+				"max_age":   "86400s",
+				"tags":      map[string]interface{}{"team": "backups"},
+				"interval":  "300s",
+			}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			_, got := new(Textfile).ApplyRule(input)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
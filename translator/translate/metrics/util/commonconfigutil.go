@@ -48,6 +48,13 @@ func ProcessLinuxCommonConfig(input interface{}, pluginName string, path string,
 		return false
 	}
 
+	ProcessAppendDimensionsAndInterval(inputMap, pluginName, result)
+	return true
+}
+
+// ProcessAppendDimensionsAndInterval sets the tags and interval of the plugin for the
+// plugins that do not have a measurement field since the metric names are not known.
+func ProcessAppendDimensionsAndInterval(inputMap map[string]interface{}, pluginName string, result map[string]interface{}) {
 	ProcessAppendDimensions(inputMap, pluginName, result)
 
 	isHighResolution := IsHighResolution(agent.Global_Config.Interval)
@@ -60,8 +67,8 @@ func ProcessLinuxCommonConfig(input interface{}, pluginName string, path string,
 			result[Append_Dimensions_Mapped_Key] = map[string]interface{}{util.High_Resolution_Tag_Key: "true"}
 		}
 	}
-	return true
 }
+
 func ProcessAppendDimensions(inputMap map[string]interface{}, pluginName string, result map[string]interface{}) {
 	// Set append_dimensions as tags
 	if val, ok := inputMap[Append_Dimensions_Key]; ok {
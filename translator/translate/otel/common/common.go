@@ -50,6 +50,7 @@ const (
 	SigV4Auth                          = "sigv4auth"
 	MetricsCollectionIntervalKey       = "metrics_collection_interval"
 	AggregationDimensionsKey           = "aggregation_dimensions"
+	AggregationRulesKey                = "aggregation_rules"
 	MeasurementKey                     = "measurement"
 	DropOriginalMetricsKey             = "drop_original_metrics"
 	ForceFlushIntervalKey              = "force_flush_interval"
@@ -62,6 +63,8 @@ const (
 	DiskKey                            = "disk"
 	DiskIOKey                          = "diskio"
 	NetKey                             = "net"
+	KernelNetKey                       = "kernel_net"
+	PSIKey                             = "psi"
 	Emf                                = "emf"
 	StructuredLog                      = "structuredlog"
 	ServiceAddress                     = "service_address"
@@ -75,6 +78,9 @@ const (
 	NameKey                            = "name"
 	RenameKey                          = "rename"
 	UnitKey                            = "unit"
+	DerivedMetricsKey                  = "derived_metrics"
+	RatesKey                           = "rates"
+	ExpressionsKey                     = "expressions"
 )
 
 const (
@@ -134,6 +140,8 @@ var (
 
 	AgentDebugConfigKey             = ConfigKey(AgentKey, DebugKey)
 	MetricsAggregationDimensionsKey = ConfigKey(MetricsKey, AggregationDimensionsKey)
+	MetricsDerivedRatesKey          = ConfigKey(MetricsKey, DerivedMetricsKey, RatesKey)
+	MetricsDerivedExpressionsKey    = ConfigKey(MetricsKey, DerivedMetricsKey, ExpressionsKey)
 )
 
 // Translator is used to translate the JSON config into an
@@ -19,6 +19,10 @@ const (
 	forceFlushIntervalKey = "force_flush_interval"
 	dropOriginalWildcard  = "*"
 
+	metricNamePatternKey   = "metric_name_pattern"
+	aggregationIntervalKey = "aggregation_interval"
+	storageResolutionKey   = "storage_resolution"
+
 	internalMaxValuesPerDatum = 5000
 )
 
@@ -71,10 +75,45 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	if dropOriginalMetrics := common.GetDropOriginalMetrics(conf); len(dropOriginalMetrics) != 0 {
 		cfg.DropOriginalConfigs = dropOriginalMetrics
 	}
+	cfg.AggregationRules = getAggregationRules(conf)
 	cfg.MiddlewareID = &agenthealth.MetricsID
 	return cfg, nil
 }
 
+// getAggregationRules translates the aggregation_rules section, e.g.
+//
+//	"aggregation_rules": [
+//	  {"metric_name_pattern": "latency_*", "aggregation_interval": 10, "storage_resolution": 1},
+//	  {"metric_name_pattern": "*", "aggregation_interval": 60}
+//	]
+func getAggregationRules(conf *confmap.Conf) []cloudwatch.AggregationRule {
+	rules, ok := conf.Get(common.ConfigKey(common.MetricsKey, common.AggregationRulesKey)).([]interface{})
+	if !ok {
+		return nil
+	}
+	var result []cloudwatch.AggregationRule
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ruleConf := confmap.NewFromStringMap(ruleMap)
+		pattern, ok := common.GetString(ruleConf, metricNamePatternKey)
+		if !ok {
+			continue
+		}
+		aggregationRule := cloudwatch.AggregationRule{MetricNamePattern: pattern}
+		if interval, ok := common.GetDuration(ruleConf, aggregationIntervalKey); ok {
+			aggregationRule.AggregationInterval = interval
+		}
+		if resolution, ok := common.GetNumber(ruleConf, storageResolutionKey); ok {
+			aggregationRule.StorageResolution = int64(resolution)
+		}
+		result = append(result, aggregationRule)
+	}
+	return result
+}
+
 func getRoleARN(conf *confmap.Conf) string {
 	key := common.ConfigKey(common.MetricsKey, common.CredentialsKey, common.RoleARNKey)
 	roleARN, ok := common.GetString(conf, key)
@@ -62,6 +62,35 @@ func TestTranslator(t *testing.T) {
 				RoleARN:            "global_arn",
 			},
 		},
+		"WithAggregationRules": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"aggregation_rules": []interface{}{
+					map[string]interface{}{
+						"metric_name_pattern":  "latency_*",
+						"aggregation_interval": float64(10),
+						"storage_resolution":   float64(1),
+					},
+					map[string]interface{}{
+						"metric_name_pattern":  "*",
+						"aggregation_interval": float64(60),
+					},
+					map[string]interface{}{
+						"aggregation_interval": float64(30),
+					},
+				},
+			}},
+			want: &cloudwatch.Config{
+				Namespace:          "CWAgent",
+				Region:             "us-east-1",
+				ForceFlushInterval: time.Minute,
+				MaxValuesPerDatum:  150,
+				RoleARN:            "global_arn",
+				AggregationRules: []cloudwatch.AggregationRule{
+					{MetricNamePattern: "latency_*", AggregationInterval: 10 * time.Second, StorageResolution: 1},
+					{MetricNamePattern: "*", AggregationInterval: time.Minute},
+				},
+			},
+		},
 		"WithInvalidCredentialFields": {
 			input: map[string]interface{}{"metrics": map[string]interface{}{}},
 			credentials: map[string]interface{}{
@@ -164,6 +193,7 @@ func TestTranslator(t *testing.T) {
 				assert.Equal(t, testCase.want.SharedCredentialFilename, gotCfg.SharedCredentialFilename)
 				assert.Equal(t, testCase.want.MaxValuesPerDatum, gotCfg.MaxValuesPerDatum)
 				assert.Equal(t, testCase.want.RollupDimensions, gotCfg.RollupDimensions)
+				assert.Equal(t, testCase.want.AggregationRules, gotCfg.AggregationRules)
 				assert.NotNil(t, gotCfg.MiddlewareID)
 				assert.Equal(t, "agenthealth/metrics", gotCfg.MiddlewareID.String())
 				if testCase.wantWindows != nil && runtime.GOOS == "windows" {
@@ -22,8 +22,10 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/awsentity"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/batchprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/cumulativetodeltaprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/deltatorateprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/ec2taggerprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/metricsdecorator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/metricsgenerationprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/rollupprocessor"
 )
 
@@ -83,11 +85,20 @@ func (t translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators,
 	}
 
 	if strings.HasPrefix(t.name, common.PipelineNameHostDeltaMetrics) || strings.HasPrefix(t.name, common.PipelineNameHostOtlpMetrics) {
-		log.Printf("D! delta processor required because metrics with diskio or net are set")
+		log.Printf("D! delta processor required because metrics with diskio, net, kernel_net or psi are set")
 		translators.Processors.Set(cumulativetodeltaprocessor.NewTranslator(common.WithName(t.name), cumulativetodeltaprocessor.WithDefaultKeys()))
+		if t.Destination() != common.CloudWatchLogsKey && conf.IsSet(common.MetricsDerivedRatesKey) {
+			log.Printf("D! delta to rate processor required because derived_metrics rates are set")
+			translators.Processors.Set(deltatorateprocessor.NewTranslator())
+		}
 	}
 
 	if t.Destination() != common.CloudWatchLogsKey {
+		if conf.IsSet(common.MetricsDerivedExpressionsKey) {
+			log.Printf("D! metrics generation processor required because derived_metrics expressions are set")
+			translators.Processors.Set(metricsgenerationprocessor.NewTranslator())
+		}
+
 		if conf.IsSet(common.ConfigKey(common.MetricsKey, common.AppendDimensionsKey)) {
 			log.Printf("D! ec2tagger processor required because append_dimensions is set")
 			translators.Processors.Set(ec2taggerprocessor.NewTranslator())
@@ -180,6 +180,54 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"agenthealth/metrics"},
 			},
 		},
+		"WithDerivedMetrics": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"derived_metrics": map[string]interface{}{
+						"rates": []interface{}{"net_bytes_sent"},
+						"expressions": []interface{}{
+							map[string]interface{}{
+								"name":      "disk_used_pct",
+								"type":      "calculate",
+								"metric1":   "disk_used",
+								"metric2":   "disk_total",
+								"operation": "percent",
+							},
+						},
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"awsentity/resource", "experimental_metricsgeneration"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics"},
+			},
+		},
+		"WithDerivedMetrics/Delta": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"net": map[string]interface{}{},
+					},
+					"derived_metrics": map[string]interface{}{
+						"rates": []interface{}{"net_bytes_sent"},
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHostDeltaMetrics,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/hostDeltaMetrics",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"awsentity/resource", "cumulativetodelta/hostDeltaMetrics", "deltatorate"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics"},
+			},
+		},
 		"WithPRWExporter/Aggregation": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{
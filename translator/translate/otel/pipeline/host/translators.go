@@ -35,7 +35,7 @@ func NewTranslators(conf *confmap.Conf, configSection, os string) (pipeline.Tran
 			return nil, fmt.Errorf("error finding receivers in config: %w", err)
 		}
 		adapterReceivers.Range(func(translator common.Translator[component.Config]) {
-			if translator.ID().Type() == adapter.Type(common.DiskIOKey) || translator.ID().Type() == adapter.Type(common.NetKey) {
+			if translator.ID().Type() == adapter.Type(common.DiskIOKey) || translator.ID().Type() == adapter.Type(common.NetKey) || translator.ID().Type() == adapter.Type(common.KernelNetKey) || translator.ID().Type() == adapter.Type(common.PSIKey) {
 				deltaReceivers.Set(translator)
 			} else if translator.ID().Type() == adapter.Type(common.StatsDMetricKey) || translator.ID().Type() == adapter.Type(common.CollectDPluginKey) {
 				hostCustomReceivers.Set(translator)
@@ -121,6 +121,48 @@ func TestTranslators(t *testing.T) {
 				},
 			},
 		},
+		"WithKernelNetDeltaMetrics": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"metrics_collected": map[string]any{
+						"cpu":        map[string]any{},
+						"kernel_net": map[string]any{},
+					},
+				},
+			},
+			configSection: MetricsKey,
+			want: map[string]want{
+				"metrics/host": {
+					receivers: []string{"telegraf_cpu"},
+					exporters: []string{"awscloudwatch"},
+				},
+				"metrics/hostDeltaMetrics": {
+					receivers: []string{"telegraf_kernel_net"},
+					exporters: []string{"awscloudwatch"},
+				},
+			},
+		},
+		"WithPSIDeltaMetrics": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"metrics_collected": map[string]any{
+						"cpu": map[string]any{},
+						"psi": map[string]any{},
+					},
+				},
+			},
+			configSection: MetricsKey,
+			want: map[string]want{
+				"metrics/host": {
+					receivers: []string{"telegraf_cpu"},
+					exporters: []string{"awscloudwatch"},
+				},
+				"metrics/hostDeltaMetrics": {
+					receivers: []string{"telegraf_psi"},
+					exporters: []string{"awscloudwatch"},
+				},
+			},
+		},
 		"WithOtlpMetrics/CloudWatch": {
 			input: map[string]any{
 				"metrics": map[string]any{
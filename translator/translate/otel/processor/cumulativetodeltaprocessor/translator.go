@@ -24,10 +24,12 @@ const (
 )
 
 var (
-	netKey     = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.NetKey)
-	diskioKey  = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.DiskIOKey)
-	otlpKey    = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.OtlpKey)
-	otlpEmfKey = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.OtlpKey)
+	netKey       = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.NetKey)
+	diskioKey    = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.DiskIOKey)
+	kernelNetKey = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.KernelNetKey)
+	psiKey       = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.PSIKey)
+	otlpKey      = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.OtlpKey)
+	otlpEmfKey   = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.OtlpKey)
 
 	exclusions = map[string][]string{
 		// DiskIO and Net Metrics are cumulative metrics
@@ -39,7 +41,8 @@ var (
 )
 
 func WithDefaultKeys() common.TranslatorOption {
-	return WithConfigKeys(diskioKey, netKey, otlpKey, otlpEmfKey)
+	// kernel_net and psi only add their counters as sums, so their gauges are not converted
+	return WithConfigKeys(diskioKey, netKey, kernelNetKey, psiKey, otlpKey, otlpEmfKey)
 }
 
 func WithConfigKeys(keys ...string) common.TranslatorOption {
@@ -31,7 +31,7 @@ func TestTranslator(t *testing.T) {
 					},
 				},
 			},
-			wantErr: &common.MissingKeyError{ID: cdpTranslator.ID(), JsonKey: fmt.Sprint(diskioKey, " or ", netKey, " or ", otlpKey, " or ", otlpEmfKey)},
+			wantErr: &common.MissingKeyError{ID: cdpTranslator.ID(), JsonKey: fmt.Sprint(diskioKey, " or ", netKey, " or ", kernelNetKey, " or ", psiKey, " or ", otlpKey, " or ", otlpEmfKey)},
 		},
 		"GenerateDeltaProcessorConfigWithNet": {
 			input: map[string]any{
@@ -45,6 +45,30 @@ func TestTranslator(t *testing.T) {
 				"initial_value": "drop",
 			},
 		},
+		"GenerateDeltaProcessorConfigWithKernelNet": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"metrics_collected": map[string]any{
+						"kernel_net": map[string]any{},
+					},
+				},
+			},
+			want: map[string]any{
+				"initial_value": "drop",
+			},
+		},
+		"GenerateDeltaProcessorConfigWithPSI": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"metrics_collected": map[string]any{
+						"psi": map[string]any{},
+					},
+				},
+			},
+			want: map[string]any{
+				"initial_value": "drop",
+			},
+		},
 		"GenerateDeltaProcessorConfigWithDiskIO": {
 			input: map[string]any{
 				"metrics": map[string]any{
@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package deltatorateprocessor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatorateprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.Translator[component.Config] = (*translator)(nil)
+
+func NewTranslator() common.Translator[component.Config] {
+	return NewTranslatorWithName("")
+}
+
+func NewTranslatorWithName(name string) common.Translator[component.Config] {
+	return &translator{name: name, factory: deltatorateprocessor.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// Translate creates a processor config that converts the delta sums listed
+// in the derived_metrics rates of the Metrics section into per-second rates.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(common.MetricsDerivedRatesKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.MetricsDerivedRatesKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*deltatorateprocessor.Config)
+	cfg.Metrics = common.GetArray[string](conf, common.MetricsDerivedRatesKey)
+	return cfg, nil
+}
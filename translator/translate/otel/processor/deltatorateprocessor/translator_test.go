@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package deltatorateprocessor
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatorateprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	dtrt := NewTranslator()
+	require.EqualValues(t, "deltatorate", dtrt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *deltatorateprocessor.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{
+				ID:      dtrt.ID(),
+				JsonKey: common.MetricsDerivedRatesKey,
+			},
+		},
+		"WithRates": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"derived_metrics": map[string]interface{}{
+						"rates": []interface{}{"net_bytes_sent", "diskio_reads"},
+					},
+				},
+			},
+			want: &deltatorateprocessor.Config{
+				Metrics: []string{"net_bytes_sent", "diskio_reads"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := dtrt.Translate(conf)
+			require.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				require.NoError(t, err)
+				gotCfg, ok := got.(*deltatorateprocessor.Config)
+				require.True(t, ok)
+				assert.Equal(t, testCase.want.Metrics, gotCfg.Metrics)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricsgenerationprocessor
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsgenerationprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const rulesKey = "rules"
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.Translator[component.Config] = (*translator)(nil)
+
+func NewTranslator() common.Translator[component.Config] {
+	return NewTranslatorWithName("")
+}
+
+func NewTranslatorWithName(name string) common.Translator[component.Config] {
+	return &translator{name: name, factory: metricsgenerationprocessor.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// Translate creates a processor config that generates new metrics from the
+// derived_metrics expressions in the Metrics section of the JSON config. The
+// expression fields map directly onto the upstream generation rules.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(common.MetricsDerivedExpressionsKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.MetricsDerivedExpressionsKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*metricsgenerationprocessor.Config)
+	c := confmap.NewFromStringMap(map[string]any{
+		rulesKey: conf.Get(common.MetricsDerivedExpressionsKey),
+	})
+	if err := c.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal metrics generation processor: %w", err)
+	}
+	return cfg, nil
+}
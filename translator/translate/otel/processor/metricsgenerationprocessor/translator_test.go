@@ -0,0 +1,91 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricsgenerationprocessor
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsgenerationprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	mgt := NewTranslator()
+	require.EqualValues(t, "experimental_metricsgeneration", mgt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *metricsgenerationprocessor.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{
+				ID:      mgt.ID(),
+				JsonKey: common.MetricsDerivedExpressionsKey,
+			},
+		},
+		"WithExpressions": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"derived_metrics": map[string]interface{}{
+						"expressions": []interface{}{
+							map[string]interface{}{
+								"name":      "disk_used_pct",
+								"unit":      "Percent",
+								"type":      "calculate",
+								"metric1":   "disk_used",
+								"metric2":   "disk_total",
+								"operation": "percent",
+							},
+							map[string]interface{}{
+								"name":      "mem_used_mb",
+								"type":      "scale",
+								"metric1":   "mem_used",
+								"operation": "divide",
+								"scale_by":  1048576,
+							},
+						},
+					},
+				},
+			},
+			want: &metricsgenerationprocessor.Config{
+				Rules: []metricsgenerationprocessor.Rule{
+					{
+						Name:      "disk_used_pct",
+						Unit:      "Percent",
+						Type:      "calculate",
+						Metric1:   "disk_used",
+						Metric2:   "disk_total",
+						Operation: "percent",
+					},
+					{
+						Name:      "mem_used_mb",
+						Type:      "scale",
+						Metric1:   "mem_used",
+						Operation: "divide",
+						ScaleBy:   1048576,
+					},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := mgt.Translate(conf)
+			require.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				require.NoError(t, err)
+				gotCfg, ok := got.(*metricsgenerationprocessor.Config)
+				require.True(t, ok)
+				assert.Equal(t, testCase.want.Rules, gotCfg.Rules)
+				assert.NoError(t, gotCfg.Validate())
+			}
+		})
+	}
+}
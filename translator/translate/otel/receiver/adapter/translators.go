@@ -206,6 +206,15 @@ func fromMultipleInput(conf *confmap.Conf, inputName, os string) common.Translat
 			// and setting dirrectly
 			psKey := procStatKey.(map[string]interface{})
 			psCollectionInterval, _ := common.ParseDuration(psKey[common.MetricsCollectionIntervalKey])
+			if procstat.IsAggregate(psKey) {
+				translators.Set(NewTranslatorWithName(
+					procstat.AggregateName(psKey),
+					procstat.AggregateSectionKey,
+					cfgKey,
+					psCollectionInterval,
+					defaultMetricsCollectionInterval))
+				continue
+			}
 
 			// Array type validation needs to be specific https://stackoverflow.com/a/47989212
 			for _, procstatMonitored := range procstatMonitoredSet {
@@ -25,6 +25,7 @@ func TestFindReceiversInConfig(t *testing.T) {
 	telegrafNvidiaSmiType, _ := component.NewType("telegraf_nvidia_smi")
 	telegrafStatsdType, _ := component.NewType("telegraf_statsd")
 	telegrafProcstatType, _ := component.NewType("telegraf_procstat")
+	telegrafProcstatAggregateType, _ := component.NewType("telegraf_procstat_aggregate")
 	telegrafWinPerfCountersType, _ := component.NewType("telegraf_win_perf_counters")
 	type wantResult struct {
 		cfgKey   string
@@ -72,6 +73,34 @@ func TestFindReceiversInConfig(t *testing.T) {
 				component.NewIDWithName(telegrafProcstatType, "3599690165"): {"metrics::metrics_collected::procstat", time.Minute},
 			},
 		},
+		"WithProcstatAggregate": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"procstat": []interface{}{
+							map[string]interface{}{
+								"exe": "amazon-cloudwatch-agent",
+							},
+							map[string]interface{}{
+								"aggregate_by": "user",
+							},
+							map[string]interface{}{
+								"aggregate_by":                "command",
+								"top_n":                       5,
+								"exe":                         "java",
+								"metrics_collection_interval": 10,
+							},
+						},
+					},
+				},
+			},
+			os: translatorconfig.OS_TYPE_LINUX,
+			want: map[component.ID]wantResult{
+				component.NewIDWithName(telegrafProcstatType, "793254176"):           {"metrics::metrics_collected::procstat", time.Minute},
+				component.NewIDWithName(telegrafProcstatAggregateType, "3473149085"): {"metrics::metrics_collected::procstat", time.Minute},
+				component.NewIDWithName(telegrafProcstatAggregateType, "3049276682"): {"metrics::metrics_collected::procstat", time.Minute},
+			},
+		},
 		"WithWindowsMetrics": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{
@@ -6,6 +6,7 @@ package translate
 import (
 	"log"
 	"sort"
+	"strings"
 
 	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
@@ -40,6 +41,33 @@ func RegisterWindowsRule(fieldname string, r Rule) {
 type Translator struct {
 }
 
+// logEventKeys are the json paths of the metrics inputs that publish log events, which only the
+// cloudwatchlogs output of the logs section publishes.
+var logEventKeys = [][]string{
+	{"metrics", "metrics_collected", "statsd", "events"},
+	{"metrics", "metrics_collected", "endpoints", "failure_log_group_name"},
+}
+
+// checkLogEventDestinations reports the inputs that publish log events when there is no
+// cloudwatchlogs output, since the log agent would drop them.
+func checkLogEventDestinations(input map[string]interface{}) {
+	for _, keys := range logEventKeys {
+		var value interface{} = input
+		for _, key := range keys {
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				value = nil
+				break
+			}
+			value = m[key]
+		}
+		if value != nil {
+			translator.AddErrorMessages(GetCurPath()+strings.Join(keys, "/"),
+				"the logs section must be configured to publish the log events to CloudWatch Logs")
+		}
+	}
+}
+
 func (t *Translator) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
 	m := input.(map[string]interface{})
 	result := map[string]interface{}{}
@@ -98,6 +126,9 @@ func (t *Translator) ApplyRule(input interface{}) (returnKey string, returnVal i
 	if len(allInputPlugin) != 0 {
 		result["inputs"] = allInputPlugin
 	}
+	if _, ok := allOutputPlugin["cloudwatchlogs"]; !ok {
+		checkLogEventDestinations(m)
+	}
 	if len(allOutputPlugin) != 0 {
 		result["outputs"] = allOutputPlugin
 	}
@@ -0,0 +1,47 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package translate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestCheckLogEventDestinations(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  []string
+	}{
+		"WithoutLogEvents": {
+			input: `{"metrics":{"metrics_collected":{"statsd":{},"endpoints":{"urls":["http://localhost"]}}}}`,
+		},
+		"WithStatsdEvents": {
+			input: `{"metrics":{"metrics_collected":{"statsd":{"events":{"log_group_name":"statsd-events"}}}}}`,
+			want: []string{
+				"Under path : /metrics/metrics_collected/statsd/events | Error : the logs section must be configured to publish the log events to CloudWatch Logs",
+			},
+		},
+		"WithEndpointsFailureLog": {
+			input: `{"metrics":{"metrics_collected":{"endpoints":{"failure_log_group_name":"failures"}}}}`,
+			want: []string{
+				"Under path : /metrics/metrics_collected/endpoints/failure_log_group_name | Error : the logs section must be configured to publish the log events to CloudWatch Logs",
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			t.Cleanup(translator.ResetMessages)
+			var input map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			checkLogEventDestinations(input)
+			assert.ElementsMatch(t, testCase.want, translator.ErrorMessages)
+		})
+	}
+}
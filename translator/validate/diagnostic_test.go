@@ -0,0 +1,59 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	diagnostics := []Diagnostic{
+		{File: "config.json", Line: 3, Column: 5, Path: "/logs", Severity: SeverityWarning, Rule: ruleOverlappingFilePaths, Message: "overlap"},
+		{Severity: SeverityError, Rule: ruleMerge, Message: "conflict"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatText, diagnostics))
+	assert.Equal(t, "config.json:3:5: warning: overlap (overlapping-file-paths)\nerror: conflict (merge-conflict)\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, FormatJSON, diagnostics))
+	var gotJSON []Diagnostic
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &gotJSON))
+	assert.Equal(t, diagnostics, gotJSON)
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, FormatJSON, nil))
+	assert.Equal(t, "[]\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, FormatSARIF, diagnostics))
+	var gotSarif sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &gotSarif))
+	assert.Equal(t, sarifVersion, gotSarif.Version)
+	require.Len(t, gotSarif.Runs, 1)
+	run := gotSarif.Runs[0]
+	assert.Len(t, run.Tool.Driver.Rules, len(rules))
+	assert.Equal(t, []sarifResult{
+		{
+			RuleID:  ruleOverlappingFilePaths,
+			Level:   SeverityWarning,
+			Message: sarifMessage{Text: "overlap"},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: "config.json"},
+				Region:           &sarifRegion{StartLine: 3, StartColumn: 5},
+			}}},
+		},
+		{RuleID: ruleMerge, Level: SeverityError, Message: sarifMessage{Text: "conflict"}},
+	}, run.Results)
+
+	assert.Error(t, Write(&buf, "xml", diagnostics))
+	assert.True(t, HasErrors(diagnostics))
+	assert.False(t, HasErrors(diagnostics[:1]))
+}
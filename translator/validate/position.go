@@ -0,0 +1,105 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// positions maps the JSON pointers of the values in a json document to their offsets. Object
+// members are mapped to the offset of their key.
+type positions map[string]int
+
+func indexPositions(data []byte) (positions, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	p := positions{}
+	if err := p.index(dec, data, nil); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p positions) index(dec *json.Decoder, data []byte, path []string) error {
+	pointer := jsonPointer(path)
+	if _, ok := p[pointer]; !ok {
+		p[pointer] = skipSeparators(data, int(dec.InputOffset()))
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			start := skipSeparators(data, int(dec.InputOffset()))
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			child := append(path[:len(path):len(path)], key.(string))
+			p[jsonPointer(child)] = start
+			if err = p.index(dec, data, child); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err = p.index(dec, data, append(path[:len(path):len(path)], strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	}
+	return err
+}
+
+// locate returns the offset of the value at the path, or of its closest parent that has one.
+func (p positions) locate(path []string) int {
+	for i := len(path); i >= 0; i-- {
+		if offset, ok := p[jsonPointer(path[:i])]; ok {
+			return offset
+		}
+	}
+	return 0
+}
+
+// skipSeparators returns the offset of the next token after the offset.
+func skipSeparators(data []byte, offset int) int {
+	for offset < len(data) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// lineColumn converts an offset into a 1-based line and column. Columns count characters.
+func lineColumn(data []byte, offset int) (int, int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+	return line, utf8.RuneCount(before[lineStart:]) + 1
+}
+
+// jsonPointer formats the path as a JSON pointer, e.g. "/logs/logs_collected/files".
+func jsonPointer(path []string) string {
+	var sb strings.Builder
+	for _, key := range path {
+		sb.WriteByte('/')
+		sb.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(key))
+	}
+	return sb.String()
+}
@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositions(t *testing.T) {
+	data := []byte(`{
+  "a/b": [1, {"ü": "x"}],
+  "c": {
+    "d": null
+  }
+}`)
+	p, err := indexPositions(data)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		path       []string
+		wantLine   int
+		wantColumn int
+	}{
+		"Root":          {path: nil, wantLine: 1, wantColumn: 1},
+		"EscapedKey":    {path: []string{"a/b"}, wantLine: 2, wantColumn: 3},
+		"ArrayItem":     {path: []string{"a/b", "1"}, wantLine: 2, wantColumn: 14},
+		"AfterUnicode":  {path: []string{"a/b", "1", "ü"}, wantLine: 2, wantColumn: 15},
+		"Nested":        {path: []string{"c", "d"}, wantLine: 4, wantColumn: 5},
+		"MissingChild":  {path: []string{"c", "missing"}, wantLine: 3, wantColumn: 3},
+		"MissingParent": {path: []string{"missing", "child"}, wantLine: 1, wantColumn: 1},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			line, column := lineColumn(data, p.locate(testCase.path))
+			assert.Equal(t, testCase.wantLine, line)
+			assert.Equal(t, testCase.wantColumn, column)
+		})
+	}
+	assert.Equal(t, "/a~1b/1", jsonPointer([]string{"a/b", "1"}))
+}
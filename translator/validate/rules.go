@@ -0,0 +1,318 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	ruleOverlappingFilePaths   = "overlapping-file-paths"
+	ruleUnreachableDestination = "unreachable-destination"
+	ruleDimensionLimit         = "dimension-limit"
+	ruleRetentionConflict      = "retention-conflict"
+
+	// maxDimensions is the number of dimensions CloudWatch accepts per metric.
+	maxDimensions = 30
+	// maxDimensionNameLength is the length of the dimension names CloudWatch accepts.
+	maxDimensionNameLength = 255
+
+	keyLogs             = "logs"
+	keyLogsCollected    = "logs_collected"
+	keyFiles            = "files"
+	keyWindowsEvents    = "windows_events"
+	keyCollectList      = "collect_list"
+	keyFilePath         = "file_path"
+	keyLogGroupName     = "log_group_name"
+	keyLogStreamName    = "log_stream_name"
+	keyRetentionInDays  = "retention_in_days"
+	keyMetrics          = "metrics"
+	keyMetricsCollected = "metrics_collected"
+	keyAppendDimensions = "append_dimensions"
+	keyEndpointOverride = "endpoint_override"
+	defaultName         = "(default)"
+)
+
+// logEventPaths are the json paths of the metrics inputs that publish log events, which only the
+// cloudwatchlogs output of the logs section publishes.
+var logEventPaths = [][]string{
+	{keyMetrics, keyMetricsCollected, "statsd", "events"},
+	{keyMetrics, keyMetricsCollected, "endpoints", "failure_log_group_name"},
+}
+
+type rule struct {
+	id          string
+	description string
+	check       func([]*document) []Diagnostic
+}
+
+// rules are the rules diagnostics are reported for. The rules without a check are applied
+// while the files are parsed and merged.
+var rules = []rule{
+	{id: ruleSyntax, description: "The json config files must be valid JSON objects."},
+	{id: ruleSchema, description: "The json config files must match the agent json schema."},
+	{id: ruleResolve, description: "The references and $include directive of the json config files must resolve."},
+	{id: ruleMerge, description: "The json config files must not conflict with each other when they are merged."},
+	{
+		id:          ruleOverlappingFilePaths,
+		description: "Log files collected by more than one collect_list entry must not be sent to the same log group and stream.",
+		check:       checkOverlappingFilePaths,
+	},
+	{
+		id:          ruleUnreachableDestination,
+		description: "Endpoint overrides must be valid http(s) URLs or host names, and inputs publishing log events require the logs section, for data to reach them.",
+		check:       checkUnreachableDestinations,
+	},
+	{
+		id:          ruleDimensionLimit,
+		description: "Metrics must not be appended more dimensions or longer dimension names than CloudWatch accepts.",
+		check:       checkDimensionLimits,
+	},
+	{
+		id:          ruleRetentionConflict,
+		description: "Log groups must not be configured with different retention_in_days.",
+		check:       checkRetentionConflicts,
+	},
+}
+
+// collectEntry is a collect_list entry of a json config file.
+type collectEntry struct {
+	doc   *document
+	path  []string
+	entry map[string]interface{}
+}
+
+func (e collectEntry) location() string {
+	line, _ := lineColumn(e.doc.data, e.doc.positions.locate(e.path))
+	return fmt.Sprintf("%s:%d", e.doc.file, line)
+}
+
+func collectEntries(documents []*document, section string) []collectEntry {
+	var entries []collectEntry
+	for _, doc := range documents {
+		path := []string{keyLogs, keyLogsCollected, section, keyCollectList}
+		list, _ := lookup(doc.config, path...).([]interface{})
+		for i, item := range list {
+			if entry, ok := item.(map[string]interface{}); ok {
+				entries = append(entries, collectEntry{doc: doc, path: append(path[:len(path):len(path)], strconv.Itoa(i)), entry: entry})
+			}
+		}
+	}
+	return entries
+}
+
+func checkOverlappingFilePaths(documents []*document) []Diagnostic {
+	var defaultStream string
+	for _, doc := range documents {
+		if stream, ok := lookup(doc.config, keyLogs, keyLogStreamName).(string); ok {
+			defaultStream = stream
+			break
+		}
+	}
+	var diagnostics []Diagnostic
+	entries := collectEntries(documents, keyFiles)
+	for i, entry := range entries {
+		filePath, ok := entry.entry[keyFilePath].(string)
+		if !ok {
+			continue
+		}
+		group, _ := entry.entry[keyLogGroupName].(string)
+		stream, ok := entry.entry[keyLogStreamName].(string)
+		if !ok {
+			stream = defaultStream
+		}
+		for _, other := range entries[:i] {
+			otherFilePath, _ := other.entry[keyFilePath].(string)
+			otherGroup, _ := other.entry[keyLogGroupName].(string)
+			otherStream, ok := other.entry[keyLogStreamName].(string)
+			if !ok {
+				otherStream = defaultStream
+			}
+			if group != otherGroup || stream != otherStream || !pathsOverlap(filePath, otherFilePath) {
+				continue
+			}
+			diagnostics = append(diagnostics, entry.doc.diagnostic(append(entry.path, keyFilePath), SeverityWarning, ruleOverlappingFilePaths,
+				fmt.Sprintf("file_path %q overlaps %q at %s, both are sent to log group %s and log stream %s",
+					filePath, otherFilePath, other.location(), describe(group), describe(stream))))
+			break
+		}
+	}
+	return diagnostics
+}
+
+// pathsOverlap reports whether the file paths can match the same file.
+func pathsOverlap(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if matched, err := filepath.Match(a, b); err == nil && matched {
+		return true
+	}
+	matched, err := filepath.Match(b, a)
+	return err == nil && matched
+}
+
+func checkRetentionConflicts(documents []*document) []Diagnostic {
+	type retention struct {
+		days  int
+		entry collectEntry
+	}
+	var diagnostics []Diagnostic
+	retentions := map[string]retention{}
+	entries := append(collectEntries(documents, keyFiles), collectEntries(documents, keyWindowsEvents)...)
+	for _, entry := range entries {
+		group, _ := entry.entry[keyLogGroupName].(string)
+		days, ok := entry.entry[keyRetentionInDays].(float64)
+		// retention below 1 leaves the retention of the log group unchanged
+		if group == "" || !ok || days < 1 {
+			continue
+		}
+		first, ok := retentions[group]
+		if !ok {
+			retentions[group] = retention{days: int(days), entry: entry}
+			continue
+		}
+		if first.days != int(days) {
+			diagnostics = append(diagnostics, entry.doc.diagnostic(append(entry.path, keyRetentionInDays), SeverityError, ruleRetentionConflict,
+				fmt.Sprintf("retention_in_days %d of log group %q conflicts with %d at %s", int(days), group, first.days, first.entry.location())))
+		}
+	}
+	return diagnostics
+}
+
+func checkDimensionLimits(documents []*document) []Diagnostic {
+	var diagnostics []Diagnostic
+	var global map[string]interface{}
+	for _, doc := range documents {
+		path := []string{keyMetrics, keyAppendDimensions}
+		if dimensions, ok := lookup(doc.config, path...).(map[string]interface{}); ok {
+			if global == nil {
+				global = dimensions
+			}
+			diagnostics = append(diagnostics, checkDimensionNames(doc, path, dimensions)...)
+		}
+	}
+	for _, doc := range documents {
+		plugins, _ := lookup(doc.config, keyMetrics, keyMetricsCollected).(map[string]interface{})
+		for _, plugin := range sortedKeys(plugins) {
+			path := []string{keyMetrics, keyMetricsCollected, plugin, keyAppendDimensions}
+			dimensions, ok := lookup(doc.config, path...).(map[string]interface{})
+			if !ok {
+				continue
+			}
+			diagnostics = append(diagnostics, checkDimensionNames(doc, path, dimensions)...)
+			count := len(dimensions)
+			for name := range global {
+				if _, ok := dimensions[name]; !ok {
+					count++
+				}
+			}
+			if count > maxDimensions {
+				diagnostics = append(diagnostics, doc.diagnostic(path, SeverityWarning, ruleDimensionLimit,
+					fmt.Sprintf("%s metrics are appended %d dimensions, CloudWatch accepts at most %d per metric", plugin, count, maxDimensions)))
+			}
+		}
+	}
+	return diagnostics
+}
+
+func checkDimensionNames(doc *document, path []string, dimensions map[string]interface{}) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, name := range sortedKeys(dimensions) {
+		if len(name) > maxDimensionNameLength {
+			diagnostics = append(diagnostics, doc.diagnostic(append(path[:len(path):len(path)], name), SeverityWarning, ruleDimensionLimit,
+				fmt.Sprintf("dimension name is %d characters long, CloudWatch accepts at most %d", len(name), maxDimensionNameLength)))
+		}
+	}
+	return diagnostics
+}
+
+func checkUnreachableDestinations(documents []*document) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, doc := range documents {
+		walk(doc.config, nil, func(path []string, value interface{}) {
+			endpoint, ok := value.(string)
+			if path[len(path)-1] != keyEndpointOverride || !ok || validEndpoint(endpoint) {
+				return
+			}
+			diagnostics = append(diagnostics, doc.diagnostic(path, SeverityWarning, ruleUnreachableDestination,
+				fmt.Sprintf("endpoint_override %q is not a valid endpoint, nothing can be sent to it", endpoint)))
+		})
+	}
+	for _, doc := range documents {
+		if lookup(doc.config, keyLogs) != nil {
+			return diagnostics
+		}
+	}
+	for _, doc := range documents {
+		for _, path := range logEventPaths {
+			if lookup(doc.config, path...) != nil {
+				diagnostics = append(diagnostics, doc.diagnostic(path, SeverityError, ruleUnreachableDestination,
+					fmt.Sprintf("%s publishes log events but there is no logs section, nothing can be sent to CloudWatch Logs", path[len(path)-2])))
+			}
+		}
+	}
+	return diagnostics
+}
+
+// validEndpoint reports whether the endpoint is an http(s) URL or a host name, which the agent
+// sends to over https.
+func validEndpoint(endpoint string) bool {
+	if strings.ContainsAny(endpoint, " \t\r\n") {
+		return false
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Hostname() != ""
+}
+
+// walk calls fn with the path and value of every object member below the value.
+func walk(value interface{}, path []string, fn func([]string, interface{})) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			child := append(path[:len(path):len(path)], key)
+			fn(child, v[key])
+			walk(v[key], child, fn)
+		}
+	case []interface{}:
+		for i, item := range v {
+			walk(item, append(path[:len(path):len(path)], strconv.Itoa(i)), fn)
+		}
+	}
+}
+
+func lookup(value interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func describe(name string) string {
+	if name == "" {
+		return defaultName
+	}
+	return strconv.Quote(name)
+}
@@ -0,0 +1,235 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig"
+)
+
+const (
+	ruleSyntax  = "json-syntax"
+	ruleSchema  = "schema"
+	ruleMerge   = "merge-conflict"
+	ruleResolve = "resolve"
+
+	// schemaPathDelimiter separates the keys of the gojsonschema contexts, since the keys can
+	// contain the default "." delimiter.
+	schemaPathDelimiter = "\x00"
+	schemaRoot          = "(root)"
+	// schemaAllOfErrorType is reported in addition to the errors of the schemas it combines.
+	schemaAllOfErrorType              = "number_all_of"
+	schemaAdditionalPropertyErrorType = "additional_property_not_allowed"
+)
+
+// document is a parsed json config file.
+type document struct {
+	file      string
+	data      []byte
+	config    map[string]interface{}
+	positions positions
+}
+
+func (d *document) diagnostic(path []string, severity Severity, rule, message string) Diagnostic {
+	line, column := lineColumn(d.data, d.positions.locate(path))
+	return Diagnostic{
+		File:     d.file,
+		Line:     line,
+		Column:   column,
+		Path:     jsonPointer(path),
+		Severity: severity,
+		Rule:     rule,
+		Message:  message,
+	}
+}
+
+// Validate validates the json config files. It reports syntax and schema errors with the line
+// and column they are at, the conflicts between the files and the semantic problems found by
+// the lint rules. Relative $include paths are relative to the config dir, or to the including
+// file if it is empty. The returned error is only set when a file cannot be read.
+func Validate(files []string, configDir string) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	var parsed []*document
+	included := map[string]bool{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		doc, diagnostic := parse(file, data)
+		if doc == nil {
+			diagnostics = append(diagnostics, diagnostic)
+			continue
+		}
+		resolved, includedPaths, err := jsonconfig.ResolveJsonConfig(doc.config, file, filepath.Dir(file), configDir)
+		if err != nil {
+			diagnostics = append(diagnostics, Diagnostic{File: file, Severity: SeverityError, Rule: ruleResolve, Message: err.Error()})
+			continue
+		}
+		doc.config = resolved
+		for _, path := range includedPaths {
+			included[path] = true
+		}
+		parsed = append(parsed, doc)
+	}
+	// the files included by other files are only validated on their own
+	var documents []*document
+	for _, doc := range parsed {
+		if abs, err := filepath.Abs(doc.file); err != nil || !included[abs] {
+			documents = append(documents, doc)
+		}
+	}
+	for _, doc := range parsed {
+		diagnostics = append(diagnostics, validateSchema(doc.config, func(errorType string) bool {
+			return len(documents) == 1 || !jsonconfig.MergedSchemaErrorTypes[errorType]
+		}, doc.diagnostic)...)
+	}
+	for _, rule := range rules {
+		if rule.check != nil {
+			diagnostics = append(diagnostics, rule.check(documents)...)
+		}
+	}
+	if len(documents) > 1 {
+		merged, mergeDiagnostics := validateMerge(documents)
+		diagnostics = append(diagnostics, mergeDiagnostics...)
+		if merged != nil {
+			diagnostics = append(diagnostics, validateSchema(merged, func(errorType string) bool {
+				return jsonconfig.MergedSchemaErrorTypes[errorType]
+			}, func(path []string, severity Severity, rule, message string) Diagnostic {
+				return locateInDocuments(documents, path).diagnostic(path, severity, rule, message)
+			})...)
+		}
+	}
+	sortDiagnostics(diagnostics)
+	return diagnostics, nil
+}
+
+func parse(file string, data []byte) (*document, Diagnostic) {
+	doc := &document{file: file, data: data}
+	err := json.Unmarshal(data, &doc.config)
+	if err == nil && doc.config == nil {
+		err = errors.New("json config must be an object")
+	}
+	if err == nil {
+		doc.positions, err = indexPositions(data)
+	}
+	if err == nil {
+		return doc, Diagnostic{}
+	}
+	offset := 0
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) {
+		offset = int(syntaxErr.Offset) - 1
+	} else if errors.As(err, &typeErr) {
+		offset = int(typeErr.Offset) - 1
+	}
+	line, column := lineColumn(data, max(offset, 0))
+	return nil, Diagnostic{
+		File:     file,
+		Line:     line,
+		Column:   column,
+		Severity: SeverityError,
+		Rule:     ruleSyntax,
+		Message:  err.Error(),
+	}
+}
+
+// validateSchema validates the config against the json schema and reports the included error
+// types.
+func validateSchema(c map[string]interface{}, include func(errorType string) bool, diagnostic func(path []string, severity Severity, rule, message string) Diagnostic) []Diagnostic {
+	result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(config.GetJsonSchema()), gojsonschema.NewGoLoader(c))
+	if err != nil {
+		return []Diagnostic{diagnostic(nil, SeverityError, ruleSchema, err.Error())}
+	}
+	var diagnostics []Diagnostic
+	for _, resultErr := range result.Errors() {
+		// the schemas that failed are reported on their own
+		if resultErr.Type() == schemaAllOfErrorType || !include(resultErr.Type()) {
+			continue
+		}
+		path := schemaPath(resultErr.Context())
+		message := resultErr.Description()
+		// point at the property itself rather than at the object it is in
+		if property, ok := resultErr.Details()["property"].(string); ok && resultErr.Type() == schemaAdditionalPropertyErrorType {
+			path = append(path, property)
+		}
+		if field := resultErr.Field(); field != schemaRoot {
+			message = field + ": " + message
+		}
+		diagnostics = append(diagnostics, diagnostic(path, SeverityError, ruleSchema, message))
+	}
+	return diagnostics
+}
+
+// locateInDocuments returns the first document with the value at the path, or else the first
+// one with its closest parent.
+func locateInDocuments(documents []*document, path []string) *document {
+	for i := len(path); i > 0; i-- {
+		pointer := jsonPointer(path[:i])
+		for _, doc := range documents {
+			if _, ok := doc.positions[pointer]; ok {
+				return doc
+			}
+		}
+	}
+	return documents[0]
+}
+
+func schemaPath(context *gojsonschema.JsonContext) []string {
+	if context == nil {
+		return nil
+	}
+	path := strings.Split(context.String(schemaPathDelimiter), schemaPathDelimiter)
+	if len(path) > 0 && path[0] == schemaRoot {
+		path = path[1:]
+	}
+	return path
+}
+
+// validateMerge merges the files the way the config-translator does and reports the conflicts
+// of each file with the files before it. The merged config is nil if there are any.
+func validateMerge(documents []*document) (merged map[string]interface{}, diagnostics []Diagnostic) {
+	// the merge modifies the maps, so it is done after the other rules
+	jsonConfigMapMap := make(map[string]map[string]interface{}, len(documents))
+	for _, doc := range documents {
+		jsonConfigMapMap[doc.file] = doc.config
+	}
+	translator.ResetMessages()
+	defer func() {
+		if r := recover(); r != nil {
+			for _, message := range translator.ErrorMessages {
+				diagnostics = append(diagnostics, Diagnostic{Severity: SeverityError, Rule: ruleMerge, Message: message})
+			}
+			if len(diagnostics) == 0 {
+				diagnostics = append(diagnostics, Diagnostic{Severity: SeverityError, Rule: ruleMerge, Message: fmt.Sprint(r)})
+			}
+			merged = nil
+		}
+		translator.ResetMessages()
+	}()
+	merged, fragments, err := jsonconfig.MergeJsonConfigFragments(jsonConfigMapMap, nil, "remove")
+	if err != nil {
+		return nil, []Diagnostic{{Severity: SeverityError, Rule: ruleMerge, Message: err.Error()}}
+	}
+	for _, f := range fragments {
+		for _, message := range f.Errors {
+			diagnostics = append(diagnostics, Diagnostic{File: f.Path, Severity: SeverityError, Rule: ruleMerge, Message: message})
+		}
+	}
+	if len(diagnostics) > 0 {
+		return nil, diagnostics
+	}
+	return merged, nil
+}
@@ -0,0 +1,260 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := map[string]struct {
+		files []string
+		want  []Diagnostic
+	}{
+		"WithValid": {
+			files: []string{`{
+  "metrics": {
+    "metrics_collected": {
+      "cpu": {"measurement": ["usage_idle"]}
+    }
+  }
+}`},
+		},
+		"WithSyntaxError": {
+			files: []string{`{
+  "metrics": {
+    "metrics_collected": {,
+  }
+}`},
+			want: []Diagnostic{
+				{File: "0.json", Line: 3, Column: 27, Severity: SeverityError, Rule: ruleSyntax, Message: "invalid character ',' looking for beginning of object key string"},
+			},
+		},
+		"WithNotObject": {
+			files: []string{`[]`},
+			want: []Diagnostic{
+				{File: "0.json", Line: 1, Column: 1, Severity: SeverityError, Rule: ruleSyntax, Message: "json: cannot unmarshal array into Go value of type map[string]interface {}"},
+			},
+		},
+		"WithSchemaErrors": {
+			files: []string{`{
+  "metrics": {
+    "metrics_collected": {
+      "cpu": {"measurement": "usage_idle"}
+    },
+    "unknown": true
+  }
+}`},
+			want: []Diagnostic{
+				{File: "0.json", Line: 4, Column: 15, Path: "/metrics/metrics_collected/cpu/measurement", Severity: SeverityError, Rule: ruleSchema, Message: "metrics.metrics_collected.cpu.measurement: Invalid type. Expected: array, given: string"},
+				{File: "0.json", Line: 6, Column: 5, Path: "/metrics/unknown", Severity: SeverityError, Rule: ruleSchema, Message: "metrics: Additional property unknown is not allowed"},
+			},
+		},
+		"WithRequiredInOtherFile": {
+			files: []string{
+				`{"logs": {"log_stream_name": "stream"}}`,
+				`{"logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/app.log"}]}}}}`,
+			},
+		},
+		"WithRequiredInNoFile": {
+			files: []string{
+				`{"metrics": {"metrics_collected": {"cpu": {"measurement": ["usage_idle"]}}}}`,
+				`{
+  "logs": {"log_stream_name": "stream"}
+}`,
+			},
+			want: []Diagnostic{
+				{File: "1.json", Line: 2, Column: 3, Path: "/logs", Severity: SeverityError, Rule: ruleSchema, Message: "logs: Must validate at least one schema (anyOf)"},
+				{File: "1.json", Line: 2, Column: 3, Path: "/logs", Severity: SeverityError, Rule: ruleSchema, Message: "logs: logs_collected is required"},
+			},
+		},
+		"WithInclude": {
+			files: []string{
+				`{"$include": ["1.json"], "logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/app.log"}]}}}}`,
+				`{"logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/other.log"}]}}}}`,
+			},
+		},
+		"WithResolveError": {
+			files: []string{`{"agent": {"region": "${env:CWA_TEST_UNSET}"}}`},
+			want: []Diagnostic{
+				{File: "0.json", Severity: SeverityError, Rule: ruleResolve, Message: "0.json: /agent/region: environment variable CWA_TEST_UNSET is not set"},
+			},
+		},
+		"WithMergeConflict": {
+			files: []string{
+				`{"agent": {"metrics_collection_interval": 60}, "metrics": {"metrics_collected": {"cpu": {"measurement": ["usage_idle"]}}}}`,
+				`{"agent": {"metrics_collection_interval": 30}}`,
+			},
+			want: []Diagnostic{
+				{File: "1.json", Severity: SeverityError, Rule: ruleMerge, Message: "Under path : /agent/metrics_collection_interval | Error : Different values are specified for metrics_collection_interval"},
+			},
+		},
+		"WithInvalidFragment": {
+			files: []string{`{"$fragment": {"name": "", "team": "web"}}`},
+			want: []Diagnostic{
+				{File: "0.json", Line: 1, Column: 16, Path: "/$fragment/name", Severity: SeverityError, Rule: ruleSchema, Message: "$fragment.name: String length must be greater than or equal to 1"},
+				{File: "0.json", Line: 1, Column: 28, Path: "/$fragment/team", Severity: SeverityError, Rule: ruleSchema, Message: "$fragment: Additional property team is not allowed"},
+			},
+		},
+		"WithOverlappingFilePaths": {
+			files: []string{
+				`{
+  "logs": {
+    "log_stream_name": "stream",
+    "logs_collected": {
+      "files": {
+        "collect_list": [
+          {"file_path": "/var/log/*.log", "log_group_name": "app"},
+          {"file_path": "/var/log/other.log", "log_group_name": "other"}
+        ]
+      }
+    }
+  }
+}`,
+				`{
+  "logs": {
+    "logs_collected": {
+      "files": {
+        "collect_list": [
+          {"file_path": "/var/log/app.log", "log_group_name": "app"},
+          {"file_path": "/var/log/app.log", "log_group_name": "app", "log_stream_name": "other"}
+        ]
+      }
+    }
+  }
+}`,
+			},
+			want: []Diagnostic{
+				{File: "1.json", Line: 6, Column: 12, Path: "/logs/logs_collected/files/collect_list/0/file_path", Severity: SeverityWarning, Rule: ruleOverlappingFilePaths, Message: `file_path "/var/log/app.log" overlaps "/var/log/*.log" at 0.json:7, both are sent to log group "app" and log stream "stream"`},
+			},
+		},
+		"WithRetentionConflict": {
+			files: []string{`{
+  "logs": {
+    "logs_collected": {
+      "files": {
+        "collect_list": [
+          {"file_path": "/var/log/app.log", "log_group_name": "app", "retention_in_days": 7},
+          {"file_path": "/var/log/app.err", "log_group_name": "app", "retention_in_days": -1}
+        ]
+      },
+      "windows_events": {
+        "collect_list": [
+          {"event_name": "System", "event_levels": ["ERROR"], "log_group_name": "app", "retention_in_days": 14}
+        ]
+      }
+    }
+  }
+}`},
+			want: []Diagnostic{
+				{File: "0.json", Line: 12, Column: 88, Path: "/logs/logs_collected/windows_events/collect_list/0/retention_in_days", Severity: SeverityError, Rule: ruleRetentionConflict, Message: `retention_in_days 14 of log group "app" conflicts with 7 at 0.json:6`},
+			},
+		},
+		"WithDimensionLimit": {
+			files: []string{`{
+  "metrics": {
+    "append_dimensions": {"InstanceId": "${aws:InstanceId}", "` + strings.Repeat("d", 256) + `": "value"},
+    "metrics_collected": {
+      "cpu": {
+        "measurement": ["usage_idle"],
+        "append_dimensions": {` + dimensions(29) + `}
+      },
+      "mem": {
+        "measurement": ["used"],
+        "append_dimensions": {` + dimensions(28) + `}
+      }
+    }
+  }
+}`},
+			want: []Diagnostic{
+				{File: "0.json", Line: 3, Column: 62, Path: "/metrics/append_dimensions/" + strings.Repeat("d", 256), Severity: SeverityWarning, Rule: ruleDimensionLimit, Message: "dimension name is 256 characters long, CloudWatch accepts at most 255"},
+				{File: "0.json", Line: 7, Column: 9, Path: "/metrics/metrics_collected/cpu/append_dimensions", Severity: SeverityWarning, Rule: ruleDimensionLimit, Message: "cpu metrics are appended 31 dimensions, CloudWatch accepts at most 30 per metric"},
+			},
+		},
+		"WithUnreachableDestination": {
+			files: []string{`{
+  "logs": {
+    "endpoint_override": "logs.example.com",
+    "logs_collected": {
+      "files": {
+        "collect_list": [{"file_path": "/var/log/app.log"}]
+      }
+    }
+  },
+  "metrics": {
+    "endpoint_override": "not a host",
+    "metrics_collected": {
+      "cpu": {"measurement": ["usage_idle"]}
+    }
+  }
+}`},
+			want: []Diagnostic{
+				{File: "0.json", Line: 11, Column: 5, Path: "/metrics/endpoint_override", Severity: SeverityWarning, Rule: ruleUnreachableDestination, Message: `endpoint_override "not a host" is not a valid endpoint, nothing can be sent to it`},
+			},
+		},
+		"WithLogEventsWithoutLogs": {
+			files: []string{`{
+  "metrics": {
+    "metrics_collected": {
+      "statsd": {
+        "events": {"log_group_name": "statsd-events"}
+      },
+      "endpoints": {
+        "urls": ["https://localhost/health"],
+        "failure_log_group_name": "failures",
+        "measurement": ["success"]
+      }
+    }
+  }
+}`},
+			want: []Diagnostic{
+				{File: "0.json", Line: 5, Column: 9, Path: "/metrics/metrics_collected/statsd/events", Severity: SeverityError, Rule: ruleUnreachableDestination, Message: "statsd publishes log events but there is no logs section, nothing can be sent to CloudWatch Logs"},
+				{File: "0.json", Line: 9, Column: 9, Path: "/metrics/metrics_collected/endpoints/failure_log_group_name", Severity: SeverityError, Rule: ruleUnreachableDestination, Message: "endpoints publishes log events but there is no logs section, nothing can be sent to CloudWatch Logs"},
+			},
+		},
+		"WithLogEventsAndLogsInAnotherFile": {
+			files: []string{
+				`{"metrics": {"metrics_collected": {"statsd": {"events": {"log_group_name": "statsd-events"}}}}}`,
+				`{"logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/app.log"}]}}}}`,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			var files []string
+			for i, content := range testCase.files {
+				file := filepath.Join(dir, strconv.Itoa(i)+".json")
+				require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+				files = append(files, file)
+			}
+			got, err := Validate(files, dir)
+			require.NoError(t, err)
+			for i := range got {
+				got[i].File = strings.TrimPrefix(got[i].File, dir+string(filepath.Separator))
+				got[i].Message = strings.ReplaceAll(got[i].Message, dir+string(filepath.Separator), "")
+			}
+			assert.Equal(t, testCase.want, got)
+			assert.Equal(t, len(testCase.want) > 0 && testCase.want[0].Severity == SeverityError, HasErrors(got))
+		})
+	}
+
+	_, err := Validate([]string{filepath.Join(t.TempDir(), "missing.json")}, "")
+	assert.Error(t, err)
+}
+
+func dimensions(n int) string {
+	var pairs []string
+	for i := 0; i < n; i++ {
+		pairs = append(pairs, `"Dimension`+strconv.Itoa(i)+`": "value"`)
+	}
+	return strings.Join(pairs, ", ")
+}